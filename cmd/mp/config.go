@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// config is the config file, $XDG_CONFIG_HOME/mp/config.json by default:
+//
+//	{
+//	  "default_profile": "sandbox",
+//	  "profiles": {
+//	    "sandbox": {"access_token": "TEST-..."},
+//	    "production": {"access_token": "APP_USR-..."}
+//	  }
+//	}
+type config struct {
+	DefaultProfile string             `json:"default_profile"`
+	Profiles       map[string]profile `json:"profiles"`
+}
+
+// profile holds the credentials of an account.
+type profile struct {
+	AccessToken string `json:"access_token"`
+}
+
+// defaultConfigFile returns the path of the config file, or an empty string if there is no config directory.
+func defaultConfigFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "mp", "config.json")
+}
+
+// accessToken returns the access token of $MP_ACCESS_TOKEN or, if it is not set, of a profile of the config file.
+// An empty name selects the default profile of the file, or the profile named "default".
+func accessToken(file, name string) (string, error) {
+	if at := os.Getenv("MP_ACCESS_TOKEN"); at != "" && name == "" {
+		return at, nil
+	}
+	if file == "" {
+		return "", errors.New("no access token: set MP_ACCESS_TOKEN or use --config")
+	}
+
+	b, err := os.ReadFile(file)
+	if errors.Is(err, fs.ErrNotExist) && name == "" {
+		return "", fmt.Errorf("no access token: set MP_ACCESS_TOKEN or create %s", file)
+	}
+	if err != nil {
+		return "", err
+	}
+	var c config
+	if err := json.Unmarshal(b, &c); err != nil {
+		return "", fmt.Errorf("error reading %s: %w", file, err)
+	}
+
+	if name == "" {
+		name = c.DefaultProfile
+	}
+	if name == "" {
+		name = "default"
+	}
+	p, ok := c.Profiles[name]
+	if !ok {
+		return "", fmt.Errorf("profile %q not found in %s", name, file)
+	}
+	if p.AccessToken == "" {
+		return "", fmt.Errorf("profile %q of %s has no access token", name, file)
+	}
+	return p.AccessToken, nil
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+)
+
+// errDryRun is returned by dryRunClient instead of a response.
+var errDryRun = errors.New("dry run")
+
+// dryRunClient is a rest.Client printing the requests built by the resource clients instead of sending them.
+// The headers added when sending, like Authorization, are not printed.
+type dryRunClient struct {
+	w io.Writer
+}
+
+func (c *dryRunClient) Send(req *http.Request, opts ...rest.Option) ([]byte, error) {
+	dump, err := httputil.DumpRequestOut(req, true)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fmt.Fprintf(c.w, "%s\n", bytes.TrimRight(dump, "\r\n")); err != nil {
+		return nil, err
+	}
+	return nil, errDryRun
+}
@@ -0,0 +1,393 @@
+// Command mp operates the Mercado Pago API from a terminal.
+//
+// Usage:
+//
+//	mp [flags] payment get <id>
+//	mp [flags] payment search [--status approved] [--since 7d] [--until 1d] [--external-reference ref]
+//	mp [flags] payment cancel <id>
+//	mp [flags] payment capture <id> [--amount 10.5]
+//	mp [flags] refund create <id> [--amount 10.5]
+//	mp [flags] methods list
+//
+// The access token is read from the MP_ACCESS_TOKEN environment variable or, if it is not set,
+// from a profile of the config file, see config.go.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/money"
+	"github.com/gdeandradero/sdk-go/pkg/mp"
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+	"github.com/gdeandradero/sdk-go/pkg/payment"
+	"github.com/gdeandradero/sdk-go/pkg/paymentmethod"
+)
+
+const usage = `mp operates the Mercado Pago API from a terminal.
+
+Usage:
+  mp [flags] payment get <id>
+  mp [flags] payment search [--status approved] [--since 7d] [--until 1d] [--external-reference ref]
+  mp [flags] payment cancel <id>
+  mp [flags] payment capture <id> [--amount 10.5]
+  mp [flags] refund create <id> [--amount 10.5]
+  mp [flags] methods list
+
+Dates of --since and --until are days (7d) or months (3mo) ago, a duration ago (12h) or a date (2024-01-31).
+
+Flags:
+`
+
+// errUsage is returned when the command line is invalid, the usage is printed.
+var errUsage = errors.New("invalid usage")
+
+// options are the flags accepted by every command.
+type options struct {
+	output     string
+	profile    string
+	configFile string
+	dryRun     bool
+}
+
+// defaultOptions returns the options before parsing the flags.
+func defaultOptions() options {
+	return options{
+		output:     "table",
+		profile:    os.Getenv("MP_PROFILE"),
+		configFile: defaultConfigFile(),
+	}
+}
+
+// register adds the flags to fs, keeping the values already parsed as defaults.
+func (o *options) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.output, "o", o.output, "output format: table, json or yaml")
+	fs.StringVar(&o.output, "output", o.output, "output format: table, json or yaml")
+	fs.StringVar(&o.profile, "profile", o.profile, "profile of the config file, $MP_PROFILE by default")
+	fs.StringVar(&o.configFile, "config", o.configFile, "config file")
+	fs.BoolVar(&o.dryRun, "dry-run", o.dryRun, "print the HTTP requests instead of sending them")
+}
+
+// app runs the commands.
+type app struct {
+	options
+	stdout io.Writer
+	stderr io.Writer
+	now    func() time.Time
+
+	payments payment.Client
+	methods  paymentmethod.Client
+}
+
+func main() {
+	a := &app{
+		options: defaultOptions(),
+		stdout:  os.Stdout,
+		stderr:  os.Stderr,
+		now:     time.Now,
+	}
+	os.Exit(a.run(os.Args[1:]))
+}
+
+// run runs the command line and returns the exit code.
+func (a *app) run(args []string) int {
+	err := a.dispatch(args)
+	switch {
+	case err == nil, errors.Is(err, errDryRun):
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		a.usage()
+		return 0
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(a.stderr, "mp: %v\n\n", err)
+		a.usage()
+		return 2
+	default:
+		fmt.Fprintf(a.stderr, "mp: %v\n", err)
+		return 1
+	}
+}
+
+func (a *app) usage() {
+	fmt.Fprint(a.stderr, usage)
+	fs := flag.NewFlagSet("mp", flag.ContinueOnError)
+	o := defaultOptions()
+	o.register(fs)
+	fs.SetOutput(a.stderr)
+	fs.PrintDefaults()
+}
+
+func (a *app) dispatch(args []string) error {
+	fs := a.flagSet("mp")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	args = fs.Args()
+	if len(args) < 2 {
+		return fmt.Errorf("%w: missing command", errUsage)
+	}
+
+	switch args[0] + " " + args[1] {
+	case "payment get":
+		return a.paymentGet(args[2:])
+	case "payment search":
+		return a.paymentSearch(args[2:])
+	case "payment cancel":
+		return a.paymentCancel(args[2:])
+	case "payment capture":
+		return a.paymentCapture(args[2:])
+	case "refund create":
+		return a.refundCreate(args[2:])
+	case "methods list":
+		return a.methodsList(args[2:])
+	}
+	return fmt.Errorf("%w: unknown command %q", errUsage, args[0]+" "+args[1])
+}
+
+// flagSet returns a flag set with the flags of every command.
+func (a *app) flagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	a.options.register(fs)
+	return fs
+}
+
+// parse parses the flags of a command, which may come before or after its arguments,
+// and returns the arguments. It fails unless there are n arguments.
+func (a *app) parse(fs *flag.FlagSet, args []string, n int) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w: %v", errUsage, err)
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+	if len(positional) != n {
+		return nil, fmt.Errorf("%w: %s expects %d argument(s), got %d", errUsage, fs.Name(), n, len(positional))
+	}
+	return positional, nil
+}
+
+// connect creates the clients, sending the requests or printing them on a dry run.
+func (a *app) connect() error {
+	if a.payments != nil {
+		return nil
+	}
+
+	var rc rest.Client
+	if a.dryRun {
+		rc = &dryRunClient{w: a.stdout}
+	} else {
+		token, err := accessToken(a.configFile, a.profile)
+		if err != nil {
+			return err
+		}
+		rc = mp.NewRestClient(token)
+	}
+	a.payments = payment.NewClient(rc)
+	a.methods = paymentmethod.NewClient(rc)
+	return nil
+}
+
+func (a *app) paymentGet(args []string) error {
+	fs := a.flagSet("payment get")
+	args, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(args[0])
+	if err != nil {
+		return err
+	}
+	if err := a.connect(); err != nil {
+		return err
+	}
+
+	p, err := a.payments.Get(id)
+	if err != nil {
+		return err
+	}
+	return a.write(p, func(t *table) { t.payments(*p) })
+}
+
+func (a *app) paymentSearch(args []string) error {
+	fs := a.flagSet("payment search")
+	var f payment.Filters
+	var since, until string
+	fs.StringVar(&f.Status, "status", "", "status of the payments, e.g. approved")
+	fs.StringVar(&since, "since", "", "start of the search interval")
+	fs.StringVar(&until, "until", "", "end of the search interval")
+	fs.StringVar(&f.Range, "range", "", "date the interval refers to, date_created by default")
+	fs.StringVar(&f.ExternalReference, "external-reference", "", "external reference of the payments")
+	fs.StringVar(&f.Sort, "sort", "date_created", "field to sort the payments by")
+	fs.StringVar(&f.Criteria, "criteria", "desc", "order of the payments: asc or desc")
+	fs.Int64Var(&f.Limit, "limit", 30, "maximum number of payments")
+	fs.Int64Var(&f.Offset, "offset", 0, "number of payments to skip")
+	if _, err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	var err error
+	if f.BeginDate, err = searchDate(since, a.now()); err != nil {
+		return err
+	}
+	if f.EndDate, err = searchDate(until, a.now()); err != nil {
+		return err
+	}
+	if err := a.connect(); err != nil {
+		return err
+	}
+
+	res, err := a.payments.Search(f)
+	if err != nil {
+		return err
+	}
+	return a.write(res, func(t *table) { t.payments(res.Results...) })
+}
+
+func (a *app) paymentCancel(args []string) error {
+	fs := a.flagSet("payment cancel")
+	args, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(args[0])
+	if err != nil {
+		return err
+	}
+	if err := a.connect(); err != nil {
+		return err
+	}
+
+	p, err := a.payments.Cancel(id)
+	if err != nil {
+		return err
+	}
+	return a.write(p, func(t *table) { t.payments(*p) })
+}
+
+func (a *app) paymentCapture(args []string) error {
+	fs := a.flagSet("payment capture")
+	amount := amountFlag(fs, "amount to capture, the whole amount by default")
+	args, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(args[0])
+	if err != nil {
+		return err
+	}
+	if err := a.connect(); err != nil {
+		return err
+	}
+
+	var p *payment.Response
+	if amount.IsZero() {
+		p, err = a.payments.Capture(id)
+	} else {
+		p, err = a.payments.CaptureAmount(id, *amount)
+	}
+	if err != nil {
+		return err
+	}
+	return a.write(p, func(t *table) { t.payments(*p) })
+}
+
+func (a *app) refundCreate(args []string) error {
+	fs := a.flagSet("refund create")
+	amount := amountFlag(fs, "amount to refund, the whole amount by default")
+	args, err := a.parse(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	id, err := parseID(args[0])
+	if err != nil {
+		return err
+	}
+	if err := a.connect(); err != nil {
+		return err
+	}
+
+	var r *payment.RefundResponse
+	if amount.IsZero() {
+		r, err = a.payments.Refund(id)
+	} else {
+		r, err = a.payments.RefundAmount(id, *amount)
+	}
+	if err != nil {
+		return err
+	}
+	return a.write(r, func(t *table) { t.refunds(*r) })
+}
+
+func (a *app) methodsList(args []string) error {
+	fs := a.flagSet("methods list")
+	if _, err := a.parse(fs, args, 0); err != nil {
+		return err
+	}
+	if err := a.connect(); err != nil {
+		return err
+	}
+
+	methods, err := a.methods.List()
+	if err != nil {
+		return err
+	}
+	return a.write(methods, func(t *table) { t.methods(methods...) })
+}
+
+// amountFlag defines an --amount flag.
+func amountFlag(fs *flag.FlagSet, usage string) *money.Amount {
+	amount := new(money.Amount)
+	fs.TextVar(amount, "amount", money.Amount{}, usage)
+	return amount
+}
+
+func parseID(s string) (int64, error) {
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("%w: invalid id %q", errUsage, s)
+	}
+	return id, nil
+}
+
+// searchDate converts a --since or --until value to a date of the search: days (7d) or months (3mo) ago,
+// which the API understands as is, a duration ago (12h), or a date.
+func searchDate(s string, now time.Time) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	for suffix, unit := range map[string]string{"d": "DAYS", "mo": "MONTHS"} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			if _, err := strconv.ParseUint(n, 10, 32); err == nil {
+				return "NOW-" + n + unit, nil
+			}
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d).UTC().Format(time.RFC3339), nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Format(time.RFC3339), nil
+		}
+	}
+	return "", fmt.Errorf("%w: invalid date %q", errUsage, s)
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+	"github.com/gdeandradero/sdk-go/pkg/payment"
+	"github.com/gdeandradero/sdk-go/pkg/paymentmethod"
+)
+
+func newTestApp(send func(req *http.Request) ([]byte, error)) (*app, *bytes.Buffer, *bytes.Buffer) {
+	var stdout, stderr bytes.Buffer
+	a := &app{
+		options: options{output: "table"},
+		stdout:  &stdout,
+		stderr:  &stderr,
+		now:     func() time.Time { return time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC) },
+	}
+	if send != nil {
+		rc := &rest.Mock{
+			SendMock: func(req *http.Request, opts ...rest.Option) ([]byte, error) {
+				return send(req)
+			},
+		}
+		a.payments = payment.NewClient(rc)
+		a.methods = paymentmethod.NewClient(rc)
+	}
+	return a, &stdout, &stderr
+}
+
+func TestRun(t *testing.T) {
+	payment := `{"id":123,"status":"approved","status_detail":"accredited","transaction_amount":10.5,` +
+		`"currency_id":"BRL","payment_method_id":"pix","external_reference":"order: 1","metadata":{"tags":["a","b"],"empty":[]}}`
+
+	tests := []struct {
+		name     string
+		args     []string
+		response string
+		want     string
+		wantCode int
+	}{
+		{
+			name:     "should_print_table",
+			args:     []string{"payment", "get", "123"},
+			response: payment,
+			want: "ID   STATUS    STATUS_DETAIL  AMOUNT  REFUNDED  CURRENCY  METHOD  EXTERNAL_REFERENCE  DATE_CREATED\n" +
+				"123  approved  accredited     10.5    0         BRL       pix     order: 1            -\n",
+		},
+		{
+			name:     "should_print_yaml",
+			args:     []string{"payment", "get", "-o", "yaml", "123"},
+			response: payment,
+			want: "payment_method_id: pix\nstatus: approved\nstatus_detail: accredited\ncurrency_id: BRL\n" +
+				"external_reference: \"order: 1\"\nid: 123\ntransaction_amount: 10.5\nmetadata:\n  empty: []\n  tags:\n    - a\n    - b\n",
+		},
+		{
+			name:     "should_print_json",
+			args:     []string{"--output", "json", "refund", "create", "123", "--amount", "5"},
+			response: `{"id":1,"payment_id":123,"amount":5}`,
+			want:     "{\n  \"id\": 1,\n  \"payment_id\": 123,\n  \"amount\": 5\n}\n",
+		},
+		{
+			name:     "should_fail_on_unknown_command",
+			args:     []string{"payment", "delete", "123"},
+			wantCode: 2,
+		},
+		{
+			name:     "should_fail_on_invalid_id",
+			args:     []string{"payment", "get", "abc"},
+			wantCode: 2,
+		},
+		{
+			name:     "should_fail_on_api_error",
+			args:     []string{"methods", "list"},
+			wantCode: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, stdout, _ := newTestApp(func(req *http.Request) ([]byte, error) {
+				if tt.response == "" {
+					return nil, fmt.Errorf("some error")
+				}
+				return []byte(tt.response), nil
+			})
+			if code := a.run(tt.args); code != tt.wantCode {
+				t.Fatalf("app.run() = %d, want %d", code, tt.wantCode)
+			}
+			if got := stdout.String(); got != tt.want {
+				t.Errorf("app.run() output:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunDryRun(t *testing.T) {
+	a, stdout, _ := newTestApp(nil)
+	if code := a.run([]string{"--dry-run", "payment", "search", "--status", "approved", "--since", "7d"}); code != 0 {
+		t.Fatalf("app.run() = %d, want 0", code)
+	}
+	if got := stdout.String(); !strings.HasPrefix(got, "GET /v1/payments/search?") || !strings.Contains(got, "begin_date=NOW-7DAYS") ||
+		!strings.Contains(got, "status=approved") {
+		t.Errorf("app.run() output = %s, want the search request", got)
+	}
+}
+
+func TestSearchDate(t *testing.T) {
+	now := time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC)
+	tests := map[string]string{
+		"":                     "",
+		"7d":                   "NOW-7DAYS",
+		"3mo":                  "NOW-3MONTHS",
+		"12h":                  "2024-01-31T00:00:00Z",
+		"2024-01-01":           "2024-01-01T00:00:00Z",
+		"2024-01-01T10:00:00Z": "2024-01-01T10:00:00Z",
+	}
+	for in, want := range tests {
+		if got, err := searchDate(in, now); err != nil || got != want {
+			t.Errorf("searchDate(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+	if _, err := searchDate("yesterday", now); err == nil {
+		t.Error("searchDate(\"yesterday\") error = nil")
+	}
+}
+
+func TestAccessToken(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.json")
+	config := `{"default_profile":"sandbox","profiles":{"sandbox":{"access_token":"TEST-1"},"production":{"access_token":"APP_USR-1"}}}`
+	if err := os.WriteFile(file, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("MP_ACCESS_TOKEN", "")
+	tests := map[string]string{"": "TEST-1", "production": "APP_USR-1"}
+	for profile, want := range tests {
+		if got, err := accessToken(file, profile); err != nil || got != want {
+			t.Errorf("accessToken(%q) = %q, %v, want %q", profile, got, err, want)
+		}
+	}
+	if _, err := accessToken(file, "staging"); err == nil {
+		t.Error("accessToken(\"staging\") error = nil")
+	}
+
+	t.Setenv("MP_ACCESS_TOKEN", "TEST-ENV")
+	if got, _ := accessToken(file, ""); got != "TEST-ENV" {
+		t.Errorf("accessToken() = %q, want the environment token", got)
+	}
+}
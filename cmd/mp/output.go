@@ -0,0 +1,244 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/payment"
+	"github.com/gdeandradero/sdk-go/pkg/paymentmethod"
+)
+
+// write writes v in the output format, using fill to build the table.
+func (a *app) write(v any, fill func(t *table)) error {
+	switch a.output {
+	case "json":
+		enc := json.NewEncoder(a.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case "yaml":
+		return writeYAML(a.stdout, v)
+	case "table", "":
+		t := &table{tw: tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)}
+		fill(t)
+		return t.tw.Flush()
+	}
+	return fmt.Errorf("%w: unknown output format %q", errUsage, a.output)
+}
+
+// table writes aligned columns.
+type table struct {
+	tw *tabwriter.Writer
+}
+
+func (t *table) row(cells ...string) {
+	for i, c := range cells {
+		if c == "" {
+			cells[i] = "-"
+		}
+	}
+	fmt.Fprintln(t.tw, strings.Join(cells, "\t"))
+}
+
+func (t *table) payments(ps ...payment.Response) {
+	t.row("ID", "STATUS", "STATUS_DETAIL", "AMOUNT", "REFUNDED", "CURRENCY", "METHOD", "EXTERNAL_REFERENCE", "DATE_CREATED")
+	for _, p := range ps {
+		t.row(
+			strconv.FormatInt(p.ID, 10),
+			string(p.Status),
+			string(p.StatusDetail),
+			p.TransactionAmount.String(),
+			p.TransactionAmountRefunded.String(),
+			p.CurrencyID,
+			p.PaymentMethodID,
+			p.ExternalReference,
+			date(p.DateCreated),
+		)
+	}
+}
+
+func (t *table) refunds(rs ...payment.RefundResponse) {
+	t.row("ID", "PAYMENT_ID", "STATUS", "AMOUNT", "DATE_CREATED")
+	for _, r := range rs {
+		t.row(
+			strconv.FormatInt(r.ID, 10),
+			strconv.FormatInt(r.PaymentID, 10),
+			r.Status,
+			r.Amount.String(),
+			date(r.DateCreated),
+		)
+	}
+}
+
+func (t *table) methods(ms ...paymentmethod.Response) {
+	t.row("ID", "NAME", "TYPE", "STATUS", "MIN_AMOUNT", "MAX_AMOUNT")
+	for _, m := range ms {
+		t.row(m.ID, m.Name, m.PaymentTypeID, m.Status, m.MinAllowedAmount.String(), m.MaxAllowedAmount.String())
+	}
+}
+
+func date(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// writeYAML writes v as YAML. v is encoded as JSON first, so the JSON tags apply,
+// and the fields keep their order.
+func writeYAML(w io.Writer, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	node, err := decodeNode(dec)
+	if err != nil {
+		return err
+	}
+
+	var out strings.Builder
+	for _, l := range yamlLines(node) {
+		out.WriteString(l)
+		out.WriteByte('\n')
+	}
+	_, err = io.WriteString(w, out.String())
+	return err
+}
+
+// object is a JSON object keeping the order of its fields.
+type object []field
+
+type field struct {
+	key   string
+	value any
+}
+
+// decodeNode decodes a JSON value into an object, a []any or a scalar.
+func decodeNode(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		obj := object{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj = append(obj, field{key: key.(string), value: value})
+		}
+		_, err := dec.Token()
+		return obj, err
+	case json.Delim('['):
+		arr := []any{}
+		for dec.More() {
+			value, err := decodeNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, value)
+		}
+		_, err := dec.Token()
+		return arr, err
+	}
+	return tok, nil
+}
+
+// yamlLines returns the lines of a YAML block holding node, without indentation.
+func yamlLines(node any) []string {
+	var lines []string
+	switch n := node.(type) {
+	case object:
+		if len(n) == 0 {
+			return []string{"{}"}
+		}
+		for _, f := range n {
+			key := yamlScalar(f.key)
+			if isBlock(f.value) {
+				lines = append(lines, key+":")
+				for _, l := range yamlLines(f.value) {
+					lines = append(lines, "  "+l)
+				}
+				continue
+			}
+			lines = append(lines, key+": "+yamlLines(f.value)[0])
+		}
+	case []any:
+		if len(n) == 0 {
+			return []string{"[]"}
+		}
+		for _, item := range n {
+			for i, l := range yamlLines(item) {
+				if i == 0 {
+					lines = append(lines, "- "+l)
+				} else {
+					lines = append(lines, "  "+l)
+				}
+			}
+		}
+	default:
+		lines = append(lines, yamlScalar(n))
+	}
+	return lines
+}
+
+// isBlock reports whether node is a non-empty object or array, written on its own lines.
+func isBlock(node any) bool {
+	switch n := node.(type) {
+	case object:
+		return len(n) > 0
+	case []any:
+		return len(n) > 0
+	}
+	return false
+}
+
+// yamlScalar formats a JSON scalar, quoting the strings YAML would read as something else.
+func yamlScalar(v any) string {
+	switch s := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(s)
+	case json.Number:
+		return s.String()
+	case string:
+		if needsQuotes(s) {
+			return strconv.Quote(s)
+		}
+		return s
+	}
+	return fmt.Sprint(v)
+}
+
+func needsQuotes(s string) bool {
+	if s == "" || strings.TrimSpace(s) != s {
+		return true
+	}
+	switch strings.ToLower(s) {
+	case "null", "~", "true", "false", "yes", "no", "on", "off", "y", "n":
+		return true
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return true
+	}
+	if strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`") {
+		return true
+	}
+	return strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.ContainsFunc(s, func(r rune) bool {
+		return r < ' ' || r == 0x7f
+	})
+}
@@ -0,0 +1,146 @@
+// Package otelmp adapts the OpenTelemetry API to the rest.Tracer and rest.Meter interfaces.
+//
+// It lives in its own module so that the SDK does not depend on OpenTelemetry:
+//
+//	mp.SetTracer(otelmp.NewTracer(otel.GetTracerProvider()))
+//	meter, err := otelmp.NewMeter(otel.GetMeterProvider())
+//	if err != nil {
+//		panic(err)
+//	}
+//	mp.SetMeter(meter)
+package otelmp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+)
+
+// ScopeName is the instrumentation scope name used for tracers and meters.
+const ScopeName = "github.com/gdeandradero/sdk-go"
+
+const (
+	latencyInstrument = "mercadopago.client.operation.duration"
+	errorsInstrument  = "mercadopago.client.operation.errors"
+)
+
+// tracer is the OpenTelemetry implementation of rest.Tracer.
+type tracer struct {
+	t trace.Tracer
+}
+
+// NewTracer returns a rest.Tracer that creates client spans with the given provider.
+func NewTracer(tp trace.TracerProvider) rest.Tracer {
+	return &tracer{
+		t: tp.Tracer(ScopeName),
+	}
+}
+
+func (t *tracer) Start(ctx context.Context, operation string) (context.Context, rest.Span) {
+	ctx, s := t.t.Start(ctx, operation, trace.WithSpanKind(trace.SpanKindClient))
+	return ctx, &span{s: s}
+}
+
+// span is the OpenTelemetry implementation of rest.Span.
+type span struct {
+	s trace.Span
+}
+
+func (s *span) SetAttributes(attrs ...rest.Attribute) {
+	s.s.SetAttributes(convert(attrs)...)
+}
+
+func (s *span) AddEvent(name string, attrs ...rest.Attribute) {
+	s.s.AddEvent(name, trace.WithAttributes(convert(attrs)...))
+}
+
+func (s *span) RecordError(err error) {
+	s.s.RecordError(err)
+	s.s.SetStatus(codes.Error, err.Error())
+}
+
+func (s *span) End() {
+	s.s.End()
+}
+
+// meter is the OpenTelemetry implementation of rest.Meter.
+type meter struct {
+	latency metric.Float64Histogram
+	errors  metric.Int64Counter
+}
+
+// NewMeter returns a rest.Meter that records a latency histogram, in seconds,
+// and an error counter with the given provider.
+func NewMeter(mp metric.MeterProvider) (rest.Meter, error) {
+	m := mp.Meter(ScopeName)
+
+	latency, err := m.Float64Histogram(latencyInstrument,
+		metric.WithDescription("Duration of Mercado Pago SDK operations, retries included."),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating latency histogram: %w", err)
+	}
+
+	errors, err := m.Int64Counter(errorsInstrument,
+		metric.WithDescription("Number of failed Mercado Pago SDK operations by error class."),
+		metric.WithUnit("{error}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating errors counter: %w", err)
+	}
+
+	return &meter{
+		latency: latency,
+		errors:  errors,
+	}, nil
+}
+
+func (m *meter) RecordLatency(ctx context.Context, operation string, d time.Duration, attrs ...rest.Attribute) {
+	m.latency.Record(ctx, d.Seconds(), metric.WithAttributes(metricAttributes(attrs)...))
+}
+
+func (m *meter) AddError(ctx context.Context, operation string, class rest.ErrorClass, attrs ...rest.Attribute) {
+	kvs := append(metricAttributes(attrs), attribute.String(rest.AttributeErrorClass, string(class)))
+	m.errors.Add(ctx, 1, metric.WithAttributes(kvs...))
+}
+
+// metricAttributes keeps only the attributes with a bounded cardinality.
+func metricAttributes(attrs []rest.Attribute) []attribute.KeyValue {
+	kept := make([]rest.Attribute, 0, len(attrs))
+	for _, a := range attrs {
+		switch a.Key {
+		case rest.AttributeOperation, rest.AttributeHTTPStatusCode:
+			kept = append(kept, a)
+		}
+	}
+	return convert(kept)
+}
+
+func convert(attrs []rest.Attribute) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		switch v := a.Value.(type) {
+		case string:
+			kvs = append(kvs, attribute.String(a.Key, v))
+		case int:
+			kvs = append(kvs, attribute.Int(a.Key, v))
+		case int64:
+			kvs = append(kvs, attribute.Int64(a.Key, v))
+		case float64:
+			kvs = append(kvs, attribute.Float64(a.Key, v))
+		case bool:
+			kvs = append(kvs, attribute.Bool(a.Key, v))
+		default:
+			kvs = append(kvs, attribute.String(a.Key, fmt.Sprint(v)))
+		}
+	}
+	return kvs
+}
@@ -0,0 +1,136 @@
+package otelmp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+)
+
+func TestTracerAndMeter(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("X-Request-Id", "req-123")
+		_, _ = w.Write([]byte(`{"id": 1, "status": "approved"}`))
+	}))
+	defer srv.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	rc := rest.NewClient("token")
+	rest.SetTracer(NewTracer(tp))
+	m, err := NewMeter(mp)
+	if err != nil {
+		t.Fatalf("NewMeter() error = %v", err)
+	}
+	rest.SetMeter(m)
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/v1/payments/1", nil)
+	if _, err := rc.Send(req, rest.WithOperation("payment.Get"), rest.WithRetryDelay(time.Millisecond)); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	s := spans[0]
+	if s.Name != "payment.Get" {
+		t.Errorf("span name = %q, want %q", s.Name, "payment.Get")
+	}
+	want := map[attribute.Key]attribute.Value{
+		rest.AttributeHTTPStatusCode: attribute.IntValue(http.StatusOK),
+		rest.AttributeRetryCount:     attribute.IntValue(1),
+		rest.AttributeRequestID:      attribute.StringValue("req-123"),
+		rest.AttributeStatus:         attribute.StringValue("approved"),
+	}
+	got := map[attribute.Key]attribute.Value{}
+	for _, kv := range s.Attributes {
+		got[kv.Key] = kv.Value
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("span attribute %s = %v, want %v", k, got[k].Emit(), v.Emit())
+		}
+	}
+	if len(s.Events) != 1 || s.Events[0].Name != "retry" {
+		t.Errorf("span events = %v, want a single retry event", s.Events)
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if len(rm.ScopeMetrics) != 1 {
+		t.Fatalf("got %d scopes, want 1", len(rm.ScopeMetrics))
+	}
+	for _, metric := range rm.ScopeMetrics[0].Metrics {
+		if metric.Name != latencyInstrument {
+			t.Errorf("unexpected metric %q", metric.Name)
+			continue
+		}
+		h := metric.Data.(metricdata.Histogram[float64])
+		if len(h.DataPoints) != 1 || h.DataPoints[0].Count != 1 {
+			t.Errorf("latency data points = %v, want a single measurement", h.DataPoints)
+		}
+	}
+}
+
+func TestMeterErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	rc := rest.NewClient("token")
+	m, err := NewMeter(mp)
+	if err != nil {
+		t.Fatalf("NewMeter() error = %v", err)
+	}
+	rest.SetMeter(m)
+
+	req, _ := http.NewRequest(http.MethodPost, srv.URL+"/v1/payments", nil)
+	if _, err := rc.Send(req, rest.WithOperation("payment.Create")); err == nil {
+		t.Fatal("Send() error = nil, want error")
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	for _, metric := range rm.ScopeMetrics[0].Metrics {
+		if metric.Name != errorsInstrument {
+			continue
+		}
+		sum := metric.Data.(metricdata.Sum[int64])
+		if len(sum.DataPoints) != 1 {
+			t.Fatalf("got %d error data points, want 1", len(sum.DataPoints))
+		}
+		class, _ := sum.DataPoints[0].Attributes.Value(rest.AttributeErrorClass)
+		if class.AsString() != string(rest.ErrorClassClient) {
+			t.Errorf("error class = %q, want %q", class.AsString(), rest.ErrorClassClient)
+		}
+		return
+	}
+	t.Errorf("metric %q not found", errorsInstrument)
+}
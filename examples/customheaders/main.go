@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/gdeandradero/sdk-go/pkg/money"
 	"github.com/gdeandradero/sdk-go/pkg/mp"
 	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
 	"github.com/gdeandradero/sdk-go/pkg/payment"
@@ -15,7 +16,7 @@ func main() {
 	pc := payment.NewClient(rc)
 
 	request := payment.Request{
-		TransactionAmount: 1.5,
+		TransactionAmount: money.MustParse("1.5"),
 		PaymentMethodID:   "pix",
 		Description:       "meu pagamento",
 		Payer: &payment.PayerRequest{
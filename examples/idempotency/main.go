@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gdeandradero/sdk-go/pkg/money"
+	"github.com/gdeandradero/sdk-go/pkg/mp"
+	"github.com/gdeandradero/sdk-go/pkg/mp/idempotency"
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+	"github.com/gdeandradero/sdk-go/pkg/payment"
+)
+
+func main() {
+	rc := mp.NewRestClient("TEST-640110472259637-071923-a761f639c4eb1f0835ff7611f3248628-793910800")
+
+	store, err := idempotency.NewFileStore("/tmp/mp-idempotency", 0)
+	if err != nil {
+		panic(err)
+	}
+	mp.SetIdempotencyStore(store) // completed requests survive a restart and are replayed from the store
+
+	pc := payment.NewClient(rc)
+
+	request := payment.Request{
+		TransactionAmount: money.MustParse("1.5"),
+		PaymentMethodID:   "pix",
+		Description:       "meu pagamento",
+		ExternalReference: "order-123",
+		Payer: &payment.PayerRequest{
+			Email: "fhashfadsuhfdafasdfasfashfda@testuser.com",
+		},
+	}
+
+	// running this program twice creates a single payment
+	res, err := pc.Create(request, rest.WithIdempotencyKey("order-123"))
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(res.ID)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/gdeandradero/sdk-go/pkg/money"
 	"github.com/gdeandradero/sdk-go/pkg/mp"
 	"github.com/gdeandradero/sdk-go/pkg/payment"
 	"github.com/google/uuid"
@@ -23,15 +24,16 @@ var externalReference = uuid.New().String()
 func createPayment() int64 {
 	pc := payment.NewClient(rc)
 
-	request := payment.Request{
-		TransactionAmount: 1.5,
-		PaymentMethodID:   "pix",
-		Description:       "meu pagamento",
-		ExternalReference: externalReference,
-		Payer: &payment.PayerRequest{
-			Email: "fhashfadsuhfdafasdfasfashfda@testuser.com",
-		},
+	request, err := payment.NewPixPayment(money.MustParse("1.5"), "fhashfadsuhfdafasdfasfashfda@testuser.com").
+		WithDescription("meu pagamento").
+		WithExternalReference(externalReference).
+		ExpiresIn(30 * time.Minute).
+		Build()
+	if err != nil {
+		fmt.Println(err)
+		return 0
 	}
+
 	res, err := pc.Create(request)
 	if err != nil {
 		fmt.Println(err)
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gdeandradero/sdk-go/pkg/mp"
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+	"github.com/gdeandradero/sdk-go/pkg/paymentmethod"
+)
+
+func main() {
+	rc := mp.NewRestClient("TEST-640110472259637-071923-a761f639c4eb1f0835ff7611f3248628-793910800")
+
+	mp.SetRateLimiter(rest.NewRateLimiter(rest.RateLimiterConfig{
+		Mode:     rest.RateLimitWait,                  // requests wait for budget instead of failing with rest.ErrRateLimited
+		Global:   rest.RateLimit{Rate: 50, Burst: 10}, // 50 requests per second shared by every access token
+		PerToken: rest.RateLimit{Rate: 10, Burst: 5},  // 10 requests per second for each access token
+	}))
+
+	pmc := paymentmethod.NewClient(rc)
+	for i := 0; i < 20; i++ {
+		res, err := pmc.List()
+		if err != nil {
+			panic(err)
+		}
+
+		fmt.Println(len(res))
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/gdeandradero/sdk-go/pkg/mp"
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+)
+
+// secrets stands in for a secret store holding the access token of each seller.
+var secrets = map[int64]string{
+	793910800: "TEST-640110472259637-071923-a761f639c4eb1f0835ff7611f3248628-793910800",
+}
+
+func main() {
+	registry := mp.NewRegistry(mp.RegistryConfig{
+		MaxTenants: 5000,
+		Loader: func(sellerID int64) (mp.Credentials, error) {
+			return mp.Credentials{AccessToken: secrets[sellerID]}, nil
+		},
+		// shared by every seller: the rate limiter keeps a budget per access token.
+		Client: rest.ClientConfig{
+			RateLimiter: rest.NewRateLimiter(rest.RateLimiterConfig{PerToken: rest.RateLimit{Rate: 10, Burst: 5}}),
+		},
+	})
+
+	pmc, err := registry.PaymentMethod(793910800)
+	if err != nil {
+		panic(err)
+	}
+
+	res, err := pmc.List()
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(len(res))
+}
@@ -0,0 +1,192 @@
+// Package calls records the calls of the fake clients of the test packages, e.g. paymenttest,
+// and asserts them.
+package calls
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+)
+
+// ErrNotConfigured is returned by the methods of a fake whose function field is not set.
+var ErrNotConfigured = errors.New("method of the fake not configured")
+
+// Call is a call of a method of a fake.
+type Call struct {
+	// Method is the name of the method, e.g. "Get".
+	Method string
+
+	// Args are the arguments of the call, without the context and the options, e.g. the int64 ID of Get.
+	// Expected calls with nil Args match any arguments. An expected integer argument matches a recorded one
+	// of another integer type with the same value, so that Expect("Get", 1) matches Get(int64(1)).
+	Args []any
+
+	// Opts are the options of the call.
+	Opts []rest.Option
+}
+
+// Expect returns the expected call of a method with args, or with any arguments if none is given.
+func Expect(method string, args ...any) Call {
+	if len(args) == 0 {
+		return Call{Method: method}
+	}
+	return Call{Method: method, Args: args}
+}
+
+func (c Call) String() string {
+	if c.Args == nil {
+		return c.Method + "(...)"
+	}
+	args := make([]string, len(c.Args))
+	for i, a := range c.Args {
+		args[i] = fmt.Sprintf("%#v", a)
+	}
+	return c.Method + "(" + strings.Join(args, ", ") + ")"
+}
+
+// matches reports whether got is the expected call c.
+func (c Call) matches(got Call) bool {
+	if c.Method != got.Method {
+		return false
+	}
+	if c.Args == nil {
+		return true
+	}
+	if len(c.Args) != len(got.Args) {
+		return false
+	}
+	for i := range c.Args {
+		if !equal(c.Args[i], got.Args[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// equal reports whether the arguments want and got are deeply equal, or are integers of the same value.
+func equal(want, got any) bool {
+	if reflect.DeepEqual(want, got) {
+		return true
+	}
+	w, g := reflect.ValueOf(want), reflect.ValueOf(got)
+	if !w.IsValid() || !g.IsValid() {
+		return false
+	}
+	wi, wSigned, wOK := integer(w)
+	gi, gSigned, gOK := integer(g)
+	if !wOK || !gOK {
+		return false
+	}
+	if wSigned != gSigned && (wSigned && int64(wi) < 0 || gSigned && int64(gi) < 0) {
+		return false
+	}
+	return wi == gi
+}
+
+// integer returns the bits of an integer and whether its kind is signed, or false if v is not an integer.
+func integer(v reflect.Value) (bits uint64, signed, ok bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return uint64(v.Int()), true, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint(), false, true
+	}
+	return 0, false, false
+}
+
+// TB is the subset of testing.TB used by the assertions.
+type TB interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// Recorder records the calls of a fake. It is safe for concurrent use, and its zero value is ready to use.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Record records a call of a fake.
+func (r *Recorder) Record(method string, opts []rest.Option, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args, Opts: opts})
+}
+
+// Calls returns the calls recorded, in order.
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// CallsTo returns the calls of a method recorded, in order.
+func (r *Recorder) CallsTo(method string) []Call {
+	var calls []Call
+	for _, c := range r.Calls() {
+		if c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// Reset forgets the calls recorded.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
+
+// AssertCalled asserts that the method was called with args, or with any arguments if none is given.
+func (r *Recorder) AssertCalled(t TB, method string, args ...any) bool {
+	t.Helper()
+	want := Expect(method, args...)
+	calls := r.Calls()
+	for _, c := range calls {
+		if want.matches(c) {
+			return true
+		}
+	}
+	t.Errorf("%s not called, calls: %s", want, list(calls))
+	return false
+}
+
+// AssertNotCalled asserts that the method was not called.
+func (r *Recorder) AssertNotCalled(t TB, method string) bool {
+	t.Helper()
+	if calls := r.CallsTo(method); len(calls) > 0 {
+		t.Errorf("%s called %d time(s): %s", method, len(calls), list(calls))
+		return false
+	}
+	return true
+}
+
+// AssertCalls asserts that the calls recorded are the expected calls, in order.
+func (r *Recorder) AssertCalls(t TB, want ...Call) bool {
+	t.Helper()
+	calls := r.Calls()
+	ok := len(calls) == len(want)
+	for i := 0; ok && i < len(want); i++ {
+		ok = want[i].matches(calls[i])
+	}
+	if !ok {
+		t.Errorf("calls = %s, want %s", list(calls), list(want))
+	}
+	return ok
+}
+
+func list(calls []Call) string {
+	if len(calls) == 0 {
+		return "none"
+	}
+	s := make([]string, len(calls))
+	for i, c := range calls {
+		s[i] = c.String()
+	}
+	return strings.Join(s, ", ")
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strings"
+)
+
+// extraFile is the name of the file of the JSON methods of the structs keeping the unknown fields of the responses.
+const extraFile = "extra.go"
+
+// extraModel is the extra.go file of a package.
+type extraModel struct {
+	// Source is the directory of the package, relative to the root of its module.
+	Source string
+
+	Package string
+
+	// Structs are the names of the structs with an Extra field, in the order of their declarations.
+	Structs []string
+}
+
+// generateExtra returns the extra.go file of the package in dir, with the UnmarshalJSON and MarshalJSON methods
+// of the structs of the package with an Extra map[string]json.RawMessage field.
+func generateExtra(dir string) ([]byte, error) {
+	source, err := sourceName(filepath.Join(dir, extraFile))
+	if err != nil {
+		return nil, err
+	}
+	m := &extraModel{Source: filepath.ToSlash(filepath.Dir(source))}
+
+	fset := token.NewFileSet()
+	names, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		if strings.HasSuffix(name, "_test.go") || filepath.Base(name) == extraFile {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		m.Package = f.Name.Name
+		ast.Inspect(f, func(n ast.Node) bool {
+			if ts, ok := n.(*ast.TypeSpec); ok {
+				if st, ok := ts.Type.(*ast.StructType); ok && hasExtra(st) {
+					m.Structs = append(m.Structs, ts.Name.Name)
+				}
+				return false
+			}
+			return true
+		})
+	}
+	if len(m.Structs) == 0 {
+		return nil, fmt.Errorf("%s: no struct with an Extra field", dir)
+	}
+
+	var b bytes.Buffer
+	if err := templates.ExecuteTemplate(&b, extraFile, m); err != nil {
+		return nil, err
+	}
+	return addImports(b.Bytes(), imports)
+}
+
+// hasExtra reports whether the struct has an Extra map[string]json.RawMessage field.
+func hasExtra(st *ast.StructType) bool {
+	for _, f := range st.Fields.List {
+		for _, name := range f.Names {
+			if name.Name == "Extra" && types.ExprString(f.Type) == "map[string]json.RawMessage" {
+				return true
+			}
+		}
+	}
+	return false
+}
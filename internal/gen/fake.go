@@ -0,0 +1,301 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// fakeModel is the fake of the Client interface of a package.
+type fakeModel struct {
+	// Source is the file of the interface, relative to the root of its module.
+	Source string
+
+	// Package is the name of the package of the interface, e.g. payment, and Name the name of the package
+	// of the fake, e.g. paymenttest.
+	Package string
+	Name    string
+
+	Methods []fakeMethod
+
+	// imports are the packages the fake may use, by name.
+	imports map[string]string
+}
+
+// fakeMethod is a method of the interface.
+type fakeMethod struct {
+	Name    string
+	Params  []fakeParam
+	Results []string
+}
+
+// fakeParam is a parameter of a method.
+type fakeParam struct {
+	Name     string
+	Type     string
+	Variadic bool
+}
+
+// generateFake returns the fake of the Client interface of the package in dir, and the directory to write it in.
+func generateFake(dir string) (string, []byte, error) {
+	m, err := newFakeModel(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	var b bytes.Buffer
+	if err := templates.ExecuteTemplate(&b, "fake", m); err != nil {
+		return "", nil, err
+	}
+	src, err := addImports(b.Bytes(), m.imports)
+	if err != nil {
+		return "", nil, err
+	}
+	return filepath.Join(dir, m.Name), src, nil
+}
+
+// newFakeModel parses the Client interface of the package in dir.
+func newFakeModel(dir string) (*fakeModel, error) {
+	importPath, err := importPath(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	names, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		iface := clientInterface(f)
+		if iface == nil {
+			continue
+		}
+
+		source, err := sourceName(name)
+		if err != nil {
+			return nil, err
+		}
+		m := &fakeModel{
+			Source:  source,
+			Package: f.Name.Name,
+			Name:    f.Name.Name + "test",
+			imports: map[string]string{
+				"calls":     "github.com/gdeandradero/sdk-go/internal/calls",
+				"fmt":       "fmt",
+				f.Name.Name: importPath,
+			},
+		}
+		for _, spec := range f.Imports {
+			p, _ := strconv.Unquote(spec.Path.Value)
+			name := path.Base(p)
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			m.imports[name] = p
+		}
+		for _, field := range iface.Methods.List {
+			fn, ok := field.Type.(*ast.FuncType)
+			if !ok || len(field.Names) == 0 {
+				return nil, fmt.Errorf("%s: Client embeds an interface", source)
+			}
+			m.Methods = append(m.Methods, m.newMethod(field.Names[0].Name, fn))
+		}
+		return m, nil
+	}
+	return nil, fmt.Errorf("%s: no Client interface", dir)
+}
+
+// clientInterface returns the Client interface declared in f, or nil.
+func clientInterface(f *ast.File) *ast.InterfaceType {
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if iface, ok := ts.Type.(*ast.InterfaceType); ok && ts.Name.Name == "Client" {
+				return iface
+			}
+		}
+	}
+	return nil
+}
+
+// newMethod returns the method of the interface, with its types qualified by the name of the package.
+func (m *fakeModel) newMethod(name string, fn *ast.FuncType) fakeMethod {
+	method := fakeMethod{Name: name}
+	for i, field := range fn.Params.List {
+		typ, variadic := field.Type, false
+		if ellipsis, ok := typ.(*ast.Ellipsis); ok {
+			typ, variadic = ellipsis.Elt, true
+		}
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{ast.NewIdent(fmt.Sprintf("arg%d", i))}
+		}
+		for _, n := range names {
+			method.Params = append(method.Params, fakeParam{Name: n.Name, Type: m.qualify(typ), Variadic: variadic})
+		}
+	}
+	if fn.Results != nil {
+		for _, field := range fn.Results.List {
+			for range max(len(field.Names), 1) {
+				method.Results = append(method.Results, m.qualify(field.Type))
+			}
+		}
+	}
+	return method
+}
+
+// qualify returns the type expr, with the exported names of the package qualified by its name.
+func (m *fakeModel) qualify(expr ast.Expr) string {
+	expr = copyExpr(expr)
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			return false
+		case *ast.Ident:
+			if n.IsExported() {
+				n.Name = m.Package + "." + n.Name
+			}
+		}
+		return true
+	})
+	return types.ExprString(expr)
+}
+
+// copyExpr returns a copy of expr, so that qualify does not change the parsed file.
+func copyExpr(expr ast.Expr) ast.Expr {
+	src := types.ExprString(expr)
+	copied, err := parser.ParseExpr(src)
+	if err != nil {
+		panic(fmt.Sprintf("gen: %s: %v", src, err))
+	}
+	return copied
+}
+
+// importPath returns the import path of the package in dir, from the go.mod of its module.
+func importPath(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for root := abs; ; root = filepath.Dir(root) {
+		f, err := os.Open(filepath.Join(root, "go.mod"))
+		if err == nil {
+			defer f.Close()
+			s := bufio.NewScanner(f)
+			for s.Scan() {
+				if module, ok := strings.CutPrefix(strings.TrimSpace(s.Text()), "module "); ok {
+					rel, err := filepath.Rel(root, abs)
+					return path.Join(strings.TrimSpace(module), filepath.ToSlash(rel)), err
+				}
+			}
+			return "", fmt.Errorf("%s: no module directive", filepath.Join(root, "go.mod"))
+		}
+		if root == filepath.Dir(root) {
+			return "", fmt.Errorf("%s: not in a module", dir)
+		}
+	}
+}
+
+// Signature returns the parameters of the method.
+func (f fakeMethod) Signature() string {
+	params := make([]string, len(f.Params))
+	for i, p := range f.Params {
+		if p.Variadic {
+			params[i] = p.Name + " ..." + p.Type
+		} else {
+			params[i] = p.Name + " " + p.Type
+		}
+	}
+	return strings.Join(params, ", ")
+}
+
+// Returns returns the results of the method.
+func (f fakeMethod) Returns() string {
+	if len(f.Results) == 1 {
+		return f.Results[0]
+	}
+	if len(f.Results) > 1 {
+		return "(" + strings.Join(f.Results, ", ") + ")"
+	}
+	return ""
+}
+
+// Args returns the arguments of a call with the parameters of the method.
+func (f fakeMethod) Args() string {
+	args := make([]string, len(f.Params))
+	for i, p := range f.Params {
+		args[i] = p.Name
+		if p.Variadic {
+			args[i] += "..."
+		}
+	}
+	return strings.Join(args, ", ")
+}
+
+// Record returns the arguments of the call of Record: the name of the method, its options and its other
+// arguments, except the context.
+func (f fakeMethod) Record() string {
+	opts, args := "nil", ""
+	for _, p := range f.Params {
+		switch {
+		case p.Variadic && p.Type == "rest.Option":
+			opts = p.Name
+		case p.Type != "context.Context":
+			args += ", " + p.Name
+		}
+	}
+	return strconv.Quote(f.Name) + ", " + opts + args
+}
+
+// NotConfigured returns the statement run when the function field of the method is not set: it returns
+// ErrNotConfigured, with the zero values of the other results, or panics with it if the method returns no error.
+func (f fakeMethod) NotConfigured() string {
+	err := "notConfigured(" + strconv.Quote(f.Name) + ")"
+	n := len(f.Results)
+	if n == 0 || f.Results[n-1] != "error" {
+		return "panic(" + err + ")"
+	}
+	results := make([]string, 0, n)
+	for _, r := range f.Results[:n-1] {
+		results = append(results, zero(r))
+	}
+	return "return " + strings.Join(append(results, err), ", ")
+}
+
+// zero returns the zero value of the type.
+func zero(typ string) string {
+	switch {
+	case strings.HasPrefix(typ, "*"), strings.HasPrefix(typ, "[]"), strings.HasPrefix(typ, "map["),
+		strings.HasPrefix(typ, "chan "), strings.HasPrefix(typ, "<-chan "), strings.HasPrefix(typ, "func("),
+		typ == "error", typ == "any":
+		return "nil"
+	case typ == "string":
+		return `""`
+	case typ == "bool":
+		return "false"
+	case strings.HasPrefix(typ, "int"), strings.HasPrefix(typ, "uint"), strings.HasPrefix(typ, "float"),
+		typ == "byte", typ == "rune":
+		return "0"
+	}
+	return "*new(" + typ + ")"
+}
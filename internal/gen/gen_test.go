@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateUpToDate(t *testing.T) {
+	generated, err := generate("../../api/customers.json", "customer")
+	if err != nil {
+		t.Fatalf("generate() error = %v", err)
+	}
+	for _, name := range files {
+		want, err := os.ReadFile(filepath.Join("../../pkg/customer", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(generated[name]) != string(want) {
+			t.Errorf("pkg/customer/%s is not up to date, run go generate ./pkg/customer", name)
+		}
+	}
+}
+
+func TestGenerateFakeUpToDate(t *testing.T) {
+	for _, pkg := range []string{"chargeback", "customer", "marketplace", "payment", "paymentmethod", "report"} {
+		dir, generated, err := generateFake(filepath.Join("../../pkg", pkg))
+		if err != nil {
+			t.Fatalf("generateFake(%s) error = %v", pkg, err)
+		}
+		want, err := os.ReadFile(filepath.Join(dir, "client.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(generated) != string(want) {
+			t.Errorf("pkg/%s/%stest/client.go is not up to date, run go generate ./pkg/%s", pkg, pkg, pkg)
+		}
+	}
+}
+
+func TestNewStruct(t *testing.T) {
+	s := &spec{}
+	if err := json.Unmarshal([]byte(`{"components":{"schemas":{
+		"Item": {"type": "object", "properties": {"id": {"type": "string"}}},
+		"Request": {"type": "object", "required": ["name", "item", "amount"], "properties": {
+			"name": {"type": "string"},
+			"count": {"type": "integer", "format": "int32"},
+			"enabled": {"type": "boolean", "nullable": true},
+			"amount": {"type": "number", "format": "decimal"},
+			"fee": {"type": "number", "format": "decimal"},
+			"rate": {"type": "number"},
+			"tags": {"type": "array", "items": {"type": "string"}},
+			"labels": {"type": "object", "additionalProperties": {"type": "string"}},
+			"date": {"type": "string", "format": "date-time"},
+			"item": {"$ref": "#/components/schemas/Item"},
+			"other": {"$ref": "#/components/schemas/Item", "x-go-name": "OtherItem"},
+			"items": {"type": "array", "items": {"$ref": "#/components/schemas/Item"}}
+		}}
+	}}}`), s); err != nil {
+		t.Fatal(err)
+	}
+
+	m := &model{}
+	st, err := m.newStruct(s, "Request", s.Components.Schemas.Values["Request"])
+	if err != nil {
+		t.Fatalf("newStruct() error = %v", err)
+	}
+
+	var got []string
+	for _, f := range append(st.Fields, st.Nested...) {
+		got = append(got, f.Name+" "+f.Type+" "+f.Tag)
+	}
+	want := []string{
+		"Name string `json:\"name\"`",
+		"Count int `json:\"count,omitempty\"`",
+		"Enabled *bool `json:\"enabled,omitempty\"`",
+		"Amount money.Amount `json:\"amount\"`",
+		"Fee money.Amount `json:\"fee,omitzero\"`",
+		"Rate float64 `json:\"rate,omitempty\"`",
+		"Tags []string `json:\"tags,omitempty\"`",
+		"Labels map[string]string `json:\"labels,omitempty\"`",
+		"Date *time.Time `json:\"date,omitempty\"`",
+		"Item Item `json:\"item\"`",
+		"OtherItem *Item `json:\"other,omitempty\"`",
+		"Items []Item `json:\"items,omitempty\"`",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("newStruct() fields =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	inline := &schema{}
+	_ = json.Unmarshal([]byte(`{"type": "object", "properties": {"a": {"type": "object", "properties": {"b": {"type": "string"}}}}}`), inline)
+	if _, err := m.newStruct(s, "Inline", inline); err == nil || !strings.Contains(err.Error(), "inline objects") {
+		t.Errorf("newStruct() error = %v, want an inline objects error", err)
+	}
+}
+
+func TestNames(t *testing.T) {
+	tests := []struct {
+		name           string
+		wantExported   string
+		wantUnexported string
+	}{
+		{name: "id", wantExported: "ID", wantUnexported: "id"},
+		{name: "customer_id", wantExported: "CustomerID", wantUnexported: "customerID"},
+		{name: "notification_url", wantExported: "NotificationURL", wantUnexported: "notificationURL"},
+		{name: "ListCards", wantExported: "ListCards", wantUnexported: "listCards"},
+		{name: "URLFor", wantExported: "URLFor", wantUnexported: "urlFor"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exported(tt.name); got != tt.wantExported {
+				t.Errorf("exported() = %s, want %s", got, tt.wantExported)
+			}
+			if got := unexported(tt.name); got != tt.wantUnexported {
+				t.Errorf("unexported() = %s, want %s", got, tt.wantUnexported)
+			}
+		})
+	}
+}
+
+func TestGenerateExtraUpToDate(t *testing.T) {
+	for _, pkg := range []string{"chargeback", "payment", "paymentmethod", "report"} {
+		dir := filepath.Join("../../pkg", pkg)
+		generated, err := generateExtra(dir)
+		if err != nil {
+			t.Fatalf("generateExtra(%s) error = %v", pkg, err)
+		}
+		want, err := os.ReadFile(filepath.Join(dir, extraFile))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(generated) != string(want) {
+			t.Errorf("pkg/%s/%s is not up to date, run go generate ./pkg/%s", pkg, extraFile, pkg)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Command gen generates the client of an API from its OpenAPI description: the models, the Client interface
+// with its NewClient implementation over rest.Client, and the table tests of the client.
+// It is meant to be run by go generate in the package of the API:
+//
+//	//go:generate go run ../../internal/gen -spec ../../api/customers.json
+//
+// It reads a subset of OpenAPI 3, in JSON:
+//   - the components are objects, turned into structs, or string enums, turned into string types with constants.
+//     Inline objects are not supported.
+//   - a string or number with the "decimal" format is a money.Amount, a string with the "date-time" format
+//     a time.Time, and an integer an int64, or an int with the "int32" format.
+//   - the operationId of an operation is the name of its method. Its summary completes the doc of the method,
+//     after its name, as the description of the API completes the doc of the package and the description of
+//     a component the doc of its type.
+//   - the path parameters are the first parameters of a method, the query parameters are the fields of
+//     the filters struct named by x-go-filters, the JSON request body is the dto parameter, and the JSON body
+//     of the first 2xx response is the result, a component or an array of components. Its example is the response
+//     of the tests.
+//   - x-go-name names the field of a property, x-enum-names and x-enum-descriptions name and document
+//     the constants of an enum.
+//
+// An optional property is omitted from the JSON when it is empty, and is a pointer when it is an object,
+// a time or nullable. A required property is always encoded. The structs decoded from the responses keep
+// their unknown fields in Extra.
+//
+// With -fake, it generates instead a fake of the Client interface of the package, generated or not: the Client
+// of the package named after it with the "test" suffix, e.g. paymenttest. The fake records its calls with
+// internal/calls, and each of its methods calls the function field of the same name:
+//
+//	//go:generate go run ../../internal/gen -fake
+//
+// With -extra, it generates the extra.go file of a hand-written package: the UnmarshalJSON and MarshalJSON
+// methods of its structs with an Extra map[string]json.RawMessage field, which keep the unknown fields
+// of the responses with rest.DecodeExtra and rest.EncodeExtra:
+//
+//	//go:generate go run ../../internal/gen -extra
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	specPath := flag.String("spec", "", "path of the OpenAPI description, in JSON")
+	out := flag.String("out", ".", "directory of the generated package")
+	pkg := flag.String("package", "", "name of the generated package, the name of the directory by default")
+	fake := flag.Bool("fake", false, "generate the fake of the Client interface of the package in -out")
+	extra := flag.Bool("extra", false, "generate the JSON methods of the structs with an Extra field of the package in -out")
+	flag.Parse()
+
+	var err error
+	switch {
+	case *fake:
+		err = runFake(*out)
+	case *extra:
+		err = runExtra(*out)
+	case *specPath != "":
+		err = run(*specPath, *out, *pkg)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath, out, pkg string) error {
+	dir, err := filepath.Abs(out)
+	if err != nil {
+		return err
+	}
+	if pkg == "" {
+		pkg = filepath.Base(dir)
+	}
+
+	generated, err := generate(specPath, pkg)
+	if err != nil {
+		return err
+	}
+	for _, name := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), generated[name], 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runFake(dir string) error {
+	out, src, err := generateFake(dir)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(out, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(out, "client.go"), src, 0o644)
+}
+
+func runExtra(dir string) error {
+	src, err := generateExtra(dir)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, extraFile), src, 0o644)
+}
+
+// generate returns the files generated from the spec at specPath, by name.
+func generate(specPath, pkg string) (map[string][]byte, error) {
+	s, err := loadSpec(specPath)
+	if err != nil {
+		return nil, err
+	}
+	source, err := sourceName(specPath)
+	if err != nil {
+		return nil, err
+	}
+	m, err := newModel(s, pkg, source)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	return render(m)
+}
+
+// sourceName returns the path of the spec relative to the root of its module, so that the generated code
+// does not depend on where it is generated.
+func sourceName(specPath string) (string, error) {
+	abs, err := filepath.Abs(specPath)
+	if err != nil {
+		return "", err
+	}
+	for dir := filepath.Dir(abs); ; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			rel, err := filepath.Rel(dir, abs)
+			return filepath.ToSlash(rel), err
+		}
+		if dir == filepath.Dir(dir) {
+			return filepath.Base(abs), nil
+		}
+	}
+}
@@ -0,0 +1,916 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type kind int
+
+const (
+	kindString kind = iota
+	kindInt
+	kindInt64
+	kindFloat
+	kindBool
+	kindAmount
+	kindTime
+	kindStruct
+	kindEnum
+	kindSlice
+	kindMap
+	kindAny
+)
+
+// goType is the Go type of a schema.
+type goType struct {
+	kind    kind
+	name    string // of a struct or an enum
+	elem    *goType
+	pointer bool
+}
+
+func (t *goType) String() string {
+	var s string
+	switch t.kind {
+	case kindString:
+		s = "string"
+	case kindInt:
+		s = "int"
+	case kindInt64:
+		s = "int64"
+	case kindFloat:
+		s = "float64"
+	case kindBool:
+		s = "bool"
+	case kindAmount:
+		s = "money.Amount"
+	case kindTime:
+		s = "time.Time"
+	case kindStruct, kindEnum:
+		s = t.name
+	case kindSlice:
+		s = "[]" + t.elem.String()
+	case kindMap:
+		s = "map[string]" + t.elem.String()
+	case kindAny:
+		s = "any"
+	}
+	if t.pointer {
+		return "*" + s
+	}
+	return s
+}
+
+// Pointer reports whether the type is a pointer.
+func (t *goType) Pointer() bool {
+	return t.pointer
+}
+
+// Name returns the name of a struct or an enum.
+func (t *goType) Name() string {
+	return t.name
+}
+
+// nested reports whether a field of the type goes in the second group of the fields of a struct,
+// after the scalars, as in the hand-written models.
+func (t *goType) nested() bool {
+	switch t.kind {
+	case kindStruct, kindTime:
+		return true
+	case kindSlice:
+		return t.elem.kind == kindStruct
+	}
+	return false
+}
+
+// model is the data of the templates.
+type model struct {
+	Package     string
+	Source      string
+	Title       string
+	Description string
+	Structs     []*structModel
+	Enums       []*enumModel
+	Filters     []*filtersModel
+	Operations  []*operationModel
+
+	structs map[string]*structModel
+	enums   map[string]*enumModel
+	helpers map[string]bool // of the tests, set by literal
+}
+
+type structModel struct {
+	Name   string
+	Doc    string
+	Fields []*fieldModel
+	Nested []*fieldModel
+	Spaced bool // the fields are documented, and separated by blank lines
+	Extra  bool // the struct is decoded from responses and keeps their unknown fields
+
+	byJSON map[string]*fieldModel
+}
+
+type fieldModel struct {
+	Name string
+	Type string
+	Tag  string
+	Doc  string
+
+	json string
+	t    *goType
+}
+
+type enumModel struct {
+	Name     string
+	Doc      string
+	Receiver string
+	Values   []*enumValue
+}
+
+type enumValue struct {
+	Name  string
+	Value string
+	Doc   string
+}
+
+type filtersModel struct {
+	Name      string
+	Operation string
+	Fields    []*filterField
+}
+
+type filterField struct {
+	Name  string
+	Param string
+	Type  string
+	Doc   string
+	Cond  string // empty for the required parameters, always sent
+	Value string
+}
+
+type operationModel struct {
+	Name       string
+	Summary    string
+	Reference  string
+	Method     string // the name of the http.Method constant, e.g. "Get"
+	URL        string
+	URLConst   string
+	PathParams string // the map of the path parameters of rest.Do
+	Params     []*paramModel
+	Filters    *filtersModel
+	Body       string // the type of the request body, empty without body
+	Result     *goType
+	Example    string // the response of the tests
+	Want       string // the literal of the response of the tests
+	TestPath   string
+}
+
+type paramModel struct {
+	Name    string
+	Type    string
+	Example string
+}
+
+// Signature returns the parameters of the method.
+func (o *operationModel) Signature() string {
+	var params []string
+	for _, p := range o.Params {
+		params = append(params, p.Name+" "+p.Type)
+	}
+	if o.Filters != nil {
+		params = append(params, "f "+o.Filters.Name)
+	}
+	if o.Body != "" {
+		params = append(params, "dto "+o.Body)
+	}
+	return strings.Join(append(params, "opts ...rest.Option"), ", ")
+}
+
+// Returns returns the results of the method.
+func (o *operationModel) Returns() string {
+	if o.Result == nil {
+		return "error"
+	}
+	return "(" + o.Result.String() + ", error)"
+}
+
+// newModel builds the model of the Go code of a spec.
+func newModel(s *spec, pkg, source string) (*model, error) {
+	m := &model{
+		Package:     pkg,
+		Source:      source,
+		Title:       s.Info.Title,
+		Description: s.Info.Description,
+		structs:     map[string]*structModel{},
+		enums:       map[string]*enumModel{},
+		helpers:     map[string]bool{},
+	}
+
+	responses := map[string]bool{}
+	for _, p := range s.Paths.Keys {
+		for _, op := range s.Paths.Values[p].Values {
+			if mt := successContent(op); mt != nil {
+				if err := s.reachable(mt.Schema, responses); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	for _, name := range s.Components.Schemas.Keys {
+		sc := s.Components.Schemas.Values[name]
+		switch {
+		case sc.Type == "string" && len(sc.Enum) > 0:
+			e, err := newEnum(name, sc)
+			if err != nil {
+				return nil, err
+			}
+			m.Enums = append(m.Enums, e)
+			m.enums[name] = e
+		case sc.Type == "object":
+			st, err := m.newStruct(s, name, sc)
+			if err != nil {
+				return nil, err
+			}
+			st.Extra = responses[name]
+			m.Structs = append(m.Structs, st)
+			m.structs[name] = st
+		default:
+			return nil, fmt.Errorf("schema %s: only objects and string enums are supported", name)
+		}
+	}
+
+	base := strings.TrimSuffix(s.Servers[0].URL, "/")
+	for _, p := range s.Paths.Keys {
+		item := s.Paths.Values[p]
+		for _, method := range item.Keys {
+			op, err := m.newOperation(s, base, p, method, item.Values[method])
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, p, err)
+			}
+			m.Operations = append(m.Operations, op)
+			if op.Filters != nil {
+				m.Filters = append(m.Filters, op.Filters)
+			}
+		}
+	}
+
+	// the literals of the tests need every struct.
+	for _, op := range m.Operations {
+		if err := m.testData(op); err != nil {
+			return nil, fmt.Errorf("%s: %w", op.Name, err)
+		}
+	}
+	return m, nil
+}
+
+// reachable adds the components used by a schema to names.
+func (s *spec) reachable(sc *schema, names map[string]bool) error {
+	if sc == nil {
+		return nil
+	}
+	if sc.Ref != "" {
+		name, ref, err := s.resolve(sc.Ref)
+		if err != nil || names[name] {
+			return err
+		}
+		names[name] = true
+		sc = ref
+	}
+	for _, p := range sc.Properties.Values {
+		if err := s.reachable(p, names); err != nil {
+			return err
+		}
+	}
+	if err := s.reachable(sc.Items, names); err != nil {
+		return err
+	}
+	if ap := additionalProperties(sc); ap != nil {
+		return s.reachable(ap, names)
+	}
+	return nil
+}
+
+// successContent returns the JSON content of the first 2xx response of an operation.
+func successContent(op *operation) *mediaType {
+	for _, code := range op.Responses.Keys {
+		if strings.HasPrefix(code, "2") {
+			if r := op.Responses.Values[code]; r != nil {
+				return jsonContent(r.Content)
+			}
+		}
+	}
+	return nil
+}
+
+// additionalProperties returns the schema of the values of a map, nil if sc is not a map.
+// A map without a schema of its values has values of any type.
+func additionalProperties(sc *schema) *schema {
+	raw := bytes.TrimSpace(sc.AdditionalProperties)
+	if len(raw) == 0 || string(raw) == "false" {
+		return nil
+	}
+	ap := &schema{}
+	if string(raw) != "true" {
+		_ = json.Unmarshal(raw, ap)
+	}
+	return ap
+}
+
+// typeOf returns the Go type of a schema.
+func (m *model) typeOf(s *spec, sc *schema) (*goType, error) {
+	if sc.Ref != "" {
+		name, ref, err := s.resolve(sc.Ref)
+		if err != nil {
+			return nil, err
+		}
+		if ref.Type == "string" && len(ref.Enum) > 0 {
+			return &goType{kind: kindEnum, name: name}, nil
+		}
+		return &goType{kind: kindStruct, name: name}, nil
+	}
+
+	switch sc.Type {
+	case "string":
+		switch sc.Format {
+		case "date-time":
+			return &goType{kind: kindTime}, nil
+		case "decimal":
+			return &goType{kind: kindAmount}, nil
+		}
+		return &goType{kind: kindString}, nil
+	case "integer":
+		if sc.Format == "int32" {
+			return &goType{kind: kindInt}, nil
+		}
+		return &goType{kind: kindInt64}, nil
+	case "number":
+		if sc.Format == "decimal" {
+			return &goType{kind: kindAmount}, nil
+		}
+		return &goType{kind: kindFloat}, nil
+	case "boolean":
+		return &goType{kind: kindBool}, nil
+	case "array":
+		if sc.Items == nil {
+			return nil, fmt.Errorf("array without items")
+		}
+		elem, err := m.typeOf(s, sc.Items)
+		if err != nil {
+			return nil, err
+		}
+		return &goType{kind: kindSlice, elem: elem}, nil
+	case "object", "":
+		if len(sc.Properties.Keys) > 0 {
+			return nil, fmt.Errorf("inline objects are not supported, declare them in the components")
+		}
+		ap := additionalProperties(sc)
+		if ap == nil && sc.Type == "" {
+			return &goType{kind: kindAny}, nil
+		}
+		elem := &goType{kind: kindAny}
+		if ap != nil && (ap.Ref != "" || ap.Type != "") {
+			var err error
+			if elem, err = m.typeOf(s, ap); err != nil {
+				return nil, err
+			}
+		}
+		return &goType{kind: kindMap, elem: elem}, nil
+	}
+	return nil, fmt.Errorf("unsupported type %q", sc.Type)
+}
+
+// newStruct returns the struct of an object schema.
+//
+// An optional field is omitted from the JSON when it is empty: scalars are values, with omitempty, unless they are
+// nullable, where the zero value must be told apart from the absent one; amounts use omitzero; objects and times
+// are pointers. A required field is always encoded, as a value.
+func (m *model) newStruct(s *spec, name string, sc *schema) (*structModel, error) {
+	st := &structModel{Name: name, Doc: sc.Description, byJSON: map[string]*fieldModel{}}
+	required := map[string]bool{}
+	for _, r := range sc.Required {
+		required[r] = true
+	}
+
+	for _, prop := range sc.Properties.Keys {
+		psc := sc.Properties.Values[prop]
+		t, err := m.typeOf(s, psc)
+		if err != nil {
+			return nil, fmt.Errorf("schema %s, property %s: %w", name, prop, err)
+		}
+
+		omit := ",omitempty"
+		switch t.kind {
+		case kindStruct, kindTime:
+			t.pointer = !required[prop] || psc.Nullable
+		case kindAmount:
+			omit = ",omitzero"
+			t.pointer = psc.Nullable
+		case kindString, kindInt, kindInt64, kindFloat, kindBool, kindEnum:
+			t.pointer = psc.Nullable
+		}
+		if required[prop] {
+			omit = ""
+		}
+
+		f := &fieldModel{
+			Name: psc.GoName,
+			Type: t.String(),
+			Tag:  fmt.Sprintf("`json:\"%s%s\"`", prop, omit),
+			Doc:  psc.Description,
+			json: prop,
+			t:    t,
+		}
+		if f.Name == "" {
+			f.Name = exported(prop)
+		}
+		if f.Doc != "" {
+			st.Spaced = true
+		}
+		if t.nested() && !st.Spaced {
+			st.Nested = append(st.Nested, f)
+		} else {
+			st.Fields = append(st.Fields, f)
+		}
+		st.byJSON[prop] = f
+	}
+
+	// the documented fields keep the order of the spec.
+	if st.Spaced {
+		st.Fields, st.Nested = nil, nil
+		for _, prop := range sc.Properties.Keys {
+			st.Fields = append(st.Fields, st.byJSON[prop])
+		}
+	}
+	return st, nil
+}
+
+func newEnum(name string, sc *schema) (*enumModel, error) {
+	if len(sc.EnumNames) > 0 && len(sc.EnumNames) != len(sc.Enum) {
+		return nil, fmt.Errorf("schema %s: x-enum-names must name every value", name)
+	}
+	e := &enumModel{Name: name, Doc: sc.Description, Receiver: strings.ToLower(name[:1])}
+	for i, v := range sc.Enum {
+		ev := &enumValue{Name: name + exported(v), Value: v}
+		if len(sc.EnumNames) > 0 {
+			ev.Name = sc.EnumNames[i]
+		}
+		if i < len(sc.EnumDescriptions) {
+			ev.Doc = sc.EnumDescriptions[i]
+		}
+		e.Values = append(e.Values, ev)
+	}
+	return e, nil
+}
+
+func (m *model) newOperation(s *spec, base, path, method string, op *operation) (*operationModel, error) {
+	if op.OperationID == "" {
+		return nil, fmt.Errorf("no operationId")
+	}
+	o := &operationModel{
+		Name:       op.OperationID,
+		Summary:    op.Summary,
+		Method:     exported(strings.ToLower(method)),
+		URL:        base + path,
+		URLConst:   unexported(op.OperationID) + "URL",
+		PathParams: "nil",
+	}
+	switch o.Method {
+	case "Get", "Post", "Put", "Patch", "Delete":
+	default:
+		return nil, fmt.Errorf("unsupported method")
+	}
+	if op.ExternalDocs != nil {
+		o.Reference = op.ExternalDocs.URL
+	}
+
+	var pathParams []string
+	u, err := url.Parse(base + path)
+	if err != nil {
+		return nil, err
+	}
+	testPath := u.Path
+	for _, p := range op.Parameters {
+		if p.Schema == nil {
+			return nil, fmt.Errorf("parameter %s without schema", p.Name)
+		}
+		t, err := m.typeOf(s, p.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %s: %w", p.Name, err)
+		}
+
+		switch p.In {
+		case "path":
+			pm, value, example, err := pathParam(p, t)
+			if err != nil {
+				return nil, err
+			}
+			o.Params = append(o.Params, pm)
+			pathParams = append(pathParams, strconv.Quote(p.Name)+": "+value)
+			testPath = strings.Replace(testPath, "{"+p.Name+"}", example, 1)
+		case "query":
+			if o.Filters == nil {
+				o.Filters = &filtersModel{Name: op.Filters, Operation: o.Name}
+				if o.Filters.Name == "" {
+					o.Filters.Name = o.Name + "Filters"
+				}
+			}
+			ff, err := filter(p, t)
+			if err != nil {
+				return nil, err
+			}
+			o.Filters.Fields = append(o.Filters.Fields, ff)
+		default:
+			return nil, fmt.Errorf("parameter %s: unsupported location %q", p.Name, p.In)
+		}
+	}
+	if len(pathParams) > 0 {
+		o.PathParams = "map[string]string{" + strings.Join(pathParams, ", ") + "}"
+	}
+	o.TestPath = testPath
+
+	if op.RequestBody != nil {
+		mt := jsonContent(op.RequestBody.Content)
+		if mt == nil || mt.Schema == nil || mt.Schema.Ref == "" {
+			return nil, fmt.Errorf("the request body must be a JSON component")
+		}
+		t, err := m.typeOf(s, mt.Schema)
+		if err != nil {
+			return nil, err
+		}
+		o.Body = t.String()
+	}
+
+	if mt := successContent(op); mt != nil && mt.Schema != nil {
+		t, err := m.typeOf(s, mt.Schema)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case t.kind == kindStruct:
+			t.pointer = true
+		case t.kind == kindSlice && t.elem.kind == kindStruct:
+		default:
+			return nil, fmt.Errorf("the response must be a component or an array of components")
+		}
+		o.Result = t
+		o.Example = "{}"
+		if t.kind == kindSlice {
+			o.Example = "[]"
+		}
+		if len(mt.Example) > 0 {
+			var b bytes.Buffer
+			if err := json.Compact(&b, mt.Example); err != nil {
+				return nil, err
+			}
+			o.Example = b.String()
+		}
+		if strings.Contains(o.Example, "`") {
+			return nil, fmt.Errorf("the example must not contain a backquote")
+		}
+	}
+	return o, nil
+}
+
+// pathParam returns the parameter of the method of a path parameter,
+// with its value in the path parameters of rest.Do and its example in the tests.
+func pathParam(p *parameter, t *goType) (*paramModel, string, string, error) {
+	pm := &paramModel{Name: unexported(p.Name), Type: t.String()}
+	var value, example string
+	switch t.kind {
+	case kindString:
+		value = pm.Name
+		example = "123"
+		if len(p.Example) > 0 {
+			if err := json.Unmarshal(p.Example, &example); err != nil {
+				return nil, "", "", fmt.Errorf("parameter %s: %w", p.Name, err)
+			}
+		}
+		pm.Example = strconv.Quote(example)
+	case kindInt64, kindInt:
+		value = "strconv.FormatInt(" + pm.Name + ", 10)"
+		if t.kind == kindInt {
+			value = "strconv.Itoa(" + pm.Name + ")"
+		}
+		example = "123"
+		if len(p.Example) > 0 {
+			example = string(bytes.TrimSpace(p.Example))
+		}
+		pm.Example = example
+	default:
+		return nil, "", "", fmt.Errorf("parameter %s: path parameters must be strings or integers", p.Name)
+	}
+	return pm, value, example, nil
+}
+
+// filter returns the field of a query parameter in the filters of an operation.
+func filter(p *parameter, t *goType) (*filterField, error) {
+	f := &filterField{Name: exported(p.Name), Param: p.Name, Type: t.String(), Doc: p.Description}
+	field := "f." + f.Name
+	switch t.kind {
+	case kindString:
+		f.Cond, f.Value = field+` != ""`, field
+	case kindEnum:
+		f.Cond, f.Value = field+` != ""`, "string("+field+")"
+	case kindInt64:
+		f.Cond, f.Value = field+" != 0", "strconv.FormatInt("+field+", 10)"
+	case kindInt:
+		f.Cond, f.Value = field+" != 0", "strconv.Itoa("+field+")"
+	case kindBool:
+		f.Cond, f.Value = field, `"true"`
+		if p.Required {
+			f.Value = "strconv.FormatBool(" + field + ")"
+		}
+	case kindTime:
+		f.Cond, f.Value = "!"+field+".IsZero()", field+".Format(time.RFC3339)"
+	default:
+		return nil, fmt.Errorf("parameter %s: query parameters must be strings, integers, booleans or times", p.Name)
+	}
+	if p.Required {
+		f.Cond = ""
+	}
+	return f, nil
+}
+
+// testData sets the literal of the response of the tests of an operation.
+func (m *model) testData(o *operationModel) error {
+	if o.Result == nil {
+		return nil
+	}
+	dec := json.NewDecoder(strings.NewReader(o.Example))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return fmt.Errorf("example: %w", err)
+	}
+	want, err := m.literal(o.Result, v, false)
+	if err != nil {
+		return fmt.Errorf("example: %w", err)
+	}
+	o.Want = want
+	return nil
+}
+
+// literal returns the Go literal of a value of an example, decoded with json.Number numbers.
+// With elided, the type of a composite literal is left out, as in the elements of a slice.
+func (m *model) literal(t *goType, v any, elided bool) (string, error) {
+	if v == nil {
+		if t.pointer || t.kind == kindSlice || t.kind == kindMap || t.kind == kindAny {
+			return "nil", nil
+		}
+		return "", fmt.Errorf("null %s", t)
+	}
+
+	switch t.kind {
+	case kindStruct:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return "", fmt.Errorf("%s is not an object", t.name)
+		}
+		st := m.structs[t.name]
+		for k := range obj {
+			if st.byJSON[k] == nil {
+				return "", fmt.Errorf("%s has no field %s", t.name, k)
+			}
+		}
+		var b strings.Builder
+		switch {
+		case t.pointer:
+			b.WriteString("&" + t.name)
+		case !elided:
+			b.WriteString(t.name)
+		}
+		b.WriteString("{")
+		fields := append(append([]*fieldModel(nil), st.Fields...), st.Nested...)
+		for _, f := range fields {
+			fv, ok := obj[f.json]
+			if !ok {
+				continue
+			}
+			lit, err := m.literal(f.t, fv, false)
+			if err != nil {
+				return "", fmt.Errorf("%s.%s: %w", t.name, f.Name, err)
+			}
+			b.WriteString("\n" + f.Name + ": " + lit + ",")
+		}
+		if len(obj) > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("}")
+		return b.String(), nil
+
+	case kindSlice:
+		arr, ok := v.([]any)
+		if !ok {
+			return "", fmt.Errorf("%s is not an array", t)
+		}
+		var b strings.Builder
+		b.WriteString(t.String() + "{")
+		for _, e := range arr {
+			lit, err := m.literal(t.elem, e, true)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString("\n" + lit + ",")
+		}
+		if len(arr) > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("}")
+		return b.String(), nil
+
+	case kindMap:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return "", fmt.Errorf("%s is not an object", t)
+		}
+		keys := make([]string, 0, len(obj))
+		for k := range obj {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var b strings.Builder
+		b.WriteString(t.String() + "{")
+		for _, k := range keys {
+			lit, err := m.literal(t.elem, obj[k], false)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString("\n" + strconv.Quote(k) + ": " + lit + ",")
+		}
+		if len(keys) > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("}")
+		return b.String(), nil
+
+	case kindAny:
+		return anyLiteral(v)
+	}
+
+	lit, err := m.scalar(t, v)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case t.kind == kindTime:
+		m.helpers["mustTime"] = true
+		if t.pointer {
+			return lit, nil
+		}
+		return "*" + lit, nil
+	case t.pointer:
+		m.helpers["ptr"] = true
+		base := *t
+		base.pointer = false
+		return "ptr[" + base.String() + "](" + lit + ")", nil
+	}
+	return lit, nil
+}
+
+// scalar returns the literal of a scalar of an example.
+func (m *model) scalar(t *goType, v any) (string, error) {
+	switch t.kind {
+	case kindString, kindEnum, kindTime:
+		s, ok := v.(string)
+		if !ok {
+			return "", fmt.Errorf("%v is not a string", v)
+		}
+		switch t.kind {
+		case kindTime:
+			return "mustTime(" + strconv.Quote(s) + ")", nil
+		case kindEnum:
+			for _, ev := range m.enums[t.name].Values {
+				if ev.Value == s {
+					return ev.Name, nil
+				}
+			}
+			return t.name + "(" + strconv.Quote(s) + ")", nil
+		}
+		return strconv.Quote(s), nil
+	case kindInt, kindInt64, kindFloat:
+		n, ok := v.(json.Number)
+		if !ok {
+			return "", fmt.Errorf("%v is not a number", v)
+		}
+		if t.kind != kindFloat {
+			if _, err := n.Int64(); err != nil {
+				return "", fmt.Errorf("%v is not an integer", v)
+			}
+		}
+		return n.String(), nil
+	case kindAmount:
+		switch n := v.(type) {
+		case json.Number:
+			return "money.MustParse(" + strconv.Quote(n.String()) + ")", nil
+		case string:
+			return "money.MustParse(" + strconv.Quote(n) + ")", nil
+		}
+		return "", fmt.Errorf("%v is not an amount", v)
+	case kindBool:
+		b, ok := v.(bool)
+		if !ok {
+			return "", fmt.Errorf("%v is not a boolean", v)
+		}
+		return strconv.FormatBool(b), nil
+	}
+	return "", fmt.Errorf("unsupported type %s", t)
+}
+
+// anyLiteral returns the literal of a value decoded into an any: numbers are float64.
+func anyLiteral(v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "nil", nil
+	case string:
+		return strconv.Quote(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case json.Number:
+		return "float64(" + v.String() + ")", nil
+	case []any:
+		var b strings.Builder
+		b.WriteString("[]any{")
+		for _, e := range v {
+			lit, err := anyLiteral(e)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(lit + ", ")
+		}
+		return strings.TrimSuffix(b.String(), ", ") + "}", nil
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var b strings.Builder
+		b.WriteString("map[string]any{")
+		for _, k := range keys {
+			lit, err := anyLiteral(v[k])
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(strconv.Quote(k) + ": " + lit + ", ")
+		}
+		return strings.TrimSuffix(b.String(), ", ") + "}", nil
+	}
+	return "", fmt.Errorf("unsupported value %v", v)
+}
+
+// initialisms are written in upper case in Go names.
+var initialisms = map[string]bool{
+	"api": true, "cpf": true, "cnpj": true, "dni": true, "http": true, "https": true, "id": true, "ip": true,
+	"json": true, "qr": true, "sql": true, "uri": true, "url": true, "uuid": true,
+}
+
+// exported returns the exported Go name of a JSON name, e.g. ID for id and CardID for card_id.
+func exported(name string) string {
+	var b strings.Builder
+	for _, w := range words(name) {
+		if initialisms[strings.ToLower(w)] {
+			b.WriteString(strings.ToUpper(w))
+			continue
+		}
+		r := []rune(w)
+		r[0] = unicode.ToUpper(r[0])
+		b.WriteString(string(r))
+	}
+	return b.String()
+}
+
+// unexported returns the unexported Go name of a JSON or Go name, e.g. id for id and cardID for card_id.
+func unexported(name string) string {
+	ws := words(name)
+	if len(ws) == 0 {
+		return ""
+	}
+	first := strings.ToLower(ws[0])
+	if len(ws) == 1 && !strings.ContainsAny(name, "_-. ") {
+		// a Go name, e.g. ListCards.
+		r := []rune(name)
+		i := 0
+		for i < len(r) && unicode.IsUpper(r[i]) {
+			i++
+		}
+		if i > 1 && i < len(r) {
+			i--
+		}
+		return strings.ToLower(string(r[:max(i, 1)])) + string(r[max(i, 1):])
+	}
+	return first + exported(strings.Join(ws[1:], "_"))
+}
+
+func words(name string) []string {
+	return strings.FieldsFunc(name, func(r rune) bool {
+		return r == '_' || r == '-' || r == '.' || r == ' '
+	})
+}
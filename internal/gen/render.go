@@ -0,0 +1,513 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// imports are the packages the generated code may use, by name.
+var imports = map[string]string{
+	"fmt":     "fmt",
+	"context": "context",
+	"http":    "net/http",
+	"json":    "encoding/json",
+	"money":   "github.com/gdeandradero/sdk-go/pkg/money",
+	"reflect": "reflect",
+	"rest":    "github.com/gdeandradero/sdk-go/pkg/mp/rest",
+	"strconv": "strconv",
+	"strings": "strings",
+	"testing": "testing",
+	"time":    "time",
+	"url":     "net/url",
+}
+
+var funcs = template.FuncMap{
+	"comment": comment,
+	"lower":   strings.ToLower,
+}
+
+// comment returns text as a comment, indented by tabs, after prefix and a space.
+func comment(indent int, prefix, text string) string {
+	text = strings.TrimSpace(text)
+	if prefix != "" {
+		text = prefix + " " + text
+	}
+	tabs := strings.Repeat("\t", indent)
+	return tabs + "// " + strings.ReplaceAll(text, "\n", "\n"+tabs+"// ")
+}
+
+var templates = template.Must(template.New("").Funcs(funcs).Parse(`
+{{define "header"}}// Code generated by internal/gen from {{.Source}}. DO NOT EDIT.
+{{end}}
+
+{{define "client.go"}}{{template "header" .}}
+{{if .Description}}{{comment 0 (print "Package " .Package) .Description}}
+{{end}}package {{.Package}}
+
+const (
+{{- range .Operations}}
+	{{.URLConst}} = "{{.URL}}"
+{{- end}}
+)
+
+// Client contains the methods to interact with the {{.Title}}.
+type Client interface {
+{{- range $i, $op := .Operations}}
+{{- if $i}}
+{{end}}
+{{comment 1 .Name .Summary}}
+	// It is a {{.Method | lower}} request to the endpoint: {{.URL}}
+{{- if .Reference}}
+	// Reference: {{.Reference}}
+{{- end}}
+	{{.Name}}({{.Signature}}) {{.Returns}}
+{{- end}}
+}
+
+// client is the implementation of Client.
+type client struct {
+	rc rest.Client
+}
+
+// NewClient returns a new {{.Title}} Client.
+func NewClient(restClient rest.Client) Client {
+	return &client{
+		rc: restClient,
+	}
+}
+{{range .Operations}}
+func (c *client) {{.Name}}({{.Signature}}) {{.Returns}} {
+{{- if .Filters}}
+	params := url.Values{}
+{{- range .Filters.Fields}}
+{{- if .Cond}}
+	if {{.Cond}} {
+		params.Add("{{.Param}}", {{.Value}})
+	}
+{{- else}}
+	params.Add("{{.Param}}", {{.Value}})
+{{- end}}
+{{- end}}
+{{end}}
+{{- if .Result}}
+{{- if .Result.Pointer}}
+	return rest.Do[{{.BodyType}}, {{.Result.Name}}](context.Background(), c.rc, http.Method{{.Method}}, {{.URLConst}}, {{.PathParams}}, {{.Query}}, {{.BodyArg}},
+		append([]rest.Option{rest.WithOperation("{{$.Package}}.{{.Name}}")}, opts...)...)
+{{- else}}
+	res, err := rest.Do[{{.BodyType}}, {{.Result}}](context.Background(), c.rc, http.Method{{.Method}}, {{.URLConst}}, {{.PathParams}}, {{.Query}}, {{.BodyArg}},
+		append([]rest.Option{rest.WithOperation("{{$.Package}}.{{.Name}}")}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return *res, nil
+{{- end}}
+{{- else}}
+	_, err := rest.Do[{{.BodyType}}, rest.NoBody](context.Background(), c.rc, http.Method{{.Method}}, {{.URLConst}}, {{.PathParams}}, {{.Query}}, {{.BodyArg}},
+		append([]rest.Option{rest.WithOperation("{{$.Package}}.{{.Name}}")}, opts...)...)
+	return err
+{{- end}}
+}
+{{end}}
+{{- end}}
+
+{{define "fields"}}
+{{- range $i, $f := .Fields}}
+{{- if and $.Spaced $i}}
+{{end}}
+{{- if .Doc}}
+{{comment 1 .Name .Doc}}
+{{- end}}
+	{{.Name}} {{.Type}} {{.Tag}}
+{{- end}}
+{{- if .Nested}}
+{{if .Fields}}
+{{end}}
+{{- range .Nested}}
+	{{.Name}} {{.Type}} {{.Tag}}
+{{- end}}
+{{- end}}
+{{- if .Extra}}
+
+	Extra map[string]json.RawMessage ` + "`json:\"-\"`" + `
+{{- end}}
+{{- end}}
+
+{{define "models.go"}}{{template "header" .}}
+package {{.Package}}
+{{range .Enums}}{{$enum := .}}
+{{if .Doc}}{{comment 0 .Name .Doc}}
+{{end}}type {{.Name}} string
+
+const (
+{{- range $i, $v := .Values}}
+{{- if and $i .Doc}}
+{{end}}
+{{- if .Doc}}
+{{comment 1 .Name .Doc}}
+{{- end}}
+	{{.Name}} {{$enum.Name}} = "{{.Value}}"
+{{- end}}
+)
+
+// IsKnown reports whether {{.Receiver}} is one of the documented values.
+func ({{.Receiver}} {{.Name}}) IsKnown() bool {
+	switch {{.Receiver}} {
+	case {{range $i, $v := .Values}}{{if $i}}, {{end}}{{.Name}}{{end}}:
+		return true
+	}
+	return false
+}
+{{end}}
+{{- range .Structs}}
+{{if .Doc}}{{comment 0 .Name .Doc}}
+{{end}}type {{.Name}} struct {
+{{- template "fields" .}}
+}
+{{end}}
+{{- range .Filters}}
+// {{.Name}} is the filters of {{.Operation}}.
+type {{.Name}} struct {
+{{- range $i, $f := .Fields}}
+{{- if $i}}
+{{end}}
+{{- if .Doc}}
+{{comment 1 .Name .Doc}}
+{{- end}}
+	{{.Name}} {{.Type}}
+{{- end}}
+}
+{{end}}
+{{- if .ExtraStructs}}
+{{template "extra" .ExtraStructs}}
+{{- end}}
+{{- end}}
+
+{{define "extra"}}// The response structs keep the fields they do not model in Extra, see rest.DecodeExtra.
+{{range .}}
+func (r *{{.}}) UnmarshalJSON(b []byte) error {
+	type plain {{.}}
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r {{.}}) MarshalJSON() ([]byte, error) {
+	type plain {{.}}
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+{{end}}
+{{- end}}
+
+{{define "extra.go"}}{{template "header" .}}
+package {{.Package}}
+
+{{template "extra" .Structs}}
+{{- end}}
+
+{{define "fake"}}{{template "header" .}}
+// Package {{.Name}} provides Client, a fake {{.Package}}.Client for the tests of the code using it.
+//
+// Each method of Client records the call, then calls the function field of the same name:
+//
+//	c := &{{.Name}}.Client{
+//		{{with index .Methods 0}}{{.Name}}Func: func({{.Signature}}) {{.Returns}} {
+//			// ...
+//		},
+//	}
+//	// ... code under test using c ...
+//	c.AssertCalls(t, {{$.Name}}.Expect("{{.Name}}"))
+{{- end}}
+package {{.Name}}
+
+// ErrNotConfigured is returned by the methods whose function field is not set. The methods returning no error
+// panic with it.
+var ErrNotConfigured = calls.ErrNotConfigured
+
+// Call is a recorded or an expected call of a method of Client. Args are the arguments of the call without
+// the context and the options.
+type Call = calls.Call
+
+// Expect returns the expected call of a method with args, or with any arguments if none is given.
+func Expect(method string, args ...any) Call {
+	return calls.Expect(method, args...)
+}
+
+// Client is a fake {{.Package}}.Client. Its methods record the call and call the function field of the same name,
+// or fail with ErrNotConfigured if it is not set. Its zero value is ready to use, and it is safe for concurrent
+// use as long as the function fields are not changed while it is used.
+type Client struct {
+{{- range .Methods}}
+	{{.Name}}Func func({{.Signature}}) {{.Returns}}
+{{- end}}
+
+	calls.Recorder
+}
+
+var _ {{.Package}}.Client = (*Client)(nil)
+{{range .Methods}}
+func (c *Client) {{.Name}}({{.Signature}}) {{.Returns}} {
+	c.Record({{.Record}})
+	if c.{{.Name}}Func == nil {
+		{{.NotConfigured}}
+	}
+	{{if .Results}}return {{end}}c.{{.Name}}Func({{.Args}})
+}
+{{end}}
+func notConfigured(method string) error {
+	return fmt.Errorf("{{.Name}}: %s: %w", method, ErrNotConfigured)
+}
+{{- end}}
+
+{{define "client_test.go"}}{{template "header" .}}
+package {{.Package}}
+{{range .Operations}}
+func TestClient{{.Name}}(t *testing.T) {
+	type fields struct {
+		rc rest.Client
+	}
+	type args struct {
+{{- range .Params}}
+		{{.Name}} {{.Type}}
+{{- end}}
+{{- if .Filters}}
+		f {{.Filters.Name}}
+{{- end}}
+{{- if .Body}}
+		dto {{.Body}}
+{{- end}}
+		opts []rest.Option
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+{{- if .Result}}
+		want    {{.Result}}
+{{- end}}
+		wantErr string
+	}{
+		{
+			name: "should_return_send_error",
+			fields: fields{
+				rc: &rest.Mock{
+					SendMock: func(req *http.Request, opts ...rest.Option) ([]byte, error) {
+						return nil, fmt.Errorf("some error")
+					},
+				},
+			},
+			args:    args{ {{- template "args" .}}},
+			wantErr: "some error",
+		},
+{{- if .Result}}
+		{
+			name: "should_return_unmarshal_error",
+			fields: fields{
+				rc: &rest.Mock{
+					SendMock: func(req *http.Request, opts ...rest.Option) ([]byte, error) {
+						return []byte("malformed json"), nil
+					},
+				},
+			},
+			args:    args{ {{- template "args" .}}},
+			wantErr: "invalid character 'm' looking for beginning of value",
+		},
+{{- end}}
+		{
+			name: "should_return_success",
+			fields: fields{
+				rc: &rest.Mock{
+					SendMock: func(req *http.Request, opts ...rest.Option) ([]byte, error) {
+						if req.Method != http.Method{{.Method}} || req.URL.Path != "{{.TestPath}}" {
+							return nil, fmt.Errorf("unexpected request %s %s", req.Method, req.URL.Path)
+						}
+						return []byte(` + "`{{.Example}}`" + `), nil
+					},
+				},
+			},
+			args: args{ {{- template "args" .}}},
+{{- if .Result}}
+			want: {{.Want}},
+{{- end}}
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &client{
+				rc: tt.fields.rc,
+			}
+			{{if .Result}}got, {{end}}err := c.{{.Name}}({{.TestArgs}})
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+
+			if gotErr != tt.wantErr {
+				t.Errorf("client.{{.Name}}() error = %v, wantErr %v", err, tt.wantErr)
+			}
+{{- if .Result}}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("client.{{.Name}}() = %v, want %v", got, tt.want)
+			}
+{{- end}}
+		})
+	}
+}
+{{end}}
+{{- if .Helper "ptr"}}
+func ptr[T any](v T) *T {
+	return &v
+}
+{{end}}
+{{- if .Helper "mustTime"}}
+func mustTime(s string) *time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return &t
+}
+{{end}}
+{{- end}}
+
+{{define "args"}}{{range $i, $p := .Params}}{{if $i}}, {{end}}{{.Name}}: {{.Example}}{{end}}{{end}}
+`))
+
+// files are the files written in the package, by template.
+var files = []string{"client.go", "models.go", "client_test.go"}
+
+// ExtraStructs returns the names of the structs keeping the unknown fields of the responses.
+func (m *model) ExtraStructs() []string {
+	var names []string
+	for _, st := range m.Structs {
+		if st.Extra {
+			names = append(names, st.Name)
+		}
+	}
+	return names
+}
+
+// Helper reports whether the tests use a helper function.
+func (m *model) Helper(name string) bool {
+	return m.helpers[name]
+}
+
+// BodyType returns the Req of rest.Do.
+func (o *operationModel) BodyType() string {
+	if o.Body == "" {
+		return "rest.NoBody"
+	}
+	return o.Body
+}
+
+// BodyArg returns the body argument of rest.Do.
+func (o *operationModel) BodyArg() string {
+	if o.Body == "" {
+		return "nil"
+	}
+	return "&dto"
+}
+
+// Query returns the query argument of rest.Do.
+func (o *operationModel) Query() string {
+	if o.Filters == nil {
+		return "nil"
+	}
+	return "params"
+}
+
+// TestArgs returns the arguments of the call of the method in its test.
+func (o *operationModel) TestArgs() string {
+	var args []string
+	for _, p := range o.Params {
+		args = append(args, "tt.args."+p.Name)
+	}
+	if o.Filters != nil {
+		args = append(args, "tt.args.f")
+	}
+	if o.Body != "" {
+		args = append(args, "tt.args.dto")
+	}
+	return strings.Join(append(args, "tt.args.opts..."), ", ")
+}
+
+// render returns the generated files, by name.
+func render(m *model) (map[string][]byte, error) {
+	out := map[string][]byte{}
+	for _, name := range files {
+		var b bytes.Buffer
+		if err := templates.ExecuteTemplate(&b, name, m); err != nil {
+			return nil, err
+		}
+		src, err := addImports(b.Bytes(), imports)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		out[name] = src
+	}
+	return out, nil
+}
+
+// addImports adds the imports of the packages used by src, among imports, and formats it.
+func addImports(src []byte, imports map[string]string) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("%w\n%s", err, src)
+	}
+
+	used := map[string]bool{}
+	ast.Inspect(f, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok {
+				used[id.Name] = true
+			}
+		}
+		return true
+	})
+
+	var std, module []string
+	for name, path := range imports {
+		switch {
+		case !used[name]:
+		case strings.Contains(path, "."):
+			module = append(module, path)
+		default:
+			std = append(std, path)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(module)
+
+	var decl strings.Builder
+	switch len(std) + len(module) {
+	case 0:
+	case 1:
+		fmt.Fprintf(&decl, "\nimport %q\n", append(std, module...)[0])
+	default:
+		decl.WriteString("\nimport (\n")
+		for _, p := range std {
+			fmt.Fprintf(&decl, "\t%q\n", p)
+		}
+		if len(std) > 0 && len(module) > 0 {
+			decl.WriteString("\n")
+		}
+		for _, p := range module {
+			fmt.Fprintf(&decl, "\t%q\n", p)
+		}
+		decl.WriteString(")\n")
+	}
+
+	end := fset.Position(f.Name.End()).Offset
+	out := append(append(append([]byte(nil), src[:end]...), "\n"+decl.String()...), src[end:]...)
+	formatted, err := format.Source(out)
+	if err != nil {
+		return nil, fmt.Errorf("%w\n%s", err, out)
+	}
+	return formatted, nil
+}
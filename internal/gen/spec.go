@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// spec is the subset of an OpenAPI 3 description read by the generator.
+type spec struct {
+	Info struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+	} `json:"info"`
+	Servers []struct {
+		URL string `json:"url"`
+	} `json:"servers"`
+	Paths      ordered[ordered[*operation]] `json:"paths"`
+	Components struct {
+		Schemas ordered[*schema] `json:"schemas"`
+	} `json:"components"`
+}
+
+type operation struct {
+	OperationID  string `json:"operationId"`
+	Summary      string `json:"summary"`
+	ExternalDocs *struct {
+		URL string `json:"url"`
+	} `json:"externalDocs"`
+	Parameters  []*parameter `json:"parameters"`
+	RequestBody *struct {
+		Content map[string]*mediaType `json:"content"`
+	} `json:"requestBody"`
+	Responses ordered[*response] `json:"responses"`
+
+	// Filters is the name of the struct of the query parameters, OperationID + "Filters" by default.
+	Filters string `json:"x-go-filters"`
+}
+
+type parameter struct {
+	Name        string          `json:"name"`
+	In          string          `json:"in"`
+	Description string          `json:"description"`
+	Required    bool            `json:"required"`
+	Schema      *schema         `json:"schema"`
+	Example     json.RawMessage `json:"example"`
+}
+
+type response struct {
+	Description string                `json:"description"`
+	Content     map[string]*mediaType `json:"content"`
+}
+
+type mediaType struct {
+	Schema  *schema         `json:"schema"`
+	Example json.RawMessage `json:"example"`
+}
+
+type schema struct {
+	Ref                  string           `json:"$ref"`
+	Type                 string           `json:"type"`
+	Format               string           `json:"format"`
+	Description          string           `json:"description"`
+	Properties           ordered[*schema] `json:"properties"`
+	Required             []string         `json:"required"`
+	Items                *schema          `json:"items"`
+	AdditionalProperties json.RawMessage  `json:"additionalProperties"`
+	Enum                 []string         `json:"enum"`
+	Nullable             bool             `json:"nullable"`
+
+	// GoName overrides the name of the field of a property.
+	GoName string `json:"x-go-name"`
+
+	// EnumNames and EnumDescriptions name and document the constants of an enum.
+	EnumNames        []string `json:"x-enum-names"`
+	EnumDescriptions []string `json:"x-enum-descriptions"`
+}
+
+// ordered is a JSON object decoded with the order of its keys, which is the order of the generated code.
+type ordered[T any] struct {
+	Keys   []string
+	Values map[string]T
+}
+
+func (o *ordered[T]) UnmarshalJSON(b []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return errors.New("expected an object")
+	}
+	o.Values = map[string]T{}
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key := t.(string)
+		var v T
+		if err := dec.Decode(&v); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		o.Keys = append(o.Keys, key)
+		o.Values[key] = v
+	}
+	return nil
+}
+
+// loadSpec reads the OpenAPI description at path.
+func loadSpec(path string) (*spec, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s := &spec{}
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(s.Servers) == 0 {
+		return nil, fmt.Errorf("%s: no server", path)
+	}
+	return s, nil
+}
+
+// resolve returns the name of the component a schema refers to.
+func (s *spec) resolve(ref string) (string, *schema, error) {
+	name, ok := strings.CutPrefix(ref, "#/components/schemas/")
+	if !ok {
+		return "", nil, fmt.Errorf("unsupported $ref %q", ref)
+	}
+	sc, ok := s.Components.Schemas.Values[name]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown schema %q", name)
+	}
+	return name, sc, nil
+}
+
+// jsonContent returns the application/json content of a request or response, nil if there is none.
+func jsonContent(content map[string]*mediaType) *mediaType {
+	return content["application/json"]
+}
@@ -0,0 +1,261 @@
+// Package boleto provides helpers for bank boletos: converting between the 44-digit barcode
+// and the 47-digit digitable line (linha digitável), validating their check digits,
+// and extracting the due date and the amount.
+//
+// Only bank boletos are supported, not the 48-digit collection slips (arrecadação) starting with 8.
+package boleto
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/money"
+	"github.com/gdeandradero/sdk-go/pkg/payment"
+)
+
+const (
+	// BarcodeLength is the number of digits of a barcode.
+	BarcodeLength = 44
+
+	// DigitableLineLength is the number of digits of a digitable line.
+	DigitableLineLength = 47
+)
+
+var (
+	// ErrInvalid is returned when a code is not a barcode or a digitable line.
+	ErrInvalid = errors.New("invalid boleto")
+
+	// ErrCheckDigit is returned when a check digit does not match.
+	ErrCheckDigit = errors.New("invalid boleto check digit")
+
+	// ErrNoBoleto is returned by FromPayment when the payment has no barcode.
+	ErrNoBoleto = errors.New("payment has no boleto barcode")
+)
+
+// The due date factor counts days from a base date. It went back to 1000 after reaching 9999,
+// on 2025-02-22.
+var (
+	factorBase       = time.Date(1997, 10, 7, 0, 0, 0, 0, time.UTC)
+	factorCycleStart = time.Date(2025, 2, 22, 0, 0, 0, 0, time.UTC)
+)
+
+// now is replaced in tests.
+var now = time.Now
+
+// Boleto is a parsed bank boleto.
+type Boleto struct {
+	// Barcode is the 44-digit barcode.
+	Barcode string
+
+	// Bank is the code of the issuing bank, e.g. "237".
+	Bank string
+
+	// Currency is the currency code, "9" for BRL.
+	Currency string
+
+	// DueFactor is the number of days of the due date since the base date, 0 if the boleto has no due date.
+	DueFactor int
+
+	// Amount is zero when the payer chooses the amount.
+	Amount money.Amount
+
+	// FreeField is the 25-digit field defined by the issuing bank.
+	FreeField string
+}
+
+// Parse parses a barcode or a digitable line, ignoring spaces, dots and dashes.
+func Parse(code string) (*Boleto, error) {
+	code = digits(code)
+	switch len(code) {
+	case BarcodeLength:
+		return ParseBarcode(code)
+	case DigitableLineLength:
+		return ParseDigitableLine(code)
+	default:
+		return nil, fmt.Errorf("%w: %d digits", ErrInvalid, len(code))
+	}
+}
+
+// ParseBarcode parses and validates a 44-digit barcode.
+func ParseBarcode(barcode string) (*Boleto, error) {
+	barcode = digits(barcode)
+	if len(barcode) != BarcodeLength {
+		return nil, fmt.Errorf("%w: barcode has %d digits, want %d", ErrInvalid, len(barcode), BarcodeLength)
+	}
+	if strings.ContainsFunc(barcode, notDigit) {
+		return nil, fmt.Errorf("%w: barcode has non-digit characters", ErrInvalid)
+	}
+	if barcode[0] == '8' {
+		return nil, fmt.Errorf("%w: collection slips are not supported", ErrInvalid)
+	}
+	if want := mod11(barcode[:4] + barcode[5:]); barcode[4] != want {
+		return nil, fmt.Errorf("%w: barcode check digit is %c, want %c", ErrCheckDigit, barcode[4], want)
+	}
+
+	factor, _ := strconv.Atoi(barcode[5:9])
+	cents, _ := strconv.ParseInt(barcode[9:19], 10, 64)
+	return &Boleto{
+		Barcode:   barcode,
+		Bank:      barcode[:3],
+		Currency:  barcode[3:4],
+		DueFactor: factor,
+		Amount:    money.FromMinor(cents, money.BRL),
+		FreeField: barcode[19:],
+	}, nil
+}
+
+// ParseDigitableLine parses and validates a 47-digit digitable line.
+func ParseDigitableLine(line string) (*Boleto, error) {
+	barcode, err := DigitableLineToBarcode(line)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBarcode(barcode)
+}
+
+// DigitableLineToBarcode converts a digitable line to a barcode, validating the check digits of its fields.
+func DigitableLineToBarcode(line string) (string, error) {
+	line = digits(line)
+	if len(line) != DigitableLineLength {
+		return "", fmt.Errorf("%w: digitable line has %d digits, want %d", ErrInvalid, len(line), DigitableLineLength)
+	}
+
+	if strings.ContainsFunc(line, notDigit) {
+		return "", fmt.Errorf("%w: digitable line has non-digit characters", ErrInvalid)
+	}
+
+	fields := []string{line[0:10], line[10:21], line[21:32]}
+	for i, field := range fields {
+		body, dv := field[:len(field)-1], field[len(field)-1]
+		if want := mod10(body); dv != want {
+			return "", fmt.Errorf("%w: field %d check digit is %c, want %c", ErrCheckDigit, i+1, dv, want)
+		}
+	}
+
+	return line[0:4] + line[32:33] + line[33:47] + line[4:9] + line[10:20] + line[21:31], nil
+}
+
+// BarcodeToDigitableLine converts a barcode to a digitable line, without formatting.
+func BarcodeToDigitableLine(barcode string) (string, error) {
+	b, err := ParseBarcode(barcode)
+	if err != nil {
+		return "", err
+	}
+	return b.DigitableLine(), nil
+}
+
+// DigitableLine returns the 47-digit digitable line of the boleto, without formatting.
+func (b *Boleto) DigitableLine() string {
+	field1 := b.Barcode[0:4] + b.FreeField[0:5]
+	field2 := b.FreeField[5:15]
+	field3 := b.FreeField[15:25]
+	return field1 + string(mod10(field1)) +
+		field2 + string(mod10(field2)) +
+		field3 + string(mod10(field3)) +
+		b.Barcode[4:5] + b.Barcode[5:19]
+}
+
+// FormattedDigitableLine returns the digitable line as printed on boletos,
+// e.g. "00190.50095 40144.816069 06809.350314 3 37370000000100".
+func (b *Boleto) FormattedDigitableLine() string {
+	l := b.DigitableLine()
+	return l[0:5] + "." + l[5:10] + " " + l[10:15] + "." + l[15:21] + " " + l[21:26] + "." + l[26:32] + " " + l[32:33] + " " + l[33:]
+}
+
+// DueDate returns the due date of the boleto, and false if it has none.
+// Each factor matches two dates 9000 days apart; the one closest to today is returned.
+func (b *Boleto) DueDate() (time.Time, bool) {
+	if b.DueFactor == 0 {
+		return time.Time{}, false
+	}
+
+	first := factorBase.AddDate(0, 0, b.DueFactor)
+	second := factorCycleStart.AddDate(0, 0, b.DueFactor-1000)
+	today := now()
+	if today.Sub(first).Abs() < today.Sub(second).Abs() {
+		return first, true
+	}
+	return second, true
+}
+
+// DueFactor returns the due date factor of the date, in the current cycle of factors.
+func DueFactor(date time.Time) int {
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	if date.Before(factorCycleStart) {
+		return int(date.Sub(factorBase).Hours() / 24)
+	}
+	return 1000 + int(date.Sub(factorCycleStart).Hours()/24)%9000
+}
+
+// FromPayment returns the boleto of the payment, checking that its digitable line and barcode agree
+// and that the amount matches the payment.
+func FromPayment(p *payment.Response) (*Boleto, error) {
+	td := p.TransactionDetails
+	if td == nil || (td.Barcode == nil || td.Barcode.Content == "") && td.DigitableLine == "" {
+		return nil, ErrNoBoleto
+	}
+
+	var (
+		b   *Boleto
+		err error
+	)
+	if td.Barcode != nil && td.Barcode.Content != "" {
+		b, err = ParseBarcode(td.Barcode.Content)
+	} else {
+		b, err = ParseDigitableLine(td.DigitableLine)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if td.DigitableLine != "" && digits(td.DigitableLine) != b.DigitableLine() {
+		return nil, fmt.Errorf("%w: digitable line does not match the barcode", ErrInvalid)
+	}
+	if !b.Amount.IsZero() && !p.TransactionAmount.IsZero() && !b.Amount.Equal(p.TransactionAmount.Round(money.BRL)) {
+		return nil, fmt.Errorf("%w: amount %s does not match the payment amount %s", ErrInvalid, b.Amount, p.TransactionAmount)
+	}
+	return b, nil
+}
+
+// mod10 returns the modulo 10 check digit of s: digits are weighted 2, 1, 2... from the right,
+// and the digits of each product are added.
+func mod10(s string) byte {
+	sum := 0
+	for i := len(s) - 1; i >= 0; i-- {
+		n := int(s[i]-'0') * (2 - (len(s)-1-i)%2)
+		sum += n/10 + n%10
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// mod11 returns the modulo 11 check digit of a barcode without it: digits are weighted 2 to 9 from the right,
+// and results 0, 10 and 11 become 1.
+func mod11(s string) byte {
+	sum := 0
+	for i := len(s) - 1; i >= 0; i-- {
+		sum += int(s[i]-'0') * (2 + (len(s)-1-i)%8)
+	}
+	dv := 11 - sum%11
+	if dv == 0 || dv == 10 || dv == 11 {
+		dv = 1
+	}
+	return byte('0' + dv)
+}
+
+// digits returns s without spaces, dots and dashes. Other characters are kept so that they fail validation.
+func digits(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '.', '-', '\t', '\n':
+			return -1
+		}
+		return r
+	}, s)
+}
+
+func notDigit(r rune) bool {
+	return r < '0' || r > '9'
+}
@@ -0,0 +1,121 @@
+package boleto
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/money"
+	"github.com/gdeandradero/sdk-go/pkg/payment"
+)
+
+const (
+	barcode       = "00193373700000001000500940144816060680935031"
+	digitableLine = "00190500954014481606906809350314337370000000100"
+	formatted     = "00190.50095 40144.816069 06809.350314 3 37370000000100"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		wantErr error
+	}{
+		{name: "should_parse_barcode", code: barcode},
+		{name: "should_parse_digitable_line", code: digitableLine},
+		{name: "should_parse_formatted_digitable_line", code: formatted},
+		{name: "should_fail_on_barcode_check_digit", code: barcode[:4] + "2" + barcode[5:], wantErr: ErrCheckDigit},
+		{name: "should_fail_on_field_check_digit", code: digitableLine[:9] + "0" + digitableLine[10:], wantErr: ErrCheckDigit},
+		{name: "should_fail_on_general_check_digit", code: digitableLine[:32] + "4" + digitableLine[33:], wantErr: ErrCheckDigit},
+		{name: "should_fail_on_length", code: barcode[:40], wantErr: ErrInvalid},
+		{name: "should_fail_on_letters", code: "A" + barcode[1:], wantErr: ErrInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.code)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got.Barcode != barcode || got.Bank != "001" || got.Currency != "9" || got.DueFactor != 3737 {
+				t.Errorf("Parse() = %+v", got)
+			}
+			if !got.Amount.Equal(money.FromInt(1)) {
+				t.Errorf("Parse() amount = %v, want %v", got.Amount, money.FromInt(1))
+			}
+			if got.DigitableLine() != digitableLine || got.FormattedDigitableLine() != formatted {
+				t.Errorf("Boleto.FormattedDigitableLine() = %v, want %v", got.FormattedDigitableLine(), formatted)
+			}
+		})
+	}
+}
+
+func TestDueDate(t *testing.T) {
+	defer func() { now = time.Now }()
+
+	tests := []struct {
+		name   string
+		now    time.Time
+		factor int
+		want   time.Time
+	}{
+		{
+			name:   "first_cycle",
+			now:    time.Date(2008, 1, 1, 0, 0, 0, 0, time.UTC),
+			factor: 3737,
+			want:   time.Date(2007, 12, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "last_day_of_first_cycle",
+			now:    time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+			factor: 9999,
+			want:   time.Date(2025, 2, 21, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "second_cycle",
+			now:    time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			factor: 1000,
+			want:   time.Date(2025, 2, 22, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = func() time.Time { return tt.now }
+			got, ok := (&Boleto{DueFactor: tt.factor}).DueDate()
+			if !ok || !got.Equal(tt.want) {
+				t.Errorf("Boleto.DueDate() = %v, want %v", got, tt.want)
+			}
+			if f := DueFactor(tt.want); f != tt.factor {
+				t.Errorf("DueFactor() = %v, want %v", f, tt.factor)
+			}
+		})
+	}
+
+	if _, ok := (&Boleto{}).DueDate(); ok {
+		t.Error("Boleto.DueDate() ok = true without due date")
+	}
+}
+
+func TestFromPayment(t *testing.T) {
+	p := &payment.Response{
+		TransactionAmount: money.FromInt(1),
+		TransactionDetails: &payment.TransactionDetailsResponse{
+			DigitableLine: digitableLine,
+			Barcode:       &payment.BarcodeResponse{Content: barcode},
+		},
+	}
+	if _, err := FromPayment(p); err != nil {
+		t.Errorf("FromPayment() error = %v", err)
+	}
+
+	p.TransactionAmount = money.FromInt(2)
+	if _, err := FromPayment(p); !errors.Is(err, ErrInvalid) {
+		t.Errorf("FromPayment() error = %v, wantErr %v", err, ErrInvalid)
+	}
+
+	if _, err := FromPayment(&payment.Response{}); !errors.Is(err, ErrNoBoleto) {
+		t.Errorf("FromPayment() error = %v, wantErr %v", err, ErrNoBoleto)
+	}
+}
@@ -0,0 +1,302 @@
+// Package bulk runs cancellations, captures and refunds of many payments with a pool of workers.
+// Progress is checkpointed to a file, so an interrupted job resumes without acting twice on a payment.
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/money"
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+	"github.com/gdeandradero/sdk-go/pkg/payment"
+)
+
+const defaultWorkers = 4
+
+// Action is the operation run on a payment.
+type Action string
+
+const (
+	Cancel  Action = "cancel"
+	Capture Action = "capture"
+	Refund  Action = "refund"
+)
+
+// Item is an action on a payment. A zero Amount captures or refunds the whole amount.
+type Item struct {
+	// ID, if set, identifies the item within the job: items repeated in the stream with the same ID are run once.
+	// Items without an ID are identified by their position in the stream, so a resumed job must read them in
+	// the same order.
+	ID string `json:"id,omitempty"`
+
+	PaymentID int64        `json:"payment_id"`
+	Action    Action       `json:"action"`
+	Amount    money.Amount `json:"amount,omitzero"`
+}
+
+// key identifies the item at position in the stream, within its job.
+func (i Item) key(position int) string {
+	if i.ID != "" {
+		return "id:" + i.ID
+	}
+	return "#" + strconv.Itoa(position)
+}
+
+// sameAction reports whether i and o run the same action on the same payment.
+func (i Item) sameAction(o Item) bool {
+	return i.PaymentID == o.PaymentID && i.Action == o.Action && i.Amount.Equal(o.Amount)
+}
+
+// Status is the outcome of an item.
+type Status string
+
+const (
+	// Succeeded items were run successfully.
+	Succeeded Status = "succeeded"
+
+	// Failed items were run and failed, they are run again when the job is resumed.
+	Failed Status = "failed"
+
+	// Skipped items had already succeeded in a previous run of the job.
+	Skipped Status = "skipped"
+
+	// started marks in the checkpoint the items being run.
+	started Status = "started"
+)
+
+// Result is the outcome of an item.
+type Result struct {
+	Item
+
+	// JobID is the ID of the job that ran the item.
+	JobID string `json:"job_id"`
+
+	// Position is the position of the item in the stream, from 0.
+	Position int `json:"position"`
+
+	Status     Status        `json:"status"`
+	Error      string        `json:"error,omitempty"`
+	StatusCode int           `json:"status_code,omitempty"`
+	Duration   time.Duration `json:"duration,omitempty"`
+
+	// PaymentStatus is the status of the payment after a cancellation or capture.
+	PaymentStatus payment.Status `json:"payment_status,omitempty"`
+
+	// RefundID is the ID of the refund created by a refund.
+	RefundID int64 `json:"refund_id,omitempty"`
+}
+
+// ErrNoJobID is returned by Run when Config.JobID is empty.
+var ErrNoJobID = errors.New("bulk: job ID is required")
+
+// Config configures a job.
+type Config struct {
+	// JobID identifies the job, and is part of the idempotency keys of its calls. It is required.
+	// A resumed job must keep its ID, while a new job must have a new one: otherwise, e.g. a second partial refund
+	// of the same amount of a payment would be replayed from the first one instead of being sent.
+	JobID string
+
+	// Workers is the number of items run at the same time, 4 by default.
+	// The rate limiter of the rest client still applies to every call.
+	Workers int
+
+	// Checkpoint is the path of the file where the progress is saved. The job is not resumable if empty.
+	Checkpoint string
+
+	// Options are passed to every call, e.g. payment.WithPreCheck.
+	Options []rest.Option
+
+	// OnResult is called with the result of each item, from the workers.
+	OnResult func(r Result)
+}
+
+// Run runs the items read from items until it is closed or ctx is done.
+//
+// Each call is sent with an idempotency key derived from the job ID and the item, so a call interrupted by a crash
+// is deduplicated by the API when the job is resumed, and with ctx, so it is cancelled with the job.
+// Items that succeeded according to the checkpoint are skipped. Items repeated in the stream with the same ID
+// are run once.
+//
+// It returns the report of the items read so far, and ctx.Err() if ctx is done.
+func Run(ctx context.Context, pc payment.Client, items <-chan Item, config Config) (*Report, error) {
+	if config.JobID == "" {
+		return nil, ErrNoJobID
+	}
+	workers := config.Workers
+	if workers <= 0 {
+		workers = defaultWorkers
+	}
+
+	cp, err := openCheckpoint(config.Checkpoint, config.JobID)
+	if err != nil {
+		return nil, err
+	}
+	defer cp.close()
+
+	type job struct {
+		index int
+		item  Item
+	}
+	var (
+		jobs    = make(chan job)
+		mu      sync.Mutex
+		results []indexed
+		wg      sync.WaitGroup
+		seen    = map[string]bool{}
+	)
+	record := func(index int, r Result) {
+		mu.Lock()
+		results = append(results, indexed{index: index, result: r})
+		mu.Unlock()
+		if config.OnResult != nil {
+			config.OnResult(r)
+		}
+	}
+
+	var cpErr error
+	var cpOnce sync.Once
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				if err := cp.write(Result{Item: j.item, JobID: config.JobID, Position: j.index, Status: started}); err != nil {
+					cpOnce.Do(func() { cpErr = err })
+					continue
+				}
+				r := run(ctx, pc, j.item, config.JobID+":"+j.item.key(j.index), config.Options)
+				r.JobID, r.Position = config.JobID, j.index
+				if ctx.Err() != nil && r.Status == Failed {
+					// interrupted, it is run again when the job is resumed.
+					continue
+				}
+				if err := cp.write(r); err != nil {
+					cpOnce.Do(func() { cpErr = err })
+				}
+				record(j.index, r)
+			}
+		}()
+	}
+
+	index := 0
+feed:
+	for {
+		select {
+		case <-ctx.Done():
+			break feed
+		case item, ok := <-items:
+			if !ok {
+				break feed
+			}
+			key := item.key(index)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			if prev, ok := cp.done[key]; ok {
+				prev.Position = index
+				if !prev.sameAction(item) {
+					// the item was changed since the checkpoint: running it could act twice on a payment.
+					record(index, Result{Item: item, JobID: config.JobID, Position: index, Status: Failed,
+						Error: fmt.Sprintf("item differs from the checkpoint, where it is %s on payment %d", prev.Action, prev.PaymentID)})
+					index++
+					continue
+				}
+				prev.Status = Skipped
+				record(index, prev)
+				index++
+				continue
+			}
+			select {
+			case jobs <- job{index: index, item: item}:
+				index++
+			case <-ctx.Done():
+				break feed
+			}
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool { return results[i].index < results[j].index })
+	report := &Report{}
+	for _, r := range results {
+		report.add(r.result)
+	}
+
+	if cpErr != nil {
+		return report, fmt.Errorf("error writing checkpoint: %w", cpErr)
+	}
+	return report, ctx.Err()
+}
+
+type indexed struct {
+	index  int
+	result Result
+}
+
+// run runs an item, with the business key of its idempotency key.
+func run(ctx context.Context, pc payment.Client, item Item, businessKey string, opts []rest.Option) Result {
+	opts = append(opts[:len(opts):len(opts)], rest.WithContext(ctx), rest.WithIdempotencyKey("bulk:"+businessKey))
+	start := time.Now()
+
+	r := Result{Item: item}
+	var (
+		p   *payment.Response
+		err error
+	)
+	switch item.Action {
+	case Cancel:
+		p, err = pc.Cancel(item.PaymentID, opts...)
+	case Capture:
+		if item.Amount.IsZero() {
+			p, err = pc.Capture(item.PaymentID, opts...)
+		} else {
+			p, err = pc.CaptureAmount(item.PaymentID, item.Amount, opts...)
+		}
+	case Refund:
+		var refund *payment.RefundResponse
+		if item.Amount.IsZero() {
+			refund, err = pc.Refund(item.PaymentID, opts...)
+		} else {
+			refund, err = pc.RefundAmount(item.PaymentID, item.Amount, opts...)
+		}
+		if refund != nil {
+			r.RefundID = refund.ID
+		}
+	default:
+		err = fmt.Errorf("unknown action %q", item.Action)
+	}
+	r.Duration = time.Since(start)
+	if p != nil {
+		r.PaymentStatus = p.Status
+	}
+
+	if err != nil {
+		r.Status = Failed
+		r.Error = err.Error()
+		var er *rest.ErrorResponse
+		if errors.As(err, &er) {
+			r.StatusCode = er.StatusCode
+		}
+		return r
+	}
+	r.Status = Succeeded
+	return r
+}
+
+// IDs returns a closed channel with the action on each payment.
+func IDs(action Action, ids ...int64) <-chan Item {
+	items := make(chan Item, len(ids))
+	for _, id := range ids {
+		items <- Item{PaymentID: id, Action: action}
+	}
+	close(items)
+	return items
+}
@@ -0,0 +1,198 @@
+package bulk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gdeandradero/sdk-go/pkg/money"
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+	"github.com/gdeandradero/sdk-go/pkg/payment"
+)
+
+// fakePayments returns a payment client failing the calls on the payment 2, and the calls it received.
+func fakePayments() (payment.Client, func() []string) {
+	var (
+		mu    sync.Mutex
+		calls []string
+	)
+	pc := payment.NewClient(&rest.Mock{
+		SendMock: func(req *http.Request, opts ...rest.Option) ([]byte, error) {
+			mu.Lock()
+			calls = append(calls, req.Method+" "+req.URL.Path)
+			mu.Unlock()
+
+			switch {
+			case strings.HasPrefix(req.URL.Path, "/v1/payments/2"):
+				return nil, &rest.ErrorResponse{StatusCode: http.StatusBadRequest, Message: "invalid status"}
+			case strings.HasSuffix(req.URL.Path, "/refunds"):
+				return []byte(`{"id":99,"amount":5}`), nil
+			}
+			return []byte(`{"id":1,"status":"cancelled"}`), nil
+		},
+	})
+	return pc, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), calls...)
+	}
+}
+
+func TestRun(t *testing.T) {
+	pc, calls := fakePayments()
+	checkpoint := filepath.Join(t.TempDir(), "job.checkpoint")
+	items := func() <-chan Item {
+		ch := make(chan Item, 5)
+		ch <- Item{ID: "cancel-1", PaymentID: 1, Action: Cancel}
+		ch <- Item{PaymentID: 2, Action: Cancel}
+		ch <- Item{PaymentID: 3, Action: Refund, Amount: money.FromInt(5)}
+		ch <- Item{ID: "cancel-1", PaymentID: 1, Action: Cancel}
+		ch <- Item{PaymentID: 4, Action: "delete"}
+		close(ch)
+		return ch
+	}
+
+	report, err := Run(context.Background(), pc, items(), Config{JobID: "job-1", Workers: 2, Checkpoint: checkpoint})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if report.Succeeded != 2 || report.Failed != 2 || report.Skipped != 0 || len(report.Results) != 4 {
+		t.Fatalf("Run() = %+v, want 2 succeeded and 2 failed", report)
+	}
+	wantStatus := []Status{Succeeded, Failed, Succeeded, Failed}
+	for i, r := range report.Results {
+		if r.Status != wantStatus[i] {
+			t.Errorf("Run() result %d = %+v, want status %s", i, r, wantStatus[i])
+		}
+	}
+	if r := report.Results[1]; r.StatusCode != http.StatusBadRequest {
+		t.Errorf("Run() result 1 status code = %d, want 400", r.StatusCode)
+	}
+	if r := report.Results[2]; r.RefundID != 99 {
+		t.Errorf("Run() result 2 refund ID = %d, want 99", r.RefundID)
+	}
+	if got := len(calls()); got != 3 {
+		t.Errorf("Run() made %d calls, want 3", got)
+	}
+
+	// resuming skips the items that succeeded and runs the failed ones again.
+	report, err = Run(context.Background(), pc, items(), Config{JobID: "job-1", Checkpoint: checkpoint})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if report.Succeeded != 0 || report.Failed != 2 || report.Skipped != 2 {
+		t.Errorf("Run() resumed = %+v, want 2 failed and 2 skipped", report)
+	}
+	if r := report.Results[2]; r.Status != Skipped || r.RefundID != 99 {
+		t.Errorf("Run() resumed result 2 = %+v, want the skipped refund", r)
+	}
+	if got := calls()[3:]; len(got) != 1 || got[0] != "PUT /v1/payments/2" {
+		t.Errorf("Run() resumed made calls %v, want [PUT /v1/payments/2]", got)
+	}
+
+	var b bytes.Buffer
+	if err := report.WriteCSV(&b); err != nil {
+		t.Fatalf("Report.WriteCSV() error = %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(b.String()), "\n"); len(lines) != 5 || !strings.HasPrefix(lines[3], "3,refund,5,skipped,,,,99,") {
+		t.Errorf("Report.WriteCSV() = %s", b.String())
+	}
+}
+
+func TestRunCutCheckpoint(t *testing.T) {
+	pc, calls := fakePayments()
+	checkpoint := filepath.Join(t.TempDir(), "job.checkpoint")
+	content := `{"payment_id":1,"action":"cancel","job_id":"job-1","position":0,"status":"started"}` + "\n" +
+		`{"payment_id":1,"action":"cancel","job_id":"job-1","position":0,"status":"succeeded"}` + "\n" +
+		`{"payment_id":3,"action":"cancel","job_id":"job-1","position":1,"status":"started"}` + "\n" +
+		`{"payment_id":3,"action":"cancel","job_id":"job-1","posi`
+	if err := os.WriteFile(checkpoint, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := Run(context.Background(), pc, IDs(Cancel, 1, 3), Config{JobID: "job-1", Checkpoint: checkpoint})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if report.Skipped != 1 || report.Succeeded != 1 {
+		t.Errorf("Run() = %+v, want 1 skipped and 1 succeeded", report)
+	}
+	if got := calls(); len(got) != 1 || got[0] != "PUT /v1/payments/3" {
+		t.Errorf("Run() made calls %v, want [PUT /v1/payments/3]", got)
+	}
+
+	// the file is still readable after the cut line.
+	if _, err := openCheckpoint(checkpoint, "job-1"); err != nil {
+		t.Errorf("openCheckpoint() error = %v", err)
+	}
+	if _, err := Run(context.Background(), pc, IDs(Cancel, 1, 3), Config{JobID: "job-2", Checkpoint: checkpoint}); err == nil {
+		t.Error("Run() with the checkpoint of another job error = nil, want an error")
+	}
+}
+
+func TestRunCancelled(t *testing.T) {
+	pc, calls := fakePayments()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	items := make(chan Item)
+	report, err := Run(ctx, pc, items, Config{JobID: "job-1"})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() error = %v, want %v", err, context.Canceled)
+	}
+	if len(report.Results) != 0 || len(calls()) != 0 {
+		t.Errorf("Run() = %+v, want no results", report)
+	}
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestRunIdempotencyKeys(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		keys = map[string]bool{}
+	)
+	rc := rest.NewClientWithConfig(rest.ClientConfig{
+		AccessToken: "token",
+		HTTPClient: &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			keys[req.Header.Get("X-Idempotency-Key")] = true
+			mu.Unlock()
+			return &http.Response{StatusCode: http.StatusCreated, Body: io.NopCloser(strings.NewReader(`{"id":99}`))}, nil
+		})},
+	})
+	pc := payment.NewClient(rc)
+
+	// two partial refunds of the same amount, in two jobs: each is sent with its own key.
+	refunds := func() <-chan Item {
+		ch := make(chan Item, 2)
+		ch <- Item{PaymentID: 1, Action: Refund, Amount: money.FromInt(5)}
+		ch <- Item{PaymentID: 1, Action: Refund, Amount: money.FromInt(5)}
+		close(ch)
+		return ch
+	}
+	for _, job := range []string{"job-1", "job-2"} {
+		report, err := Run(context.Background(), pc, refunds(), Config{JobID: job})
+		if err != nil || report.Succeeded != 2 {
+			t.Fatalf("Run() = %+v, %v, want 2 succeeded", report, err)
+		}
+	}
+	if len(keys) != 4 {
+		t.Errorf("Run() sent %d distinct idempotency keys, want 4", len(keys))
+	}
+
+	if _, err := Run(context.Background(), pc, refunds(), Config{}); !errors.Is(err, ErrNoJobID) {
+		t.Errorf("Run() without a job ID error = %v, want ErrNoJobID", err)
+	}
+}
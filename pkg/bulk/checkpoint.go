@@ -0,0 +1,89 @@
+package bulk
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+)
+
+// checkpoint is an append-only file of JSON lines: a "started" result before each item is run,
+// and its final result after. A nil file keeps no checkpoint.
+type checkpoint struct {
+	mu   sync.Mutex
+	f    *os.File
+	done map[string]Result
+}
+
+// openCheckpoint opens the checkpoint file of the job, creating it if needed, and reads the items that succeeded.
+// It fails if the file is the checkpoint of another job.
+func openCheckpoint(path, jobID string) (*checkpoint, error) {
+	cp := &checkpoint{done: map[string]Result{}}
+	if path == "" {
+		return cp, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("error reading checkpoint: %w", err)
+	}
+	// a last line without a newline was cut by a crash: it is dropped, and the item is run again.
+	complete := b[:bytes.LastIndexByte(b, '\n')+1]
+	for i, line := range bytes.Split(complete, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var r Result
+		if err := json.Unmarshal(line, &r); err != nil {
+			return nil, fmt.Errorf("error reading checkpoint line %d: %w", i+1, err)
+		}
+		if r.JobID != jobID {
+			return nil, fmt.Errorf("error reading checkpoint line %d: it is the checkpoint of job %q", i+1, r.JobID)
+		}
+		if r.Status == Succeeded {
+			cp.done[r.key(r.Position)] = r
+		} else {
+			delete(cp.done, r.key(r.Position))
+		}
+	}
+
+	cp.f, err = os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("error opening checkpoint: %w", err)
+	}
+	if len(complete) < len(b) {
+		if err := cp.f.Truncate(int64(len(complete))); err != nil {
+			cp.f.Close()
+			return nil, fmt.Errorf("error writing checkpoint: %w", err)
+		}
+	}
+	return cp, nil
+}
+
+// write appends the result and syncs the file, so that it survives a crash.
+func (cp *checkpoint) write(r Result) error {
+	if cp.f == nil {
+		return nil
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	if _, err := cp.f.Write(append(b, '\n')); err != nil {
+		return err
+	}
+	return cp.f.Sync()
+}
+
+func (cp *checkpoint) close() error {
+	if cp.f == nil {
+		return nil
+	}
+	return cp.f.Close()
+}
@@ -0,0 +1,73 @@
+package bulk
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+// Report is the result of each item of a job, in the order they were read.
+type Report struct {
+	Succeeded int      `json:"succeeded"`
+	Failed    int      `json:"failed"`
+	Skipped   int      `json:"skipped"`
+	Results   []Result `json:"results"`
+}
+
+func (r *Report) add(res Result) {
+	switch res.Status {
+	case Succeeded:
+		r.Succeeded++
+	case Failed:
+		r.Failed++
+	case Skipped:
+		r.Skipped++
+	}
+	r.Results = append(r.Results, res)
+}
+
+// WriteJSON writes the report as an indented JSON document.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// csvHeader is the header of the file written by WriteCSV.
+var csvHeader = []string{"payment_id", "action", "amount", "status", "status_code", "error", "payment_status", "refund_id", "duration_ms"}
+
+// WriteCSV writes the results as a CSV file, one per row, with a header.
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, res := range r.Results {
+		record := []string{
+			strconv.FormatInt(res.PaymentID, 10),
+			string(res.Action),
+			"",
+			string(res.Status),
+			"",
+			res.Error,
+			string(res.PaymentStatus),
+			"",
+			strconv.FormatInt(res.Duration.Milliseconds(), 10),
+		}
+		if !res.Amount.IsZero() {
+			record[2] = res.Amount.String()
+		}
+		if res.StatusCode != 0 {
+			record[4] = strconv.Itoa(res.StatusCode)
+		}
+		if res.RefundID != 0 {
+			record[7] = strconv.FormatInt(res.RefundID, 10)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,100 @@
+// Package chargeback contains the client of the Chargebacks API, to follow disputes and send their evidence.
+package chargeback
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+	"github.com/gdeandradero/sdk-go/pkg/payment"
+)
+
+const (
+	getURL           = "https://api.mercadopago.com/v1/chargebacks/{id}"
+	searchURL        = "https://api.mercadopago.com/v1/chargebacks/search"
+	documentationURL = "https://api.mercadopago.com/v1/chargebacks/{id}/documentation"
+)
+
+// ErrNoPayment is returned by GetPayment when the chargeback has no payment.
+var ErrNoPayment = errors.New("chargeback has no payment")
+
+// Client contains the methods to interact with the Chargebacks API.
+type Client interface {
+	// Get gets a chargeback by its ID.
+	// It is a get request to the endpoint: https://api.mercadopago.com/v1/chargebacks/{id}
+	Get(id string, opts ...rest.Option) (*Response, error)
+
+	// Search searches for chargebacks.
+	// It is a get request to the endpoint: https://api.mercadopago.com/v1/chargebacks/search
+	Search(f Filters, opts ...rest.Option) (*SearchResponse, error)
+
+	// UploadDocumentation sends PDF, JPG or PNG files as evidence of a chargeback.
+	// The files are validated before anything is sent.
+	// It is a post request to the endpoint: https://api.mercadopago.com/v1/chargebacks/{id}/documentation
+	UploadDocumentation(id string, files []File, opts ...rest.Option) error
+}
+
+// client is the implementation of Client.
+type client struct {
+	rc rest.Client
+}
+
+// NewClient returns a new Chargebacks API Client.
+func NewClient(restClient rest.Client) Client {
+	return &client{
+		rc: restClient,
+	}
+}
+
+func (c *client) Get(id string, opts ...rest.Option) (*Response, error) {
+	return rest.Do[rest.NoBody, Response](context.Background(), c.rc, http.MethodGet, getURL, map[string]string{"id": id}, nil, nil,
+		append([]rest.Option{rest.WithOperation("chargeback.Get")}, opts...)...)
+}
+
+func (c *client) Search(f Filters, opts ...rest.Option) (*SearchResponse, error) {
+	params := url.Values{}
+	if f.PaymentID != 0 {
+		params.Add("payment_id", strconv.FormatInt(f.PaymentID, 10))
+	}
+	if f.Limit != 0 {
+		params.Add("limit", strconv.FormatInt(f.Limit, 10))
+	}
+	if f.Offset != 0 {
+		params.Add("offset", strconv.FormatInt(f.Offset, 10))
+	}
+
+	return rest.Do[rest.NoBody, SearchResponse](context.Background(), c.rc, http.MethodGet, searchURL, nil, params, nil,
+		append([]rest.Option{rest.WithOperation("chargeback.Search")}, opts...)...)
+}
+
+// UploadDocumentation sends a multipart form, not JSON, so it builds its request instead of using rest.Do.
+func (c *client) UploadDocumentation(id string, files []File, opts ...rest.Option) error {
+	body, contentType, err := multipartBody(files)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, strings.Replace(documentationURL, "{id}", url.PathEscape(id), 1), body)
+	if err != nil {
+		return &rest.ErrorResponse{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "error creating request: " + err.Error(),
+		}
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	_, err = c.rc.Send(req, append([]rest.Option{rest.WithOperation("chargeback.UploadDocumentation")}, opts...)...)
+	return err
+}
+
+// GetPayment gets the payment disputed by the chargeback, the first one if it disputes many.
+func GetPayment(pc payment.Client, cb *Response, opts ...rest.Option) (*payment.Response, error) {
+	if len(cb.Payments) == 0 {
+		return nil, ErrNoPayment
+	}
+	return pc.Get(cb.Payments[0], opts...)
+}
@@ -0,0 +1,236 @@
+package chargeback
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/money"
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+	"github.com/gdeandradero/sdk-go/pkg/payment"
+)
+
+var (
+	pdf = "%PDF-1.4\n%âãÏÓ\n1 0 obj\n<<>>\nendobj\n"
+	png = "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"
+)
+
+func TestClientGet(t *testing.T) {
+	type fields struct {
+		rc rest.Client
+	}
+	type args struct {
+		id   string
+		opts []rest.Option
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *Response
+		wantErr string
+	}{
+		{
+			name: "should_return_send_error",
+			fields: fields{
+				rc: &rest.Mock{
+					SendMock: func(req *http.Request, opts ...rest.Option) ([]byte, error) {
+						return nil, fmt.Errorf("some error")
+					},
+				},
+			},
+			args:    args{id: "123"},
+			want:    nil,
+			wantErr: "some error",
+		},
+		{
+			name: "should_return_unmarshal_error",
+			fields: fields{
+				rc: &rest.Mock{
+					SendMock: func(req *http.Request, opts ...rest.Option) ([]byte, error) {
+						return []byte("malformed json"), nil
+					},
+				},
+			},
+			args:    args{id: "123"},
+			want:    nil,
+			wantErr: "invalid character 'm' looking for beginning of value",
+		},
+		{
+			name: "should_return_success",
+			fields: fields{
+				rc: &rest.Mock{
+					SendMock: func(req *http.Request, opts ...rest.Option) ([]byte, error) {
+						if req.URL.Path != "/v1/chargebacks/123" {
+							return nil, fmt.Errorf("unexpected path %s", req.URL.Path)
+						}
+						return []byte(`{"id":"123","amount":10.5,"coverage_elegible":true,"documentation_status":"pending","payments":[42]}`), nil
+					},
+				},
+			},
+			args: args{id: "123"},
+			want: &Response{
+				ID:                  "123",
+				Amount:              money.MustParse("10.5"),
+				CoverageEligible:    true,
+				DocumentationStatus: DocumentationPending,
+				Payments:            []int64{42},
+			},
+			wantErr: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &client{
+				rc: tt.fields.rc,
+			}
+			got, err := c.Get(tt.args.id, tt.args.opts...)
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+
+			if gotErr != tt.wantErr {
+				t.Errorf("client.Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("client.Get() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientSearch(t *testing.T) {
+	c := &client{
+		rc: &rest.Mock{
+			SendMock: func(req *http.Request, opts ...rest.Option) ([]byte, error) {
+				if q := req.URL.Query().Get("payment_id"); q != "42" {
+					return nil, fmt.Errorf("unexpected payment_id %q", q)
+				}
+				return []byte(`{"results":[{"id":"123"}],"paging":{"total":1,"limit":30,"offset":0}}`), nil
+			},
+		},
+	}
+
+	got, err := c.Search(Filters{PaymentID: 42})
+	if err != nil {
+		t.Fatalf("client.Search() error = %v", err)
+	}
+	want := &SearchResponse{Results: []Response{{ID: "123"}}, Paging: PagingResponse{Total: 1, Limit: 30}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("client.Search() = %v, want %v", got, want)
+	}
+}
+
+func TestClientUploadDocumentation(t *testing.T) {
+	var parts []string
+	c := &client{
+		rc: &rest.Mock{
+			SendMock: func(req *http.Request, opts ...rest.Option) ([]byte, error) {
+				_, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+				if err != nil {
+					return nil, err
+				}
+				r := multipart.NewReader(req.Body, params["boundary"])
+				for {
+					p, err := r.NextPart()
+					if err == io.EOF {
+						return nil, nil
+					}
+					if err != nil {
+						return nil, err
+					}
+					parts = append(parts, p.FormName()+":"+p.FileName()+":"+p.Header.Get("Content-Type"))
+				}
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		files   []File
+		want    []string
+		wantErr error
+	}{
+		{
+			name: "should_upload_pdf_and_png",
+			files: []File{
+				{Name: "receipt.pdf", Content: strings.NewReader(pdf)},
+				{Name: "dir/proof.PNG", Content: strings.NewReader(png)},
+			},
+			want: []string{"files:receipt.pdf:application/pdf", "files:proof.PNG:image/png"},
+		},
+		{
+			name:    "should_fail_without_files",
+			wantErr: ErrNoFiles,
+		},
+		{
+			name:    "should_fail_on_extension",
+			files:   []File{{Name: "receipt.docx", Content: strings.NewReader(pdf)}},
+			wantErr: ErrFileType,
+		},
+		{
+			name:    "should_fail_on_content_not_matching_extension",
+			files:   []File{{Name: "receipt.pdf", Content: strings.NewReader(png)}},
+			wantErr: ErrFileType,
+		},
+		{
+			name:    "should_fail_on_size",
+			files:   []File{{Name: "receipt.pdf", Content: strings.NewReader(pdf + strings.Repeat(" ", MaxFileSize))}},
+			wantErr: ErrFileTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts = nil
+			err := c.UploadDocumentation("123", tt.files)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("client.UploadDocumentation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(parts, tt.want) {
+				t.Errorf("client.UploadDocumentation() parts = %v, want %v", parts, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetPayment(t *testing.T) {
+	pc := payment.NewClient(&rest.Mock{
+		SendMock: func(req *http.Request, opts ...rest.Option) ([]byte, error) {
+			return []byte(`{"id":42}`), nil
+		},
+	})
+
+	got, err := GetPayment(pc, &Response{Payments: []int64{42}})
+	if err != nil || got.ID != 42 {
+		t.Errorf("GetPayment() = %v, %v, want payment 42", got, err)
+	}
+	if _, err := GetPayment(pc, &Response{}); !errors.Is(err, ErrNoPayment) {
+		t.Errorf("GetPayment() error = %v, wantErr %v", err, ErrNoPayment)
+	}
+}
+
+func TestResponseAcceptsDocumentation(t *testing.T) {
+	deadline := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	cb := &Response{
+		DocumentationRequired:     true,
+		DocumentationStatus:       DocumentationPending,
+		DateDocumentationDeadline: &deadline,
+	}
+	if !cb.AcceptsDocumentation(deadline.Add(-time.Hour)) {
+		t.Error("Response.AcceptsDocumentation() = false before the deadline")
+	}
+	if cb.AcceptsDocumentation(deadline) {
+		t.Error("Response.AcceptsDocumentation() = true at the deadline")
+	}
+	if cb.Coverage() != CoverageNotEligible {
+		t.Errorf("Response.Coverage() = %v, want %v", cb.Coverage(), CoverageNotEligible)
+	}
+}
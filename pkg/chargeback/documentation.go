@@ -0,0 +1,109 @@
+package chargeback
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"path/filepath"
+	"strings"
+)
+
+// MaxFileSize is the maximum size of a document, in bytes.
+const MaxFileSize = 10 << 20
+
+// Content types accepted as documents.
+const (
+	ContentTypePDF  = "application/pdf"
+	ContentTypeJPEG = "image/jpeg"
+	ContentTypePNG  = "image/png"
+)
+
+var contentTypes = map[string]string{
+	".pdf":  ContentTypePDF,
+	".jpg":  ContentTypeJPEG,
+	".jpeg": ContentTypeJPEG,
+	".png":  ContentTypePNG,
+}
+
+var (
+	// ErrNoFiles is returned when uploading no documents.
+	ErrNoFiles = errors.New("no documents to upload")
+
+	// ErrFileTooLarge is returned when a document is larger than MaxFileSize.
+	ErrFileTooLarge = errors.New("document is too large")
+
+	// ErrFileType is returned when a document is not a PDF, JPG or PNG file.
+	ErrFileType = errors.New("document must be a PDF, JPG or PNG file")
+)
+
+// File is a document sent as evidence of a chargeback.
+type File struct {
+	// Name is the file name. Its extension must be .pdf, .jpg, .jpeg or .png.
+	Name string
+
+	// Content is read up to MaxFileSize + 1 bytes.
+	Content io.Reader
+}
+
+// document is a validated File.
+type document struct {
+	name        string
+	contentType string
+	content     []byte
+}
+
+// readDocument reads and validates the file: its size, its extension and its content must match.
+func readDocument(f File) (*document, error) {
+	wantType, ok := contentTypes[strings.ToLower(filepath.Ext(f.Name))]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrFileType, f.Name)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(f.Content, MaxFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", f.Name, err)
+	}
+	if len(content) > MaxFileSize {
+		return nil, fmt.Errorf("%w: %s is larger than %d bytes", ErrFileTooLarge, f.Name, MaxFileSize)
+	}
+	if got := http.DetectContentType(content); got != wantType {
+		return nil, fmt.Errorf("%w: %s has content of type %s", ErrFileType, f.Name, got)
+	}
+
+	return &document{name: filepath.Base(f.Name), contentType: wantType, content: content}, nil
+}
+
+// multipartBody returns the multipart/form-data body with the documents as "files" parts, and its content type.
+func multipartBody(files []File) (*bytes.Buffer, string, error) {
+	if len(files) == 0 {
+		return nil, "", ErrNoFiles
+	}
+
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	for _, f := range files {
+		doc, err := readDocument(f)
+		if err != nil {
+			return nil, "", err
+		}
+
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="files"; filename=%q`, doc.name))
+		header.Set("Content-Type", doc.contentType)
+		part, err := w.CreatePart(header)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := part.Write(doc.content); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	return body, w.FormDataContentType(), nil
+}
@@ -0,0 +1,4 @@
+package chargeback
+
+//go:generate go run ../../internal/gen -extra
+//go:generate go run ../../internal/gen -fake
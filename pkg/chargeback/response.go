@@ -0,0 +1,77 @@
+package chargeback
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/money"
+)
+
+// Response is the response from the Chargebacks API.
+type Response struct {
+	ID                    string              `json:"id,omitempty"`
+	Currency              string              `json:"currency,omitempty"`
+	Reason                string              `json:"reason,omitempty"`
+	Stage                 string              `json:"stage,omitempty"`
+	Amount                money.Amount        `json:"amount,omitzero"`
+	CoverageApplied       bool                `json:"coverage_applied,omitempty"`
+	CoverageEligible      bool                `json:"coverage_elegible,omitempty"`
+	DocumentationRequired bool                `json:"documentation_required,omitempty"`
+	DocumentationStatus   DocumentationStatus `json:"documentation_status,omitempty"`
+	LiveMode              bool                `json:"live_mode,omitempty"`
+	Payments              []int64             `json:"payments,omitempty"`
+
+	DateDocumentationDeadline *time.Time              `json:"date_documentation_deadline,omitempty"`
+	DateCreated               *time.Time              `json:"date_created,omitempty"`
+	DateLastUpdated           *time.Time              `json:"date_last_updated,omitempty"`
+	Documentation             []DocumentationResponse `json:"documentation,omitempty"`
+
+	// Extra holds the fields of the response the SDK does not model.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// DocumentationResponse represents a document sent as evidence within Response.
+type DocumentationResponse struct {
+	Type        string `json:"type,omitempty"`
+	URL         string `json:"url,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// SearchResponse represents the response from the search endpoint.
+type SearchResponse struct {
+	Results []Response     `json:"results"`
+	Paging  PagingResponse `json:"paging"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// PagingResponse represents the paging information within SearchResponse.
+type PagingResponse struct {
+	Total  int64 `json:"total"`
+	Limit  int64 `json:"limit"`
+	Offset int64 `json:"offset"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// Coverage returns the coverage status of the chargeback.
+func (r *Response) Coverage() CoverageStatus {
+	switch {
+	case r.CoverageApplied:
+		return CoverageApplied
+	case r.CoverageEligible:
+		return CoverageEligible
+	default:
+		return CoverageNotEligible
+	}
+}
+
+// AcceptsDocumentation reports whether evidence can still be sent at t.
+func (r *Response) AcceptsDocumentation(t time.Time) bool {
+	if !r.DocumentationRequired || r.DocumentationStatus != DocumentationPending {
+		return false
+	}
+	return r.DateDocumentationDeadline == nil || t.Before(*r.DateDocumentationDeadline)
+}
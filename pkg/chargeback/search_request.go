@@ -0,0 +1,13 @@
+package chargeback
+
+// Filters is the filters to search for chargebacks.
+type Filters struct {
+	// PaymentID is the ID of a payment disputed by the chargebacks.
+	PaymentID int64
+
+	// Limit is the maximum number of results.
+	Limit int64
+
+	// Offset is the number of results to skip.
+	Offset int64
+}
@@ -0,0 +1,49 @@
+package chargeback
+
+// DocumentationStatus is the status of the evidence of a chargeback.
+type DocumentationStatus string
+
+const (
+	// DocumentationNotSupplied means that no evidence was sent before the deadline.
+	DocumentationNotSupplied DocumentationStatus = "not_supplied"
+
+	// DocumentationPending means that evidence is expected.
+	DocumentationPending DocumentationStatus = "pending"
+
+	// DocumentationReviewPending means that the evidence sent is being reviewed.
+	DocumentationReviewPending DocumentationStatus = "review_pending"
+
+	// DocumentationValid means that the evidence sent was accepted.
+	DocumentationValid DocumentationStatus = "valid"
+
+	// DocumentationInvalid means that the evidence sent was rejected.
+	DocumentationInvalid DocumentationStatus = "invalid"
+)
+
+// IsKnown reports whether s is one of the documented statuses.
+func (s DocumentationStatus) IsKnown() bool {
+	switch s {
+	case DocumentationNotSupplied, DocumentationPending, DocumentationReviewPending, DocumentationValid, DocumentationInvalid:
+		return true
+	}
+	return false
+}
+
+// IsFinal reports whether the review of the evidence is over.
+func (s DocumentationStatus) IsFinal() bool {
+	return s == DocumentationNotSupplied || s == DocumentationValid || s == DocumentationInvalid
+}
+
+// CoverageStatus tells whether Mercado Pago covers the amount of a chargeback.
+type CoverageStatus string
+
+const (
+	// CoverageApplied means that Mercado Pago covers the amount, the seller does not lose it.
+	CoverageApplied CoverageStatus = "applied"
+
+	// CoverageEligible means that the chargeback can be covered, e.g. once valid evidence is sent.
+	CoverageEligible CoverageStatus = "eligible"
+
+	// CoverageNotEligible means that the amount is debited from the seller.
+	CoverageNotEligible CoverageStatus = "not_eligible"
+)
@@ -0,0 +1,4 @@
+package customer
+
+//go:generate go run ../../internal/gen -spec ../../api/customers.json
+//go:generate go run ../../internal/gen -fake
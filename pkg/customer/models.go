@@ -0,0 +1,301 @@
+// Code generated by internal/gen from api/customers.json. DO NOT EDIT.
+
+package customer
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+)
+
+// PaymentType is the type of the payment method of a card.
+type PaymentType string
+
+const (
+	// PaymentTypeCreditCard is a credit card.
+	PaymentTypeCreditCard PaymentType = "credit_card"
+
+	// PaymentTypeDebitCard is a debit card.
+	PaymentTypeDebitCard PaymentType = "debit_card"
+
+	// PaymentTypePrepaidCard is a prepaid card.
+	PaymentTypePrepaidCard PaymentType = "prepaid_card"
+)
+
+// IsKnown reports whether p is one of the documented values.
+func (p PaymentType) IsKnown() bool {
+	switch p {
+	case PaymentTypeCreditCard, PaymentTypeDebitCard, PaymentTypePrepaidCard:
+		return true
+	}
+	return false
+}
+
+// Request represents a request for creating or updating a customer.
+type Request struct {
+	Email          string         `json:"email,omitempty"`
+	FirstName      string         `json:"first_name,omitempty"`
+	LastName       string         `json:"last_name,omitempty"`
+	Description    string         `json:"description,omitempty"`
+	DefaultAddress string         `json:"default_address,omitempty"`
+	DefaultCard    string         `json:"default_card,omitempty"`
+	Metadata       map[string]any `json:"metadata,omitempty"`
+
+	DateRegistered *time.Time             `json:"date_registered,omitempty"`
+	Phone          *PhoneRequest          `json:"phone,omitempty"`
+	Identification *IdentificationRequest `json:"identification,omitempty"`
+	Address        *AddressRequest        `json:"address,omitempty"`
+}
+
+// PhoneRequest represents the phone of a customer within Request.
+type PhoneRequest struct {
+	AreaCode string `json:"area_code,omitempty"`
+	Number   string `json:"number,omitempty"`
+}
+
+// IdentificationRequest represents the identification document of a customer within Request.
+type IdentificationRequest struct {
+	Type   string `json:"type,omitempty"`
+	Number string `json:"number,omitempty"`
+}
+
+// AddressRequest represents the address of a customer within Request.
+type AddressRequest struct {
+	ID           string `json:"id,omitempty"`
+	ZipCode      string `json:"zip_code,omitempty"`
+	StreetName   string `json:"street_name,omitempty"`
+	StreetNumber *int64 `json:"street_number,omitempty"`
+}
+
+// CardRequest represents a request for saving a card to a customer.
+type CardRequest struct {
+	// Token is the card token created by the card form, valid for one use.
+	Token string `json:"token"`
+}
+
+// Response represents a customer.
+type Response struct {
+	ID             string         `json:"id,omitempty"`
+	Email          string         `json:"email,omitempty"`
+	FirstName      string         `json:"first_name,omitempty"`
+	LastName       string         `json:"last_name,omitempty"`
+	Description    string         `json:"description,omitempty"`
+	DefaultAddress string         `json:"default_address,omitempty"`
+	DefaultCard    string         `json:"default_card,omitempty"`
+	LiveMode       bool           `json:"live_mode,omitempty"`
+	Metadata       map[string]any `json:"metadata,omitempty"`
+
+	DateRegistered  *time.Time              `json:"date_registered,omitempty"`
+	DateCreated     *time.Time              `json:"date_created,omitempty"`
+	DateLastUpdated *time.Time              `json:"date_last_updated,omitempty"`
+	Phone           *PhoneResponse          `json:"phone,omitempty"`
+	Identification  *IdentificationResponse `json:"identification,omitempty"`
+	Address         *AddressResponse        `json:"address,omitempty"`
+	Cards           []CardResponse          `json:"cards,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// PhoneResponse represents the phone of a customer within Response.
+type PhoneResponse struct {
+	AreaCode string `json:"area_code,omitempty"`
+	Number   string `json:"number,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// IdentificationResponse represents the identification document of a customer or a cardholder.
+type IdentificationResponse struct {
+	Type   string `json:"type,omitempty"`
+	Number string `json:"number,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// AddressResponse represents the address of a customer within Response.
+type AddressResponse struct {
+	ID           string `json:"id,omitempty"`
+	ZipCode      string `json:"zip_code,omitempty"`
+	StreetName   string `json:"street_name,omitempty"`
+	StreetNumber *int64 `json:"street_number,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// CardResponse represents a card saved to a customer.
+type CardResponse struct {
+	ID              string `json:"id,omitempty"`
+	CustomerID      string `json:"customer_id,omitempty"`
+	FirstSixDigits  string `json:"first_six_digits,omitempty"`
+	LastFourDigits  string `json:"last_four_digits,omitempty"`
+	ExpirationMonth int    `json:"expiration_month,omitempty"`
+	ExpirationYear  int    `json:"expiration_year,omitempty"`
+
+	DateCreated     *time.Time                 `json:"date_created,omitempty"`
+	DateLastUpdated *time.Time                 `json:"date_last_updated,omitempty"`
+	PaymentMethod   *CardPaymentMethodResponse `json:"payment_method,omitempty"`
+	Issuer          *IssuerResponse            `json:"issuer,omitempty"`
+	Cardholder      *CardholderResponse        `json:"cardholder,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// CardPaymentMethodResponse represents the payment method of a card within CardResponse.
+type CardPaymentMethodResponse struct {
+	ID              string      `json:"id,omitempty"`
+	Name            string      `json:"name,omitempty"`
+	PaymentTypeID   PaymentType `json:"payment_type_id,omitempty"`
+	Thumbnail       string      `json:"thumbnail,omitempty"`
+	SecureThumbnail string      `json:"secure_thumbnail,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// IssuerResponse represents the issuer of a card within CardResponse.
+type IssuerResponse struct {
+	ID   int64  `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// CardholderResponse represents the holder of a card within CardResponse.
+type CardholderResponse struct {
+	Name string `json:"name,omitempty"`
+
+	Identification *IdentificationResponse `json:"identification,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// SearchResponse represents the customers found by Search.
+type SearchResponse struct {
+	Paging  PagingResponse `json:"paging"`
+	Results []Response     `json:"results"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// PagingResponse represents the paging of SearchResponse.
+type PagingResponse struct {
+	Total  int64 `json:"total,omitempty"`
+	Limit  int64 `json:"limit,omitempty"`
+	Offset int64 `json:"offset,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// Filters is the filters of Search.
+type Filters struct {
+	// Email is the email of the customers.
+	Email string
+
+	// Limit is the maximum number of results.
+	Limit int64
+
+	// Offset is the number of results to skip.
+	Offset int64
+}
+
+// The response structs keep the fields they do not model in Extra, see rest.DecodeExtra.
+
+func (r *Response) UnmarshalJSON(b []byte) error {
+	type plain Response
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r Response) MarshalJSON() ([]byte, error) {
+	type plain Response
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *PhoneResponse) UnmarshalJSON(b []byte) error {
+	type plain PhoneResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r PhoneResponse) MarshalJSON() ([]byte, error) {
+	type plain PhoneResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *IdentificationResponse) UnmarshalJSON(b []byte) error {
+	type plain IdentificationResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r IdentificationResponse) MarshalJSON() ([]byte, error) {
+	type plain IdentificationResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *AddressResponse) UnmarshalJSON(b []byte) error {
+	type plain AddressResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r AddressResponse) MarshalJSON() ([]byte, error) {
+	type plain AddressResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *CardResponse) UnmarshalJSON(b []byte) error {
+	type plain CardResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r CardResponse) MarshalJSON() ([]byte, error) {
+	type plain CardResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *CardPaymentMethodResponse) UnmarshalJSON(b []byte) error {
+	type plain CardPaymentMethodResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r CardPaymentMethodResponse) MarshalJSON() ([]byte, error) {
+	type plain CardPaymentMethodResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *IssuerResponse) UnmarshalJSON(b []byte) error {
+	type plain IssuerResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r IssuerResponse) MarshalJSON() ([]byte, error) {
+	type plain IssuerResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *CardholderResponse) UnmarshalJSON(b []byte) error {
+	type plain CardholderResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r CardholderResponse) MarshalJSON() ([]byte, error) {
+	type plain CardholderResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *SearchResponse) UnmarshalJSON(b []byte) error {
+	type plain SearchResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r SearchResponse) MarshalJSON() ([]byte, error) {
+	type plain SearchResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *PagingResponse) UnmarshalJSON(b []byte) error {
+	type plain PagingResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r PagingResponse) MarshalJSON() ([]byte, error) {
+	type plain PagingResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
@@ -0,0 +1,117 @@
+package marketplace
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gdeandradero/sdk-go/pkg/money"
+	"github.com/gdeandradero/sdk-go/pkg/payment"
+)
+
+// Fee types of payment.FeeDetailResponse.
+const (
+	FeeTypeMercadoPago = "mercadopago_fee"
+	FeeTypeApplication = "application_fee"
+	FeeTypeFinancing   = "financing_fee"
+	FeeTypeShipping    = "shipping_fee"
+)
+
+// Fee payers of payment.FeeDetailResponse.
+const (
+	FeePayerCollector = "collector"
+	FeePayerPayer     = "payer"
+)
+
+// ErrInvalidFee is matched, with errors.Is, by the errors returned for an invalid application fee.
+var ErrInvalidFee = errors.New("invalid application fee")
+
+// Fee computes the application fee kept by the marketplace: Percent of the amount plus Fixed,
+// bounded by Min and Max when they are not zero, and rounded to the minor units of the currency.
+type Fee struct {
+	// Percent is a percentage of the amount, e.g. money.MustParse("2.5") for 2.5%.
+	Percent money.Amount
+	Fixed   money.Amount
+	Min     money.Amount
+	Max     money.Amount
+}
+
+// IsZero reports whether the fee computes nothing.
+func (f Fee) IsZero() bool {
+	return f == Fee{}
+}
+
+// Compute returns the application fee of amount.
+func (f Fee) Compute(amount money.Amount, c money.Currency) money.Amount {
+	fee := amount.Percent(f.Percent).Add(f.Fixed)
+	if !f.Min.IsZero() && fee.Cmp(f.Min) < 0 {
+		fee = f.Min
+	}
+	if !f.Max.IsZero() && fee.Cmp(f.Max) > 0 {
+		fee = f.Max
+	}
+	return fee.Round(c)
+}
+
+// ValidateApplicationFee checks that fee is not negative and is less than amount,
+// as the seller must receive part of the payment.
+func ValidateApplicationFee(amount, fee money.Amount) error {
+	switch {
+	case fee.IsNegative():
+		return fmt.Errorf("%w: %s is negative", ErrInvalidFee, fee)
+	case fee.Cmp(amount) >= 0 && fee.IsPositive():
+		return fmt.Errorf("%w: %s is not less than the amount %s", ErrInvalidFee, fee, amount)
+	}
+	return nil
+}
+
+// FeeBreakdown splits the amount of a payment between the seller, the marketplace and Mercado Pago.
+type FeeBreakdown struct {
+	// Gross is the transaction amount.
+	Gross money.Amount
+
+	// MercadoPagoFee is the fee charged by Mercado Pago.
+	MercadoPagoFee money.Amount
+
+	// ApplicationFee is the fee kept by the marketplace.
+	ApplicationFee money.Amount
+
+	// FinancingFee is the installment fee, usually paid by the payer.
+	FinancingFee money.Amount
+
+	// ShippingFee is the shipping cost.
+	ShippingFee money.Amount
+
+	// Other adds up the fees of other types.
+	Other money.Amount
+
+	// PaidByCollector adds up the fees paid by the seller.
+	PaidByCollector money.Amount
+
+	// SellerNet is what the seller receives: Gross minus the fees paid by the seller.
+	SellerNet money.Amount
+}
+
+// Breakdown returns the fee breakdown of the payment from its fee details.
+// Refunds are not taken into account.
+func Breakdown(p *payment.Response) FeeBreakdown {
+	b := FeeBreakdown{Gross: p.TransactionAmount}
+	for _, fd := range p.FeeDetails {
+		switch fd.Type {
+		case FeeTypeMercadoPago:
+			b.MercadoPagoFee = b.MercadoPagoFee.Add(fd.Amount)
+		case FeeTypeApplication:
+			b.ApplicationFee = b.ApplicationFee.Add(fd.Amount)
+		case FeeTypeFinancing:
+			b.FinancingFee = b.FinancingFee.Add(fd.Amount)
+		case FeeTypeShipping:
+			b.ShippingFee = b.ShippingFee.Add(fd.Amount)
+		default:
+			b.Other = b.Other.Add(fd.Amount)
+		}
+		if fd.FeePayer == FeePayerCollector {
+			b.PaidByCollector = b.PaidByCollector.Add(fd.Amount)
+		}
+	}
+	b.SellerNet = b.Gross.Sub(b.PaidByCollector)
+	return b
+}
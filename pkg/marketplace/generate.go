@@ -0,0 +1,3 @@
+package marketplace
+
+//go:generate go run ../../internal/gen -fake
@@ -0,0 +1,127 @@
+// Package marketplace provides marketplace flows on top of the payment client:
+// payments created on behalf of sellers with their OAuth tokens, application fees and fee breakdowns.
+package marketplace
+
+import (
+	"errors"
+
+	"github.com/gdeandradero/sdk-go/pkg/money"
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+	"github.com/gdeandradero/sdk-go/pkg/payment"
+)
+
+// ErrUnknownCollector is returned when a payment has no collector ID to route a request to its seller.
+var ErrUnknownCollector = errors.New("payment has no collector ID")
+
+// Config configures a marketplace Client.
+type Config struct {
+	// Tokens provides the access tokens of the sellers. It is required.
+	Tokens TokenRegistry
+
+	// Fee computes the application fee of payments created without one. The zero Fee computes none.
+	Fee Fee
+
+	// Currency rounds the computed application fees. Default is money.BRL.
+	Currency money.Currency
+
+	// SponsorID, if set, is sent as the sponsor of the payments that do not set one.
+	SponsorID int64
+
+	// Payments sends the payment requests, e.g. a paymenttest.Client in tests.
+	// Default is payment.NewClient with the rest.Client of NewClient.
+	Payments payment.Client
+}
+
+// Client contains the marketplace methods. Every method sends the request with the access token of the seller.
+type Client interface {
+
+	// CreatePayment creates a payment on behalf of the seller.
+	// If the request has no application fee, it is computed with Config.Fee.
+	CreatePayment(collectorID int64, dto payment.Request, opts ...rest.Option) (*payment.Response, error)
+
+	// GetPayment gets a payment of the seller.
+	GetPayment(collectorID, paymentID int64, opts ...rest.Option) (*payment.Response, error)
+
+	// Refund refunds the payment in full, on behalf of its collector.
+	Refund(p *payment.Response, opts ...rest.Option) (*payment.RefundResponse, error)
+
+	// RefundAmount refunds part of the payment, on behalf of its collector.
+	RefundAmount(p *payment.Response, amount money.Amount, opts ...rest.Option) (*payment.RefundResponse, error)
+}
+
+// client is the implementation of Client.
+type client struct {
+	pc     payment.Client
+	config Config
+}
+
+// NewClient returns a new marketplace client.
+func NewClient(rc rest.Client, config Config) Client {
+	if config.Currency == "" {
+		config.Currency = money.BRL
+	}
+	if config.Payments == nil {
+		config.Payments = payment.NewClient(rc)
+	}
+	return &client{
+		pc:     config.Payments,
+		config: config,
+	}
+}
+
+func (c *client) CreatePayment(collectorID int64, dto payment.Request, opts ...rest.Option) (*payment.Response, error) {
+	if dto.ApplicationFee.IsZero() && !c.config.Fee.IsZero() {
+		dto.ApplicationFee = c.config.Fee.Compute(dto.TransactionAmount, c.config.Currency)
+	}
+	if err := ValidateApplicationFee(dto.TransactionAmount, dto.ApplicationFee); err != nil {
+		return nil, err
+	}
+	if dto.SponsorID == 0 {
+		dto.SponsorID = c.config.SponsorID
+	}
+
+	opts, err := c.asSeller(collectorID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return c.pc.Create(dto, opts...)
+}
+
+func (c *client) GetPayment(collectorID, paymentID int64, opts ...rest.Option) (*payment.Response, error) {
+	opts, err := c.asSeller(collectorID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return c.pc.Get(paymentID, opts...)
+}
+
+func (c *client) Refund(p *payment.Response, opts ...rest.Option) (*payment.RefundResponse, error) {
+	if p.CollectorID == 0 {
+		return nil, ErrUnknownCollector
+	}
+	opts, err := c.asSeller(p.CollectorID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return c.pc.Refund(p.ID, opts...)
+}
+
+func (c *client) RefundAmount(p *payment.Response, amount money.Amount, opts ...rest.Option) (*payment.RefundResponse, error) {
+	if p.CollectorID == 0 {
+		return nil, ErrUnknownCollector
+	}
+	opts, err := c.asSeller(p.CollectorID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return c.pc.RefundAmount(p.ID, amount, opts...)
+}
+
+// asSeller returns opts with the access token of the seller.
+func (c *client) asSeller(collectorID int64, opts []rest.Option) ([]rest.Option, error) {
+	at, err := c.config.Tokens.AccessToken(collectorID)
+	if err != nil {
+		return nil, err
+	}
+	return append(opts[:len(opts):len(opts)], rest.WithAccessToken(at)), nil
+}
@@ -0,0 +1,171 @@
+package marketplace
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gdeandradero/sdk-go/pkg/money"
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+	"github.com/gdeandradero/sdk-go/pkg/payment"
+	"github.com/gdeandradero/sdk-go/pkg/payment/paymenttest"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// recorded is a request received by the fake API.
+type recorded struct {
+	method, path, authorization, body string
+}
+
+func newTestClient(t *testing.T, config Config) (Client, *[]recorded) {
+	t.Helper()
+	var requests []recorded
+
+	rc := rest.NewClient("marketplace-token")
+	rest.SetHC(&http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		body := ""
+		if req.Body != nil {
+			b, _ := io.ReadAll(req.Body)
+			body = string(b)
+		}
+		requests = append(requests, recorded{req.Method, req.URL.Path, req.Header.Get("Authorization"), body})
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(`{"id":1,"collector_id":10}`)),
+		}, nil
+	})})
+	return NewClient(rc, config), &requests
+}
+
+func TestClient(t *testing.T) {
+	tokens := NewMemoryTokenRegistry()
+	tokens.Set(10, "seller-10-token")
+	tokens.Set(20, "seller-20-token")
+
+	c, requests := newTestClient(t, Config{
+		Tokens:    tokens,
+		Fee:       Fee{Percent: money.FromInt(10), Min: money.FromInt(1)},
+		SponsorID: 99,
+	})
+
+	if _, err := c.CreatePayment(10, payment.Request{TransactionAmount: money.FromInt(50)}); err != nil {
+		t.Fatalf("client.CreatePayment() error = %v", err)
+	}
+	if _, err := c.RefundAmount(&payment.Response{ID: 1, CollectorID: 20}, money.FromInt(5)); err != nil {
+		t.Fatalf("client.RefundAmount() error = %v", err)
+	}
+
+	want := []recorded{
+		{http.MethodPost, "/v1/payments", "Bearer seller-10-token", `{"sponsor_id":99,"application_fee":5,"transaction_amount":50}`},
+		{http.MethodPost, "/v1/payments/1/refunds", "Bearer seller-20-token", `{"amount":5}`},
+	}
+	if len(*requests) != len(want) {
+		t.Fatalf("API got %d requests, want %d", len(*requests), len(want))
+	}
+	for i, got := range *requests {
+		if got != want[i] {
+			t.Errorf("request %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+
+	if _, err := c.CreatePayment(30, payment.Request{TransactionAmount: money.FromInt(50)}); !errors.Is(err, ErrUnknownSeller) {
+		t.Errorf("client.CreatePayment() error = %v, wantErr %v", err, ErrUnknownSeller)
+	}
+	if _, err := c.CreatePayment(10, payment.Request{TransactionAmount: money.FromInt(50), ApplicationFee: money.FromInt(50)}); !errors.Is(err, ErrInvalidFee) {
+		t.Errorf("client.CreatePayment() error = %v, wantErr %v", err, ErrInvalidFee)
+	}
+	if _, err := c.Refund(&payment.Response{ID: 1}); !errors.Is(err, ErrUnknownCollector) {
+		t.Errorf("client.Refund() error = %v, wantErr %v", err, ErrUnknownCollector)
+	}
+	if len(*requests) != len(want) {
+		t.Errorf("API got %d requests, want %d", len(*requests), len(want))
+	}
+}
+
+func TestClientWithPayments(t *testing.T) {
+	tokens := NewMemoryTokenRegistry()
+	tokens.Set(10, "seller-10-token")
+
+	var token string
+	pc := &paymenttest.Client{
+		GetFunc: func(id int64, opts ...rest.Option) (*payment.Response, error) {
+			token = rest.AccessToken(opts)
+			return &payment.Response{ID: id, CollectorID: 10}, nil
+		},
+	}
+	c := NewClient(nil, Config{Tokens: tokens, Payments: pc})
+
+	got, err := c.GetPayment(10, 1)
+	if err != nil {
+		t.Fatalf("client.GetPayment() error = %v", err)
+	}
+	if got.ID != 1 || token != "seller-10-token" {
+		t.Errorf("client.GetPayment() = %+v with token %q, want payment 1 with token %q", got, token, "seller-10-token")
+	}
+	pc.AssertCalls(t, paymenttest.Expect("Get", 1))
+}
+
+func TestFeeCompute(t *testing.T) {
+	tests := []struct {
+		name   string
+		fee    Fee
+		amount money.Amount
+		want   money.Amount
+	}{
+		{
+			name:   "percent_and_fixed",
+			fee:    Fee{Percent: money.MustParse("2.5"), Fixed: money.MustParse("0.3")},
+			amount: money.MustParse("99.99"),
+			want:   money.MustParse("2.8"),
+		},
+		{
+			name:   "min",
+			fee:    Fee{Percent: money.FromInt(1), Min: money.FromInt(2)},
+			amount: money.FromInt(10),
+			want:   money.FromInt(2),
+		},
+		{
+			name:   "max",
+			fee:    Fee{Percent: money.FromInt(10), Max: money.FromInt(50)},
+			amount: money.FromInt(1000),
+			want:   money.FromInt(50),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fee.Compute(tt.amount, money.BRL); !got.Equal(tt.want) {
+				t.Errorf("Fee.Compute() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBreakdown(t *testing.T) {
+	got := Breakdown(&payment.Response{
+		TransactionAmount: money.FromInt(100),
+		FeeDetails: []payment.FeeDetailResponse{
+			{Type: FeeTypeMercadoPago, FeePayer: FeePayerCollector, Amount: money.MustParse("4.99")},
+			{Type: FeeTypeApplication, FeePayer: FeePayerCollector, Amount: money.FromInt(10)},
+			{Type: FeeTypeFinancing, FeePayer: FeePayerPayer, Amount: money.FromInt(7)},
+		},
+	})
+	want := FeeBreakdown{
+		Gross:           money.FromInt(100),
+		MercadoPagoFee:  money.MustParse("4.99"),
+		ApplicationFee:  money.FromInt(10),
+		FinancingFee:    money.FromInt(7),
+		PaidByCollector: money.MustParse("14.99"),
+		SellerNet:       money.MustParse("85.01"),
+	}
+	if got != want {
+		t.Errorf("Breakdown() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,76 @@
+package marketplace
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+)
+
+// ErrUnknownSeller is returned when the token registry has no token for a collector ID.
+var ErrUnknownSeller = errors.New("no access token for seller")
+
+// TokenRegistry is the interface that provides the OAuth access tokens of the sellers, keyed by collector ID.
+type TokenRegistry interface {
+	// AccessToken returns the access token of the seller, or an error matching ErrUnknownSeller.
+	AccessToken(collectorID int64) (string, error)
+}
+
+// TokenRegistryFunc is an adapter to use a function as a TokenRegistry,
+// e.g. to load the tokens from a database.
+type TokenRegistryFunc func(collectorID int64) (string, error)
+
+// AccessToken implements TokenRegistry.
+func (f TokenRegistryFunc) AccessToken(collectorID int64) (string, error) {
+	return f(collectorID)
+}
+
+// MemoryTokenRegistry is a TokenRegistry that keeps the tokens in memory. It is safe for concurrent use.
+type MemoryTokenRegistry struct {
+	mu     sync.RWMutex
+	tokens map[int64]string
+}
+
+// NewMemoryTokenRegistry returns an empty MemoryTokenRegistry.
+func NewMemoryTokenRegistry() *MemoryTokenRegistry {
+	return &MemoryTokenRegistry{tokens: map[int64]string{}}
+}
+
+// Set sets the access token of the seller, e.g. after the OAuth flow or a token refresh.
+func (r *MemoryTokenRegistry) Set(collectorID int64, accessToken string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokens[collectorID] = accessToken
+}
+
+// Delete removes the access token of the seller.
+func (r *MemoryTokenRegistry) Delete(collectorID int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.tokens, collectorID)
+}
+
+// AccessToken implements TokenRegistry.
+func (r *MemoryTokenRegistry) AccessToken(collectorID int64) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	at, ok := r.tokens[collectorID]
+	if !ok {
+		return "", &UnknownSellerError{CollectorID: collectorID}
+	}
+	return at, nil
+}
+
+// UnknownSellerError is returned when the token registry has no token for a collector ID.
+type UnknownSellerError struct {
+	CollectorID int64
+}
+
+// Error implements error.
+func (e *UnknownSellerError) Error() string {
+	return ErrUnknownSeller.Error() + " " + strconv.FormatInt(e.CollectorID, 10)
+}
+
+// Is reports whether target is ErrUnknownSeller.
+func (e *UnknownSellerError) Is(target error) bool {
+	return target == ErrUnknownSeller
+}
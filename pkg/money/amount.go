@@ -0,0 +1,433 @@
+// Package money provides a fixed-point decimal type for monetary amounts.
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Scale is the number of decimal places kept by an Amount.
+// Digits beyond it are rounded half away from zero.
+const Scale = 6
+
+// one is the number of units in 1.
+const one = 1_000_000
+
+var (
+	// ErrInvalidAmount is returned when parsing something that is not a decimal number.
+	ErrInvalidAmount = errors.New("invalid amount")
+
+	// ErrOverflow is returned when an amount does not fit in an Amount.
+	ErrOverflow = errors.New("amount out of range")
+)
+
+// Amount is a fixed-point decimal amount with Scale decimal places, from about -9.2 trillion to 9.2 trillion.
+// It is encoded in JSON as a number, without going through float64.
+// The zero value is 0.
+//
+// Parse returns ErrOverflow for a number out of range, while the arithmetic methods panic with an error
+// matching ErrOverflow, like integer division by zero: amounts of real payments are far from the limits.
+type Amount struct {
+	units int64
+}
+
+// FromInt returns an Amount of n, e.g. FromInt(10) is 10.00. It panics if n is out of range.
+func FromInt(n int64) Amount {
+	return Amount{units: mul(n, one)}
+}
+
+// FromMinor returns an Amount of minor units of the currency, e.g. FromMinor(1050, BRL) is 10.50.
+// It panics if minor is out of range.
+func FromMinor(minor int64, c Currency) Amount {
+	return Amount{units: mul(minor, pow10(Scale-c.MinorUnits()))}
+}
+
+// FromFloat returns the Amount closest to f, or ErrInvalidAmount for NaN and infinities
+// and ErrOverflow if f is out of range.
+// It is meant for compatibility with float64 amounts, prefer Parse or FromMinor.
+func FromFloat(f float64) (Amount, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Amount{}, fmt.Errorf("%w: %v", ErrInvalidAmount, f)
+	}
+	return Parse(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+// Parse parses a decimal number such as "123.45", "-1", "1e3" or "0.5E-2".
+func Parse(s string) (Amount, error) {
+	str := s
+	neg := false
+	switch {
+	case strings.HasPrefix(s, "-"):
+		neg, s = true, s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+
+	exp := 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.Atoi(s[i+1:])
+		if err != nil {
+			return Amount{}, fmt.Errorf("%w: %q", ErrInvalidAmount, str)
+		}
+		exp, s = e, s[:i]
+	}
+
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	digits := intPart + fracPart
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return Amount{}, fmt.Errorf("%w: %q", ErrInvalidAmount, str)
+	}
+
+	// the value is digits * 10^(exp - len(fracPart)), expressed in units of 10^-Scale.
+	n, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Amount{}, fmt.Errorf("%w: %q", ErrInvalidAmount, str)
+	}
+	if n.Sign() == 0 {
+		return Amount{}, nil
+	}
+	if neg {
+		n.Neg(n)
+	}
+	// the shift is bounded before computing 10^shift: a nonzero value shifted left by more than
+	// maxDigits overflows, and one shifted right by more than its number of digits rounds to 0.
+	// exp is bounded first, so that computing the shift cannot overflow.
+	const maxDigits = 19
+	if exp > math.MaxInt32 {
+		return Amount{}, fmt.Errorf("%w: %q", ErrOverflow, str)
+	}
+	if exp < math.MinInt32 {
+		return Amount{}, nil
+	}
+	shift := exp - len(fracPart) + Scale
+	if shift > maxDigits {
+		return Amount{}, fmt.Errorf("%w: %q", ErrOverflow, str)
+	}
+	if shift < -len(digits)-1 {
+		return Amount{}, nil
+	}
+	if shift >= 0 {
+		n.Mul(n, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(shift)), nil))
+	} else {
+		n = divRound(n, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-shift)), nil))
+	}
+
+	if !n.IsInt64() {
+		return Amount{}, fmt.Errorf("%w: %q", ErrOverflow, str)
+	}
+	return Amount{units: n.Int64()}, nil
+}
+
+// MustParse is like Parse but panics if s cannot be parsed.
+// It is meant for constants and tests.
+func MustParse(s string) Amount {
+	a, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// Float64 returns the float64 closest to a.
+// It is meant for compatibility with float64 amounts.
+func (a Amount) Float64() float64 {
+	f, _ := strconv.ParseFloat(a.String(), 64)
+	return f
+}
+
+// Minor returns a in minor units of the currency, rounded half away from zero, e.g. 10.50 BRL is 1050.
+func (a Amount) Minor(c Currency) int64 {
+	return roundUnits(a.units, pow10(Scale-c.MinorUnits())) / pow10(Scale-c.MinorUnits())
+}
+
+// Round returns a rounded half away from zero to the minor units of the currency. It panics if the result overflows.
+func (a Amount) Round(c Currency) Amount {
+	return Amount{units: roundUnits(a.units, pow10(Scale-c.MinorUnits()))}
+}
+
+// Add returns a + b. It panics if the result overflows.
+func (a Amount) Add(b Amount) Amount {
+	sum := a.units + b.units
+	if (a.units > 0 && b.units > 0 && sum < 0) || (a.units < 0 && b.units < 0 && sum >= 0) {
+		panic(overflow("%s + %s", a, b))
+	}
+	return Amount{units: sum}
+}
+
+// Sub returns a - b. It panics if the result overflows.
+func (a Amount) Sub(b Amount) Amount {
+	diff := a.units - b.units
+	if (a.units >= 0 && b.units < 0 && diff < 0) || (a.units < 0 && b.units > 0 && diff >= 0) {
+		panic(overflow("%s - %s", a, b))
+	}
+	return Amount{units: diff}
+}
+
+// Neg returns -a. It panics if the result overflows.
+func (a Amount) Neg() Amount {
+	return Amount{units: mul(a.units, -1)}
+}
+
+// Abs returns the absolute value of a. It panics if the result overflows.
+func (a Amount) Abs() Amount {
+	if a.units < 0 {
+		return a.Neg()
+	}
+	return a
+}
+
+// MulInt returns a * n. It panics if the result overflows.
+func (a Amount) MulInt(n int64) Amount {
+	return Amount{units: mul(a.units, n)}
+}
+
+// Mul returns a * factor, rounded half away from zero to Scale decimal places. It panics if the result overflows.
+func (a Amount) Mul(factor Amount) Amount {
+	n := new(big.Int).Mul(big.NewInt(a.units), big.NewInt(factor.units))
+	return Amount{units: int64Of(divRound(n, big.NewInt(one)), "%s * %s", a, factor)}
+}
+
+// Percent returns p percent of a, rounded half away from zero to Scale decimal places.
+// It panics if the result overflows.
+func (a Amount) Percent(p Amount) Amount {
+	n := new(big.Int).Mul(big.NewInt(a.units), big.NewInt(p.units))
+	return Amount{units: int64Of(divRound(n, big.NewInt(100*one)), "%s%% of %s", p, a)}
+}
+
+// Cmp compares a and b and returns -1 if a < b, 0 if a == b and +1 if a > b.
+func (a Amount) Cmp(b Amount) int {
+	switch {
+	case a.units < b.units:
+		return -1
+	case a.units > b.units:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Equal reports whether a == b.
+func (a Amount) Equal(b Amount) bool {
+	return a.units == b.units
+}
+
+// Sign returns -1 if a < 0, 0 if a == 0 and +1 if a > 0.
+func (a Amount) Sign() int {
+	return a.Cmp(Amount{})
+}
+
+// IsZero reports whether a is 0. It lets fields tagged with omitzero be omitted when 0.
+func (a Amount) IsZero() bool {
+	return a.units == 0
+}
+
+// IsNegative reports whether a < 0.
+func (a Amount) IsNegative() bool {
+	return a.units < 0
+}
+
+// IsPositive reports whether a > 0.
+func (a Amount) IsPositive() bool {
+	return a.units > 0
+}
+
+// Split splits a in n parts that differ by at most one minor unit of the currency.
+func (a Amount) Split(c Currency, n int) ([]Amount, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("cannot split in %d parts", n)
+	}
+
+	ratios := make([]int64, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return a.Allocate(c, ratios...)
+}
+
+// Allocate splits a proportionally to ratios, in minor units of the currency.
+// The parts always add up to a: the minor units left over by the division go one by one
+// to the first parts, and digits below the minor unit go to the first part. Parts with a ratio of 0 get nothing.
+func (a Amount) Allocate(c Currency, ratios ...int64) ([]Amount, error) {
+	var total int64
+	for _, r := range ratios {
+		if r < 0 {
+			return nil, fmt.Errorf("cannot allocate with negative ratio %d", r)
+		}
+		if total > math.MaxInt64-r {
+			return nil, fmt.Errorf("%w: ratios adding up to more than %d", ErrOverflow, int64(math.MaxInt64))
+		}
+		total += r
+	}
+	if total == 0 {
+		return nil, errors.New("cannot allocate with ratios adding up to zero")
+	}
+
+	unit := pow10(Scale - c.MinorUnits())
+	sign := int64(1)
+	units := a.units
+	if units < 0 {
+		sign, units = -1, -units
+	}
+	minor, subMinor := units/unit, units%unit
+
+	parts := make([]Amount, len(ratios))
+	first := -1
+	left := minor
+	for i, r := range ratios {
+		share := new(big.Int).Mul(big.NewInt(minor), big.NewInt(r))
+		share.Quo(share, big.NewInt(total))
+		parts[i] = Amount{units: share.Int64()}
+		left -= share.Int64()
+		if first < 0 && r > 0 {
+			first = i
+		}
+	}
+	for i := 0; left > 0; i = (i + 1) % len(parts) {
+		if ratios[i] == 0 {
+			continue
+		}
+		parts[i].units++
+		left--
+	}
+
+	for i := range parts {
+		parts[i].units *= unit
+		if i == first {
+			parts[i].units += subMinor
+		}
+		parts[i].units *= sign
+	}
+	return parts, nil
+}
+
+// String returns a as a decimal number without trailing zeros, e.g. "123.4" or "-10".
+func (a Amount) String() string {
+	s := a.StringFixed(Scale)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+// StringFixed returns a rounded half away from zero to places decimal places, e.g. "123.40".
+func (a Amount) StringFixed(places int) string {
+	places = min(max(places, 0), Scale)
+	units := roundUnits(a.units, pow10(Scale-places))
+
+	sign := ""
+	u := new(big.Int).SetInt64(units)
+	if u.Sign() < 0 {
+		sign = "-"
+		u.Neg(u)
+	}
+	digits := u.String()
+	if len(digits) <= Scale {
+		digits = strings.Repeat("0", Scale-len(digits)+1) + digits
+	}
+	intPart, fracPart := digits[:len(digits)-Scale], digits[len(digits)-Scale:]
+	if places == 0 {
+		return sign + intPart
+	}
+	return sign + intPart + "." + fracPart[:places]
+}
+
+// Format returns a with the minor units of the currency, e.g. "123.40" for BRL or "123" for CLP.
+func (a Amount) Format(c Currency) string {
+	return a.StringFixed(c.MinorUnits())
+}
+
+// MarshalJSON implements json.Marshaler, encoding a as a JSON number.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting JSON numbers and numeric strings.
+func (a *Amount) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (a Amount) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (a *Amount) UnmarshalText(b []byte) error {
+	parsed, err := Parse(string(b))
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// roundUnits rounds units half away from zero to a multiple of step. It panics if the result overflows.
+func roundUnits(units, step int64) int64 {
+	if step <= 1 {
+		return units
+	}
+	return mul(divRound(big.NewInt(units), big.NewInt(step)).Int64(), step)
+}
+
+// mul returns a * b. It panics if the result overflows.
+func mul(a, b int64) int64 {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	p := a * b
+	if p/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		panic(overflow("%d * %d units", a, b))
+	}
+	return p
+}
+
+// int64Of returns n as an int64. It panics if n is out of range, describing the operation with format and args.
+func int64Of(n *big.Int, format string, args ...any) int64 {
+	if !n.IsInt64() {
+		panic(overflow(format, args...))
+	}
+	return n.Int64()
+}
+
+// overflow returns the error of an operation that overflows, described by format and args.
+func overflow(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrOverflow, fmt.Sprintf(format, args...))
+}
+
+// divRound returns n / d rounded half away from zero.
+func divRound(n, d *big.Int) *big.Int {
+	q, r := new(big.Int).QuoRem(n, d, new(big.Int))
+	if new(big.Int).Abs(r).Cmp(new(big.Int).Rsh(new(big.Int).Add(d, big.NewInt(1)), 1)) >= 0 {
+		if n.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+func pow10(n int) int64 {
+	p := int64(1)
+	for i := 0; i < n; i++ {
+		p *= 10
+	}
+	return p
+}
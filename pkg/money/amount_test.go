@@ -0,0 +1,191 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr error
+	}{
+		{in: "123.45", want: "123.45"},
+		{in: "-0.1", want: "-0.1"},
+		{in: "10", want: "10"},
+		{in: "1e3", want: "1000"},
+		{in: "1.5E-2", want: "0.015"},
+		{in: "0.0000005", want: "0.000001"},
+		{in: "-0.0000005", want: "-0.000001"},
+		{in: "0.00000049", want: "0"},
+		{in: "abc", wantErr: ErrInvalidAmount},
+		{in: "", wantErr: ErrInvalidAmount},
+		{in: "1.2.3", wantErr: ErrInvalidAmount},
+		{in: "1e30", wantErr: ErrOverflow},
+		{in: "0.000000000000000000000000000001e30", want: "1"},
+		{in: "0e99999999999", want: "0"},
+		{in: "1e-2000000000", want: "0"},
+		{in: "-1e-9223372036854775808", want: "0"},
+		{in: "1e2000000000", wantErr: ErrOverflow},
+		{in: "1e9223372036854775807", wantErr: ErrOverflow},
+		{in: "9223372036854.775807", want: "9223372036854.775807"},
+		{in: "9223372036854.775808", wantErr: ErrOverflow},
+		{in: "1e99999999999999999999", wantErr: ErrInvalidAmount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := Parse(tt.in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("Parse() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmountJSON(t *testing.T) {
+	type payload struct {
+		Amount   Amount `json:"amount"`
+		Optional Amount `json:"optional,omitzero"`
+	}
+
+	var p payload
+	if err := json.Unmarshal([]byte(`{"amount": 0.1}`), &p); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	p.Amount = p.Amount.Add(MustParse("0.2"))
+	if !p.Amount.Equal(MustParse("0.3")) {
+		t.Errorf("0.1 + 0.2 = %s, want 0.3", p.Amount)
+	}
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if string(b) != `{"amount":0.3}` {
+		t.Errorf("json.Marshal() = %s, want %s", b, `{"amount":0.3}`)
+	}
+}
+
+func TestAmountRounding(t *testing.T) {
+	tests := []struct {
+		amount   string
+		currency Currency
+		want     string
+		minor    int64
+	}{
+		{amount: "10.005", currency: BRL, want: "10.01", minor: 1001},
+		{amount: "10.004", currency: BRL, want: "10.00", minor: 1000},
+		{amount: "-10.005", currency: BRL, want: "-10.01", minor: -1001},
+		{amount: "1500.5", currency: CLP, want: "1501", minor: 1501},
+	}
+	for _, tt := range tests {
+		t.Run(tt.amount, func(t *testing.T) {
+			a := MustParse(tt.amount)
+			if got := a.Format(tt.currency); got != tt.want {
+				t.Errorf("Amount.Format() = %s, want %s", got, tt.want)
+			}
+			if got := a.Minor(tt.currency); got != tt.minor {
+				t.Errorf("Amount.Minor() = %d, want %d", got, tt.minor)
+			}
+		})
+	}
+}
+
+func TestAmountAllocate(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   string
+		currency Currency
+		ratios   []int64
+		want     []string
+		wantErr  bool
+	}{
+		{name: "even_split", amount: "100", currency: BRL, ratios: []int64{1, 1, 1}, want: []string{"33.34", "33.33", "33.33"}},
+		{name: "proportional", amount: "10", currency: BRL, ratios: []int64{70, 30}, want: []string{"7", "3"}},
+		{name: "negative", amount: "-0.05", currency: BRL, ratios: []int64{1, 1}, want: []string{"-0.03", "-0.02"}},
+		{name: "sub_minor_digits", amount: "0.015", currency: BRL, ratios: []int64{1, 1}, want: []string{"0.015", "0"}},
+		{name: "zero_ratio", amount: "0.03", currency: BRL, ratios: []int64{0, 1}, want: []string{"0", "0.03"}},
+		{name: "sub_minor_digits_with_zero_ratio", amount: "0.035", currency: BRL, ratios: []int64{0, 1, 1}, want: []string{"0", "0.025", "0.01"}},
+		{name: "no_decimals", amount: "1000", currency: CLP, ratios: []int64{1, 2}, want: []string{"334", "666"}},
+		{name: "zero_ratios", amount: "1", currency: BRL, ratios: []int64{0}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts, err := MustParse(tt.amount).Allocate(tt.currency, tt.ratios...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Amount.Allocate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var got []string
+			for _, p := range parts {
+				got = append(got, p.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Amount.Allocate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmountArithmetic(t *testing.T) {
+	a := MustParse("199.90")
+	if got := a.Percent(MustParse("4.99")).Round(BRL); !got.Equal(MustParse("9.98")) {
+		t.Errorf("Amount.Percent() = %s, want 9.98", got)
+	}
+	if got := a.Mul(MustParse("0.5")); !got.Equal(MustParse("99.95")) {
+		t.Errorf("Amount.Mul() = %s, want 99.95", got)
+	}
+	if got := a.MulInt(3).Sub(FromInt(600)); !got.Equal(MustParse("-0.3")) {
+		t.Errorf("Amount.MulInt().Sub() = %s, want -0.3", got)
+	}
+	if got := FromMinor(1050, BRL); got.String() != "10.5" {
+		t.Errorf("FromMinor() = %s, want 10.5", got)
+	}
+	if got, err := FromFloat(1.1); err != nil || got.Float64() != 1.1 {
+		t.Errorf("FromFloat().Float64() = %v, %v, want 1.1", got.Float64(), err)
+	}
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if _, err := FromFloat(f); !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("FromFloat(%v) error = %v, want ErrInvalidAmount", f, err)
+		}
+	}
+	if _, err := FromFloat(1e20); !errors.Is(err, ErrOverflow) {
+		t.Errorf("FromFloat(1e20) error = %v, want ErrOverflow", err)
+	}
+}
+
+func TestAmountArithmeticOverflow(t *testing.T) {
+	largest := MustParse("9223372036854.775807")
+	smallest := largest.Neg().Sub(MustParse("0.000001"))
+	tests := []struct {
+		name string
+		op   func() Amount
+	}{
+		{name: "add", op: func() Amount { return largest.Add(MustParse("0.000001")) }},
+		{name: "sub", op: func() Amount { return smallest.Sub(MustParse("0.000001")) }},
+		{name: "neg", op: func() Amount { return smallest.Neg() }},
+		{name: "mul_int", op: func() Amount { return largest.MulInt(2) }},
+		{name: "mul", op: func() Amount { return largest.Mul(MustParse("1.5")) }},
+		{name: "percent", op: func() Amount { return largest.Percent(FromInt(200)) }},
+		{name: "from_int", op: func() Amount { return FromInt(10_000_000_000_000) }},
+		{name: "round", op: func() Amount { return largest.Round(BRL) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				err, _ := recover().(error)
+				if !errors.Is(err, ErrOverflow) {
+					t.Errorf("recover() = %v, want ErrOverflow", err)
+				}
+			}()
+			got := tt.op()
+			t.Errorf("= %s, want a panic", got)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package money
+
+// Currency is an ISO 4217 currency code, as in the currency_id field of the API.
+type Currency string
+
+// Currencies supported by Mercado Pago.
+const (
+	ARS Currency = "ARS"
+	BRL Currency = "BRL"
+	CLP Currency = "CLP"
+	COP Currency = "COP"
+	MXN Currency = "MXN"
+	PEN Currency = "PEN"
+	UYU Currency = "UYU"
+	USD Currency = "USD"
+	VES Currency = "VES"
+)
+
+// minorUnits are the currencies whose minor units are not cents.
+var minorUnits = map[Currency]int{
+	CLP: 0,
+}
+
+// MinorUnits returns the number of decimal places of the currency, 2 unless known otherwise.
+func (c Currency) MinorUnits() int {
+	if n, ok := minorUnits[c]; ok {
+		return n
+	}
+	return 2
+}
+
+// String implements fmt.Stringer.
+func (c Currency) String() string {
+	return string(c)
+}
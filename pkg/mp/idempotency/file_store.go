@@ -0,0 +1,129 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// fileStore is a Store keeping one JSON file per record in a directory.
+// Files are replaced atomically, so a record survives a crash of the process.
+type fileStore struct {
+	dir string
+	ttl time.Duration
+}
+
+// NewFileStore returns a Store persisting records in dir, which is created if needed.
+// Records older than ttl are discarded, a zero ttl keeps them forever.
+func NewFileStore(dir string, ttl time.Duration) (Store, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("error creating idempotency store directory: %w", err)
+	}
+
+	return &fileStore{
+		dir: dir,
+		ttl: ttl,
+	}, nil
+}
+
+func (s *fileStore) Get(_ context.Context, key string) (*Record, error) {
+	b, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading idempotency record: %w", err)
+	}
+
+	r := &Record{}
+	if err := json.Unmarshal(b, r); err != nil {
+		return nil, fmt.Errorf("error decoding idempotency record: %w", err)
+	}
+	if expired(r, s.ttl) {
+		_ = os.Remove(s.path(key))
+		return nil, ErrNotFound
+	}
+	return r, nil
+}
+
+func (s *fileStore) Put(_ context.Context, r *Record) error {
+	tmp, err := s.writeTemp(r)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	if err := os.Rename(tmp, s.path(r.Key)); err != nil {
+		return fmt.Errorf("error writing idempotency record: %w", err)
+	}
+	return nil
+}
+
+// Create links a complete temporary file to the path of the record, which fails if the path exists:
+// unlike a rename, a link never replaces a file, and readers never see a partial record.
+func (s *fileStore) Create(ctx context.Context, r *Record) (*Record, error) {
+	tmp, err := s.writeTemp(r)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp)
+	for {
+		err := os.Link(tmp, s.path(r.Key))
+		if err == nil {
+			return nil, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("error writing idempotency record: %w", err)
+		}
+		// Get removes an expired record, in which case the link is tried again.
+		existing, err := s.Get(ctx, r.Key)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return existing, ErrExists
+	}
+}
+
+// writeTemp writes the record to a temporary file of the directory and returns its name.
+func (s *fileStore) writeTemp(r *Record) (string, error) {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return "", fmt.Errorf("error encoding idempotency record: %w", err)
+	}
+	tmp, err := os.CreateTemp(s.dir, ".record-*")
+	if err != nil {
+		return "", fmt.Errorf("error creating idempotency record: %w", err)
+	}
+	if _, err := tmp.Write(b); err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("error writing idempotency record: %w", err)
+	}
+	return tmp.Name(), nil
+}
+
+func (s *fileStore) Delete(_ context.Context, key string) error {
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error deleting idempotency record: %w", err)
+	}
+	return nil
+}
+
+// path returns the file of the key. Keys are hashed so that any key is a valid file name.
+func (s *fileStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+".json")
+}
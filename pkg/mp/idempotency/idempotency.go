@@ -0,0 +1,95 @@
+// Package idempotency derives deterministic idempotency keys from business keys
+// and persists the outcome of the requests sent with them.
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// namespace is the UUID namespace of the keys derived by DeriveKey.
+var namespace = uuid.MustParse("6f0c1f8e-5b7a-4c1e-9d43-2a8e3b1f7c55")
+
+var (
+	// ErrNotFound is returned by a Store when there is no record for a key.
+	ErrNotFound = errors.New("idempotency record not found")
+
+	// ErrKeyReused is returned when an idempotency key is reused with a different request.
+	ErrKeyReused = errors.New("idempotency key reused with a different request")
+
+	// ErrExists is returned by Store.Create when there is already a record for the key.
+	ErrExists = errors.New("idempotency record already exists")
+)
+
+// Record is the persisted state of a request sent with an idempotency key.
+type Record struct {
+	Key         string    `json:"key"`
+	Fingerprint string    `json:"fingerprint"`
+	StatusCode  int       `json:"status_code,omitempty"`
+	Body        []byte    `json:"body,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Pending reports whether the request was sent but its response is unknown,
+// e.g. because the process crashed or the API answered with a server error.
+func (r *Record) Pending() bool {
+	return r.StatusCode == 0
+}
+
+// Store is the interface that defines how idempotency records are persisted.
+type Store interface {
+	// Get returns the record of the key, or ErrNotFound.
+	Get(ctx context.Context, key string) (*Record, error)
+
+	// Put creates or replaces the record of the key.
+	Put(ctx context.Context, r *Record) error
+
+	// Create stores r if there is no record for its key, atomically, so that a single caller creates it.
+	// Otherwise it returns the existing record and ErrExists.
+	Create(ctx context.Context, r *Record) (*Record, error)
+
+	// Delete removes the record of the key, if any.
+	Delete(ctx context.Context, key string) error
+}
+
+// DeriveKey returns the idempotency key of a business key, such as an order ID, for an operation.
+// The same operation and business key always give the same idempotency key, even across processes.
+func DeriveKey(operation, businessKey string) string {
+	return uuid.NewSHA1(namespace, []byte(operation+"\n"+businessKey)).String()
+}
+
+// TenantKey returns the key of the records of an idempotency key for the seller of an access token,
+// so that sellers sharing a store never replay each other's responses. The token is hashed, so it is not stored.
+func TenantKey(accessToken, key string) string {
+	return tenant(accessToken) + "/" + key
+}
+
+// Fingerprint returns a digest identifying a request by the seller of its access token, its method, URL and body.
+// JSON bodies are compacted first, so formatting does not change the fingerprint.
+func Fingerprint(accessToken, method, url string, body []byte) string {
+	compacted := &bytes.Buffer{}
+	if err := json.Compact(compacted, body); err == nil {
+		body = compacted.Bytes()
+	}
+	h := sha256.New()
+	h.Write([]byte(tenant(accessToken) + "\n" + method + " " + url + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// tenant returns a digest of an access token.
+func tenant(accessToken string) string {
+	sum := sha256.Sum256([]byte(accessToken))
+	return hex.EncodeToString(sum[:16])
+}
+
+func expired(r *Record, ttl time.Duration) bool {
+	return ttl > 0 && time.Since(r.CreatedAt) > ttl
+}
@@ -0,0 +1,157 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDeriveKey(t *testing.T) {
+	if DeriveKey("payment.Create", "order-1") != DeriveKey("payment.Create", "order-1") {
+		t.Error("DeriveKey() is not deterministic")
+	}
+	if DeriveKey("payment.Create", "order-1") == DeriveKey("payment.Create", "order-2") {
+		t.Error("DeriveKey() returned the same key for different business keys")
+	}
+	if DeriveKey("payment.Create", "order-1") == DeriveKey("payment.Cancel", "order-1") {
+		t.Error("DeriveKey() returned the same key for different operations")
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	a := Fingerprint("token", "POST", "https://api.mercadopago.com/v1/payments", []byte(`{"transaction_amount": 10}`))
+	b := Fingerprint("token", "POST", "https://api.mercadopago.com/v1/payments", []byte(`{"transaction_amount":10}`))
+	c := Fingerprint("token", "POST", "https://api.mercadopago.com/v1/payments", []byte(`{"transaction_amount":11}`))
+	d := Fingerprint("other-token", "POST", "https://api.mercadopago.com/v1/payments", []byte(`{"transaction_amount":10}`))
+	if a != b {
+		t.Error("Fingerprint() changed with JSON formatting")
+	}
+	if a == c {
+		t.Error("Fingerprint() returned the same digest for different bodies")
+	}
+	if a == d {
+		t.Error("Fingerprint() returned the same digest for different access tokens")
+	}
+}
+
+func TestTenantKey(t *testing.T) {
+	key := DeriveKey("payment.Create", "order-1")
+	a, b := TenantKey("seller-1", key), TenantKey("seller-2", key)
+	if a == b {
+		t.Error("TenantKey() returned the same key for different access tokens")
+	}
+	if strings.Contains(a, "seller-1") {
+		t.Errorf("TenantKey() = %s, contains the access token", a)
+	}
+}
+
+func TestStores(t *testing.T) {
+	fileStore, err := NewFileStore(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	expiringStore, err := NewFileStore(t.TempDir(), time.Nanosecond)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		store       Store
+		wantExpired bool
+	}{
+		{name: "memory", store: NewMemoryStore(0)},
+		{name: "memory_expiring", store: NewMemoryStore(time.Nanosecond), wantExpired: true},
+		{name: "file", store: fileStore},
+		{name: "file_expiring", store: expiringStore, wantExpired: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if _, err := tt.store.Get(ctx, "key/1"); !errors.Is(err, ErrNotFound) {
+				t.Fatalf("Store.Get() error = %v, want ErrNotFound", err)
+			}
+
+			want := &Record{
+				Key:         "key/1",
+				Fingerprint: "abc",
+				StatusCode:  201,
+				Body:        []byte(`{"id":1}`),
+				CreatedAt:   time.Now().UTC().Truncate(time.Second),
+			}
+			if _, err := tt.store.Create(ctx, want); err != nil {
+				t.Fatalf("Store.Create() error = %v", err)
+			}
+			existing, err := tt.store.Create(ctx, &Record{Key: "key/1", Fingerprint: "def"})
+			if tt.wantExpired {
+				if err != nil {
+					t.Errorf("Store.Create() over an expired record error = %v, want nil", err)
+				}
+			} else if !errors.Is(err, ErrExists) || !reflect.DeepEqual(existing, want) {
+				t.Errorf("Store.Create() of an existing key = %+v, %v, want %+v, ErrExists", existing, err, want)
+			}
+			if err := tt.store.Put(ctx, want); err != nil {
+				t.Fatalf("Store.Put() error = %v", err)
+			}
+
+			got, err := tt.store.Get(ctx, "key/1")
+			if tt.wantExpired {
+				if !errors.Is(err, ErrNotFound) {
+					t.Errorf("Store.Get() of an expired record error = %v, want ErrNotFound", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Store.Get() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Store.Get() = %+v, want %+v", got, want)
+			}
+
+			if err := tt.store.Delete(ctx, "key/1"); err != nil {
+				t.Fatalf("Store.Delete() error = %v", err)
+			}
+			if _, err := tt.store.Get(ctx, "key/1"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Store.Get() after Delete() error = %v, want ErrNotFound", err)
+			}
+		})
+	}
+}
+
+func TestStoresCreateConcurrently(t *testing.T) {
+	fileStore, err := NewFileStore(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	for name, store := range map[string]Store{"memory": NewMemoryStore(0), "file": fileStore} {
+		t.Run(name, func(t *testing.T) {
+			var (
+				wg      sync.WaitGroup
+				mu      sync.Mutex
+				created int
+			)
+			for i := 0; i < 20; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, err := store.Create(context.Background(), &Record{Key: "key", CreatedAt: time.Now()})
+					if err == nil {
+						mu.Lock()
+						created++
+						mu.Unlock()
+					} else if !errors.Is(err, ErrExists) {
+						t.Errorf("Store.Create() error = %v", err)
+					}
+				}()
+			}
+			wg.Wait()
+			if created != 1 {
+				t.Errorf("Store.Create() created %d records, want 1", created)
+			}
+		})
+	}
+}
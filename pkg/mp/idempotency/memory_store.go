@@ -0,0 +1,65 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryStore is an in-memory Store. Records are lost when the process exits.
+type memoryStore struct {
+	mu sync.Mutex
+
+	ttl     time.Duration
+	records map[string]Record
+}
+
+// NewMemoryStore returns an in-memory Store.
+// Records older than ttl are discarded, a zero ttl keeps them forever.
+func NewMemoryStore(ttl time.Duration) Store {
+	return &memoryStore{
+		ttl:     ttl,
+		records: map[string]Record{},
+	}
+}
+
+func (s *memoryStore) Get(_ context.Context, key string) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.records[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if expired(&r, s.ttl) {
+		delete(s.records, key)
+		return nil, ErrNotFound
+	}
+	return &r, nil
+}
+
+func (s *memoryStore) Put(_ context.Context, r *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records[r.Key] = *r
+	return nil
+}
+
+func (s *memoryStore) Create(_ context.Context, r *Record) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.records[r.Key]; ok && !expired(&existing, s.ttl) {
+		return &existing, ErrExists
+	}
+	s.records[r.Key] = *r
+	return nil, nil
+}
+
+func (s *memoryStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
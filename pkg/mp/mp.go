@@ -3,6 +3,7 @@ package mp
 import (
 	"net/http"
 
+	"github.com/gdeandradero/sdk-go/pkg/mp/idempotency"
 	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
 )
 
@@ -25,3 +26,34 @@ func SetCustomHTTPClient(hc *http.Client) {
 func SetCustomRetryClient(rc rest.RetryClient) {
 	rest.SetRC(rc)
 }
+
+// SetTracer sets a tracer to create spans for each SDK operation.
+func SetTracer(t rest.Tracer) {
+	rest.SetTracer(t)
+}
+
+// SetMeter sets a meter to record latency and error metrics for each SDK operation.
+func SetMeter(m rest.Meter) {
+	rest.SetMeter(m)
+}
+
+// SetRateLimiter sets a client-side rate limiter, see rest.NewRateLimiter.
+func SetRateLimiter(rl rest.RateLimiter) {
+	rest.SetRateLimiter(rl)
+}
+
+// SetCircuitBreaker sets a circuit breaker, see rest.NewCircuitBreaker.
+func SetCircuitBreaker(cb rest.CircuitBreaker) {
+	rest.SetCircuitBreaker(cb)
+}
+
+// SetIdempotencyStore sets a store to persist requests sent with rest.WithIdempotencyKey and replay them.
+func SetIdempotencyStore(s idempotency.Store) {
+	rest.SetIdempotencyStore(s)
+}
+
+// SetStrictDecoding makes the responses of the default client with fields the SDK does not model fail
+// to decode, instead of keeping the fields in Extra. It is meant for contract tests, see rest.WithStrictDecoding.
+func SetStrictDecoding(strict bool) {
+	rest.SetStrictDecoding(strict)
+}
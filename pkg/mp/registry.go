@@ -0,0 +1,211 @@
+package mp
+
+import (
+	"container/list"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+	"github.com/gdeandradero/sdk-go/pkg/payment"
+	"github.com/gdeandradero/sdk-go/pkg/paymentmethod"
+)
+
+const defaultMaxTenants = 1000
+
+// ErrNoCredentials is returned by Registry.Get when the credentials loader returns no access token.
+var ErrNoCredentials = errors.New("tenant has no access token")
+
+// Credentials are the credentials and settings of a tenant.
+type Credentials struct {
+	AccessToken string
+
+	// HTTPClient, RateLimiter and CircuitBreaker, if set, replace the ones of RegistryConfig.Client.
+	HTTPClient     *http.Client
+	RateLimiter    rest.RateLimiter
+	CircuitBreaker rest.CircuitBreaker
+}
+
+// CredentialsLoader loads the credentials of a tenant, e.g. from a secret store.
+type CredentialsLoader func(tenantID int64) (Credentials, error)
+
+// RegistryConfig configures a Registry.
+type RegistryConfig struct {
+	// Loader loads the credentials of the tenants. It is required.
+	Loader CredentialsLoader
+
+	// MaxTenants is the number of tenants kept in the registry. The least recently used tenant
+	// is evicted when a new one is loaded. Default is 1000.
+	MaxTenants int
+
+	// Client holds the settings shared by the clients of every tenant, such as the tracer, the meter
+	// or the retry client. Its AccessToken is ignored.
+	Client rest.ClientConfig
+
+	// OnEvict, if set, is called with the ID of each evicted tenant.
+	OnEvict func(tenantID int64)
+}
+
+// Tenant holds the clients of a tenant, such as a seller, all sending requests with its access token.
+type Tenant struct {
+	ID            int64
+	Rest          rest.Client
+	Payment       payment.Client
+	PaymentMethod paymentmethod.Client
+}
+
+// Registry lazily creates and caches the clients of many tenants, keyed by seller or collector ID.
+// It is safe for concurrent use.
+type Registry struct {
+	config RegistryConfig
+
+	mu      sync.Mutex
+	lru     *list.List
+	entries map[int64]*list.Element
+}
+
+// registryEntry is an element of Registry.lru. ready is closed once tenant or err is set.
+type registryEntry struct {
+	id     int64
+	ready  chan struct{}
+	tenant *Tenant
+	err    error
+}
+
+// NewRegistry returns a new registry.
+func NewRegistry(config RegistryConfig) *Registry {
+	if config.MaxTenants <= 0 {
+		config.MaxTenants = defaultMaxTenants
+	}
+	return &Registry{
+		config:  config,
+		lru:     list.New(),
+		entries: map[int64]*list.Element{},
+	}
+}
+
+// Get returns the clients of the tenant, loading its credentials on first use.
+// Concurrent calls for the same tenant load its credentials once. Failed loads are not cached.
+func (r *Registry) Get(tenantID int64) (*Tenant, error) {
+	r.mu.Lock()
+	if el, ok := r.entries[tenantID]; ok {
+		r.lru.MoveToFront(el)
+		r.mu.Unlock()
+
+		e := el.Value.(*registryEntry)
+		<-e.ready
+		return e.tenant, e.err
+	}
+
+	e := &registryEntry{id: tenantID, ready: make(chan struct{})}
+	r.entries[tenantID] = r.lru.PushFront(e)
+	evicted := r.evict()
+	r.mu.Unlock()
+
+	if r.config.OnEvict != nil {
+		for _, id := range evicted {
+			r.config.OnEvict(id)
+		}
+	}
+
+	e.tenant, e.err = r.load(tenantID)
+	if e.err != nil {
+		r.remove(e)
+	}
+	close(e.ready)
+	return e.tenant, e.err
+}
+
+// Payment returns the payment client of the tenant.
+func (r *Registry) Payment(tenantID int64) (payment.Client, error) {
+	t, err := r.Get(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return t.Payment, nil
+}
+
+// PaymentMethod returns the payment method client of the tenant.
+func (r *Registry) PaymentMethod(tenantID int64) (paymentmethod.Client, error) {
+	t, err := r.Get(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return t.PaymentMethod, nil
+}
+
+// Evict removes the tenant, e.g. after its access token was rotated, so that the next Get loads it again.
+func (r *Registry) Evict(tenantID int64) {
+	r.mu.Lock()
+	el, ok := r.entries[tenantID]
+	if ok {
+		r.lru.Remove(el)
+		delete(r.entries, tenantID)
+	}
+	r.mu.Unlock()
+
+	if ok && r.config.OnEvict != nil {
+		r.config.OnEvict(tenantID)
+	}
+}
+
+// Len returns the number of tenants in the registry.
+func (r *Registry) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lru.Len()
+}
+
+func (r *Registry) load(tenantID int64) (*Tenant, error) {
+	creds, err := r.config.Loader(tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if creds.AccessToken == "" {
+		return nil, ErrNoCredentials
+	}
+
+	config := r.config.Client
+	config.AccessToken = creds.AccessToken
+	if creds.HTTPClient != nil {
+		config.HTTPClient = creds.HTTPClient
+	}
+	if creds.RateLimiter != nil {
+		config.RateLimiter = creds.RateLimiter
+	}
+	if creds.CircuitBreaker != nil {
+		config.CircuitBreaker = creds.CircuitBreaker
+	}
+
+	rc := rest.NewClientWithConfig(config)
+	return &Tenant{
+		ID:            tenantID,
+		Rest:          rc,
+		Payment:       payment.NewClient(rc),
+		PaymentMethod: paymentmethod.NewClient(rc),
+	}, nil
+}
+
+// evict removes the least recently used tenants above MaxTenants and returns their IDs.
+// It must be called with r.mu held.
+func (r *Registry) evict() []int64 {
+	var evicted []int64
+	for r.lru.Len() > r.config.MaxTenants {
+		el := r.lru.Back()
+		e := el.Value.(*registryEntry)
+		r.lru.Remove(el)
+		delete(r.entries, e.id)
+		evicted = append(evicted, e.id)
+	}
+	return evicted
+}
+
+// remove removes the entry if it is still the one of its tenant.
+func (r *Registry) remove(e *registryEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if el, ok := r.entries[e.id]; ok && el.Value == e {
+		r.lru.Remove(el)
+		delete(r.entries, e.id)
+	}
+}
@@ -0,0 +1,87 @@
+package mp
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	var (
+		loads   atomic.Int32
+		evicted []int64
+	)
+	loadErr := errors.New("secret store unavailable")
+	r := NewRegistry(RegistryConfig{
+		MaxTenants: 2,
+		Loader: func(tenantID int64) (Credentials, error) {
+			loads.Add(1)
+			switch tenantID {
+			case 0:
+				return Credentials{}, nil
+			case -1:
+				return Credentials{}, loadErr
+			}
+			return Credentials{AccessToken: "token"}, nil
+		},
+		OnEvict: func(tenantID int64) { evicted = append(evicted, tenantID) },
+	})
+
+	var wg sync.WaitGroup
+	tenants := make([]*Tenant, 10)
+	for i := range tenants {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tenants[i], _ = r.Get(1)
+		}()
+	}
+	wg.Wait()
+	if loads.Load() != 1 {
+		t.Errorf("Registry.Get() loaded %d times, want 1", loads.Load())
+	}
+	for _, tenant := range tenants {
+		if tenant == nil || tenant != tenants[0] || tenant.Payment == nil || tenant.PaymentMethod == nil {
+			t.Fatalf("Registry.Get() = %+v, want the same tenant", tenant)
+		}
+	}
+
+	if _, err := r.Get(2); err != nil {
+		t.Fatalf("Registry.Get() error = %v", err)
+	}
+	if _, err := r.Get(1); err != nil {
+		t.Fatalf("Registry.Get() error = %v", err)
+	}
+	// 2 is now the least recently used tenant.
+	if _, err := r.Get(3); err != nil {
+		t.Fatalf("Registry.Get() error = %v", err)
+	}
+	if !reflect.DeepEqual(evicted, []int64{2}) {
+		t.Errorf("evicted = %v, want %v", evicted, []int64{2})
+	}
+	if r.Len() != 2 {
+		t.Errorf("Registry.Len() = %d, want 2", r.Len())
+	}
+
+	if _, err := r.Get(-1); !errors.Is(err, loadErr) {
+		t.Errorf("Registry.Get() error = %v, wantErr %v", err, loadErr)
+	}
+	if _, err := r.Get(0); !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("Registry.Get() error = %v, wantErr %v", err, ErrNoCredentials)
+	}
+	before := loads.Load()
+	if _, err := r.Get(-1); !errors.Is(err, loadErr) {
+		t.Errorf("Registry.Get() error = %v, wantErr %v", err, loadErr)
+	}
+	if loads.Load() != before+1 {
+		t.Error("Registry.Get() cached a failed load")
+	}
+
+	r.Evict(1)
+	before = loads.Load()
+	if _, err := r.Get(1); err != nil || loads.Load() != before+1 {
+		t.Errorf("Registry.Get() after Evict() error = %v, loads = %d, want %d", err, loads.Load(), before+1)
+	}
+}
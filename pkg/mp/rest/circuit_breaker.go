@@ -0,0 +1,259 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultFailureRatio        = 0.5
+	defaultMinRequests         = 10
+	defaultFailureWindow       = time.Minute
+	defaultOpenTimeout         = 30 * time.Second
+	defaultHalfOpenMaxRequests = 1
+)
+
+// ErrCircuitOpen is matched, with errors.Is, by the errors returned when a circuit is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitOpenError is returned instead of sending a request when the circuit of its scope is open.
+type CircuitOpenError struct {
+	// Scope is the scope of the open circuit, e.g. "payments".
+	Scope string
+
+	// RetryAt is when the circuit lets trial requests through again.
+	RetryAt time.Time
+}
+
+// Error implements error.
+func (e *CircuitOpenError) Error() string {
+	return ErrCircuitOpen.Error() + " for " + e.Scope
+}
+
+// Is reports whether target is ErrCircuitOpen.
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// CircuitBreaker is the interface that defines the circuit breaker signature.
+// Every request attempt, retries included, is checked with Allow and, if allowed, reported with Record.
+type CircuitBreaker interface {
+	// Allow returns an error matching ErrCircuitOpen when the request must not be sent.
+	Allow(req *http.Request) error
+
+	// Record reports the outcome of an allowed request.
+	Record(req *http.Request, res *http.Response, err error)
+}
+
+// CircuitState is the state of a circuit.
+type CircuitState int
+
+const (
+	// CircuitClosed lets every request through and counts failures.
+	CircuitClosed CircuitState = iota
+
+	// CircuitOpen rejects every request until the open timeout elapses.
+	CircuitOpen
+
+	// CircuitHalfOpen lets a few trial requests through to decide whether to close or reopen.
+	CircuitHalfOpen
+)
+
+// String implements fmt.Stringer.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreakerConfig configures the default CircuitBreaker. Zero values take the defaults.
+type CircuitBreakerConfig struct {
+	// FailureRatio is the ratio of failed requests that opens the circuit. Default is 0.5.
+	FailureRatio float64
+
+	// MinRequests is the number of requests within Window before FailureRatio is considered. Default is 10.
+	MinRequests int
+
+	// Window is the period in which requests are counted while closed. Default is 1 minute.
+	Window time.Duration
+
+	// OpenTimeout is how long the circuit stays open before going half-open. Default is 30 seconds.
+	OpenTimeout time.Duration
+
+	// HalfOpenMaxRequests is the number of successful trial requests that closes the circuit. Default is 1.
+	HalfOpenMaxRequests int
+
+	// Scope maps a request to its circuit. Default is EndpointScope.
+	Scope func(req *http.Request) string
+
+	// OnStateChange, if set, is called whenever a circuit changes state.
+	// It is called while the breaker is locked, so it must not block.
+	OnStateChange func(scope string, from, to CircuitState)
+}
+
+// EndpointScope returns the API resource of the request, e.g. "payments" for /v1/payments/123
+// and "payment_methods" for /v1/payment_methods.
+func EndpointScope(req *http.Request) string {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if len(segments) > 1 && len(segments[0]) > 1 && segments[0][0] == 'v' {
+		return segments[1]
+	}
+	return segments[0]
+}
+
+// circuitBreaker is the default implementation of CircuitBreaker.
+type circuitBreaker struct {
+	mu sync.Mutex
+
+	config   CircuitBreakerConfig
+	circuits map[string]*circuit
+}
+
+// circuit holds the state of a single scope.
+type circuit struct {
+	state       CircuitState
+	openedAt    time.Time
+	windowStart time.Time
+	successes   int
+	failures    int
+	inFlight    int
+}
+
+// NewCircuitBreaker returns a CircuitBreaker with one circuit per scope.
+func NewCircuitBreaker(config CircuitBreakerConfig) CircuitBreaker {
+	if config.FailureRatio <= 0 {
+		config.FailureRatio = defaultFailureRatio
+	}
+	if config.MinRequests <= 0 {
+		config.MinRequests = defaultMinRequests
+	}
+	if config.Window <= 0 {
+		config.Window = defaultFailureWindow
+	}
+	if config.OpenTimeout <= 0 {
+		config.OpenTimeout = defaultOpenTimeout
+	}
+	if config.HalfOpenMaxRequests <= 0 {
+		config.HalfOpenMaxRequests = defaultHalfOpenMaxRequests
+	}
+	if config.Scope == nil {
+		config.Scope = EndpointScope
+	}
+
+	return &circuitBreaker{
+		config:   config,
+		circuits: map[string]*circuit{},
+	}
+}
+
+func (cb *circuitBreaker) Allow(req *http.Request) error {
+	scope := cb.config.Scope(req)
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	now := time.Now()
+	ci := cb.circuit(scope, now)
+	if ci.state == CircuitOpen && now.Sub(ci.openedAt) >= cb.config.OpenTimeout {
+		cb.transition(scope, ci, CircuitHalfOpen, now)
+	}
+
+	switch ci.state {
+	case CircuitOpen:
+		return &CircuitOpenError{Scope: scope, RetryAt: ci.openedAt.Add(cb.config.OpenTimeout)}
+	case CircuitHalfOpen:
+		if ci.inFlight >= cb.config.HalfOpenMaxRequests-ci.successes {
+			return &CircuitOpenError{Scope: scope, RetryAt: now.Add(cb.config.OpenTimeout)}
+		}
+		ci.inFlight++
+	}
+	return nil
+}
+
+func (cb *circuitBreaker) Record(req *http.Request, res *http.Response, err error) {
+	scope := cb.config.Scope(req)
+	failed := err != nil || res.StatusCode >= http.StatusInternalServerError
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	now := time.Now()
+	ci := cb.circuit(scope, now)
+
+	// requests that never reached the API say nothing about its health.
+	if errors.Is(err, ErrRateLimited) || errors.Is(err, context.Canceled) {
+		if ci.state == CircuitHalfOpen {
+			ci.inFlight = max(ci.inFlight-1, 0)
+		}
+		return
+	}
+
+	switch ci.state {
+	case CircuitClosed:
+		if now.Sub(ci.windowStart) > cb.config.Window {
+			ci.windowStart, ci.successes, ci.failures = now, 0, 0
+		}
+		if !failed {
+			ci.successes++
+			return
+		}
+		ci.failures++
+		total := ci.successes + ci.failures
+		if total >= cb.config.MinRequests && float64(ci.failures)/float64(total) >= cb.config.FailureRatio {
+			cb.transition(scope, ci, CircuitOpen, now)
+		}
+	case CircuitHalfOpen:
+		ci.inFlight = max(ci.inFlight-1, 0)
+		if failed {
+			cb.transition(scope, ci, CircuitOpen, now)
+			return
+		}
+		ci.successes++
+		if ci.successes >= cb.config.HalfOpenMaxRequests {
+			cb.transition(scope, ci, CircuitClosed, now)
+		}
+	}
+}
+
+// circuit returns the circuit of the scope, creating it if needed.
+// It must be called with cb.mu held.
+func (cb *circuitBreaker) circuit(scope string, now time.Time) *circuit {
+	ci, ok := cb.circuits[scope]
+	if !ok {
+		ci = &circuit{windowStart: now}
+		cb.circuits[scope] = ci
+	}
+	return ci
+}
+
+// transition moves the circuit to a new state and resets its counters.
+// It must be called with cb.mu held.
+func (cb *circuitBreaker) transition(scope string, ci *circuit, to CircuitState, now time.Time) {
+	from := ci.state
+	ci.state = to
+	ci.windowStart, ci.successes, ci.failures, ci.inFlight = now, 0, 0, 0
+	if to == CircuitOpen {
+		ci.openedAt = now
+	}
+
+	if cb.config.OnStateChange != nil {
+		cb.config.OnStateChange(scope, from, to)
+	}
+}
+
+// noopCircuitBreaker is the default CircuitBreaker, it lets every request through.
+type noopCircuitBreaker struct{}
+
+func (noopCircuitBreaker) Allow(*http.Request) error                   { return nil }
+func (noopCircuitBreaker) Record(*http.Request, *http.Response, error) {}
@@ -0,0 +1,184 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	payments, _ := http.NewRequest(http.MethodGet, "https://api.mercadopago.com/v1/payments/123", nil)
+	methods, _ := http.NewRequest(http.MethodGet, "https://api.mercadopago.com/v1/payment_methods", nil)
+	ok := &http.Response{StatusCode: http.StatusOK}
+	unavailable := &http.Response{StatusCode: http.StatusServiceUnavailable}
+
+	var transitions []CircuitState
+	cb := NewCircuitBreaker(CircuitBreakerConfig{
+		FailureRatio: 0.5,
+		MinRequests:  4,
+		OpenTimeout:  20 * time.Millisecond,
+		OnStateChange: func(scope string, from, to CircuitState) {
+			transitions = append(transitions, to)
+		},
+	})
+
+	record := func(req *http.Request, res *http.Response) {
+		if err := cb.Allow(req); err != nil {
+			t.Fatalf("circuitBreaker.Allow() error = %v, want nil", err)
+		}
+		cb.Record(req, res, nil)
+	}
+
+	record(payments, ok)
+	record(payments, unavailable)
+	record(payments, ok)
+	record(payments, unavailable)
+
+	err := cb.Allow(payments)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("circuitBreaker.Allow() error = %v, want ErrCircuitOpen", err)
+	}
+	var openErr *CircuitOpenError
+	if !errors.As(err, &openErr) || openErr.Scope != "payments" {
+		t.Errorf("circuitBreaker.Allow() error = %#v, want scope payments", err)
+	}
+
+	if err := cb.Allow(methods); err != nil {
+		t.Errorf("circuitBreaker.Allow() on another scope error = %v, want nil", err)
+	}
+	cb.Record(methods, ok, nil)
+
+	time.Sleep(25 * time.Millisecond)
+	if err := cb.Allow(payments); err != nil {
+		t.Fatalf("circuitBreaker.Allow() after open timeout error = %v, want nil", err)
+	}
+	if err := cb.Allow(payments); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("circuitBreaker.Allow() beyond half-open trials error = %v, want ErrCircuitOpen", err)
+	}
+	cb.Record(payments, ok, nil)
+	if err := cb.Allow(payments); err != nil {
+		t.Errorf("circuitBreaker.Allow() after closing error = %v, want nil", err)
+	}
+
+	want := []CircuitState{CircuitOpen, CircuitHalfOpen, CircuitClosed}
+	if len(transitions) != len(want) {
+		t.Fatalf("transitions = %v, want %v", transitions, want)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Errorf("transitions = %v, want %v", transitions, want)
+			break
+		}
+	}
+}
+
+func TestEndpointScope(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "https://api.mercadopago.com/v1/payments", want: "payments"},
+		{url: "https://api.mercadopago.com/v1/payments/123", want: "payments"},
+		{url: "https://api.mercadopago.com/v1/payments/search?sort=id", want: "payments"},
+		{url: "https://api.mercadopago.com/v1/payment_methods", want: "payment_methods"},
+		{url: "https://api.mercadopago.com/users/me", want: "users"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, tt.url, nil)
+			if got := EndpointScope(req); got != tt.want {
+				t.Errorf("EndpointScope() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSendCircuitOpenWhileRetrying(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	rc := NewClientWithConfig(ClientConfig{
+		AccessToken:    "token",
+		CircuitBreaker: NewCircuitBreaker(CircuitBreakerConfig{MinRequests: 1, OpenTimeout: time.Minute}),
+	})
+
+	// the first attempt opens the circuit, which stops the retries.
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+"/v1/payments/1", nil)
+		_, err := rc.Send(req, WithRetryDelay(time.Millisecond))
+		var openErr *CircuitOpenError
+		if !errors.Is(err, ErrCircuitOpen) || !errors.As(err, &openErr) || openErr.Scope != "payments" {
+			t.Errorf("Send() #%d error = %#v, want *CircuitOpenError for payments", i+1, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("server calls = %d, want 1", calls)
+	}
+}
+
+func TestSendErrorUnwrap(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	rc := NewClientWithConfig(ClientConfig{AccessToken: "token"})
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/v1/payments/1", nil)
+	_, err := rc.Send(req, WithTimeout(10*time.Millisecond), WithMaxRetries(1))
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Send() error = %#v, want *ErrorResponse wrapping %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestSendRetryTimeoutWhileHalfOpen(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	cb := NewCircuitBreaker(CircuitBreakerConfig{MinRequests: 10, HalfOpenMaxRequests: 1}).(*circuitBreaker)
+	rc := NewClientWithConfig(ClientConfig{AccessToken: "token", CircuitBreaker: cb})
+
+	done := make(chan error)
+	go func() {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+"/v1/payments/1", nil)
+		_, err := rc.Send(req, WithRetryDelay(time.Second), WithTimeout(100*time.Millisecond))
+		done <- err
+	}()
+
+	// once the first attempt is recorded, the circuit becomes half-open while the retry waits for its delay,
+	// which times out.
+	var ci *circuit
+	for ci == nil {
+		time.Sleep(time.Millisecond)
+		cb.mu.Lock()
+		if c := cb.circuits["payments"]; c != nil && c.failures == 1 {
+			ci = c
+			cb.transition("payments", ci, CircuitHalfOpen, time.Now())
+		}
+		cb.mu.Unlock()
+	}
+	if err := <-done; !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Send() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// the timeout neither reopened the circuit nor took its probe slot.
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/v1/payments/1", nil)
+	cb.mu.Lock()
+	state := ci.state
+	cb.mu.Unlock()
+	if state != CircuitHalfOpen {
+		t.Errorf("circuit state = %v, want %v", state, CircuitHalfOpen)
+	}
+	if err := cb.Allow(req); err != nil {
+		t.Errorf("circuitBreaker.Allow() error = %v, want the probe slot", err)
+	}
+}
@@ -2,11 +2,14 @@ package rest
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net/http"
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/gdeandradero/sdk-go/pkg/mp/idempotency"
 )
 
 const (
@@ -21,6 +24,7 @@ var (
 	idempotencyHeader   = http.CanonicalHeaderKey("x-idempotency-key")
 )
 
+// c is the default client, created by NewClient and configured by the Set functions.
 var c *client
 
 // Client is the interface that wraps the basic Send method.
@@ -36,20 +40,85 @@ type client struct {
 	accessToken string
 	productID   string
 
-	httpClient  *http.Client
-	retryClient RetryClient
+	httpClient     *http.Client
+	retryClient    RetryClient
+	tracer         Tracer
+	meter          Meter
+	rateLimiter    RateLimiter
+	circuitBreaker CircuitBreaker
+
+	idempotencyStore idempotency.Store
+	strictDecoding   bool
 }
 
+// ClientConfig configures a client created with NewClientWithConfig. Zero values take the defaults.
+type ClientConfig struct {
+	AccessToken string
+
+	HTTPClient  *http.Client
+	RetryClient RetryClient
+	Tracer      Tracer
+	Meter       Meter
+
+	// RateLimiter limits the requests sent, see NewRateLimiter. By default, there is no limit: the requests
+	// with an access token are only held back after a 429 Too Many Requests response, until its Retry-After.
+	RateLimiter      RateLimiter
+	CircuitBreaker   CircuitBreaker
+	IdempotencyStore idempotency.Store
+
+	// StrictDecoding makes Do fail when a response has fields the SDK does not model, see WithStrictDecoding.
+	StrictDecoding bool
+}
+
+// NewClient returns a new client with the access token and makes it the default client,
+// the one configured by SetAT, SetHC and the other Set functions.
 func NewClient(at string) Client {
-	c = &client{
-		accessToken: at,
-		productID:   productID,
-		httpClient:  &http.Client{},
-		retryClient: &retryClient{},
-	}
+	c = newClient(ClientConfig{AccessToken: at})
 	return c
 }
 
+// NewClientWithConfig returns a new client configured by config.
+// Unlike NewClient, it does not change the default client, so many clients can be used side by side,
+// e.g. one per seller.
+func NewClientWithConfig(config ClientConfig) Client {
+	return newClient(config)
+}
+
+func newClient(config ClientConfig) *client {
+	cl := &client{
+		accessToken:      config.AccessToken,
+		productID:        productID,
+		httpClient:       config.HTTPClient,
+		retryClient:      config.RetryClient,
+		tracer:           config.Tracer,
+		meter:            config.Meter,
+		rateLimiter:      config.RateLimiter,
+		circuitBreaker:   config.CircuitBreaker,
+		idempotencyStore: config.IdempotencyStore,
+		strictDecoding:   config.StrictDecoding,
+	}
+	if cl.httpClient == nil {
+		cl.httpClient = &http.Client{}
+	}
+	if cl.retryClient == nil {
+		cl.retryClient = &retryClient{}
+	}
+	if cl.tracer == nil {
+		cl.tracer = noopTracer{}
+	}
+	if cl.meter == nil {
+		cl.meter = noopMeter{}
+	}
+	if cl.rateLimiter == nil {
+		// a limiter without budgets, which only applies the backoff of the 429 responses.
+		cl.rateLimiter = NewRateLimiter(RateLimiterConfig{})
+	}
+	if cl.circuitBreaker == nil {
+		cl.circuitBreaker = noopCircuitBreaker{}
+	}
+	return cl
+}
+
 func SetAT(at string) {
 	c.accessToken = at
 }
@@ -62,17 +131,99 @@ func SetRC(rc RetryClient) {
 	c.retryClient = rc
 }
 
+func SetTracer(t Tracer) {
+	c.tracer = t
+}
+
+func SetMeter(m Meter) {
+	c.meter = m
+}
+
+func SetRateLimiter(rl RateLimiter) {
+	c.rateLimiter = rl
+}
+
+func SetCircuitBreaker(cb CircuitBreaker) {
+	c.circuitBreaker = cb
+}
+
+func SetIdempotencyStore(s idempotency.Store) {
+	c.idempotencyStore = s
+}
+
+// SetStrictDecoding sets the StrictDecoding of the default client.
+func SetStrictDecoding(strict bool) {
+	c.strictDecoding = strict
+}
+
+// strict reports whether Do decodes the responses of the client strictly.
+func (cl *client) strict() bool {
+	return cl.strictDecoding
+}
+
 func (cl *client) Send(req *http.Request, opts ...Option) ([]byte, error) {
-	cl.prepareRequest(req, opts...)
+	options := &options{}
+	for _, opt := range opts {
+		opt.apply(options)
+	}
+
+	req, cancel := cl.prepareRequest(req, options)
+	defer cancel()
+
+	var (
+		res      *http.Response
+		response []byte
+		err      error
+	)
+	if options.idempotencyKey != "" && cl.idempotencyStore != nil {
+		res, response, err = cl.sendIdempotent(req, opts...)
+	} else {
+		res, response, err = cl.send(req, opts...)
+	}
+	obs := observationFromContext(req.Context())
+	if options.responseInfo != nil {
+		*options.responseInfo = newResponseInfo(req, res, response, obs)
+	}
+	if err != nil {
+		// the body of an error response is in its message.
+		response = nil
+	}
+	obs.finish(req.Context(), res, response, err)
 
-	res, err := c.httpClient.Do(req)
+	return response, err
+}
+
+func (cl *client) send(req *http.Request, opts ...Option) (*http.Response, []byte, error) {
+	if err := cl.rateLimiter.Wait(req.Context(), accessTokenOf(req)); err != nil {
+		return nil, nil, err
+	}
+	if err := cl.circuitBreaker.Allow(req); err != nil {
+		return nil, nil, err
+	}
+
+	observationFromContext(req.Context()).sent()
+	res, err := cl.httpClient.Do(req)
+	cl.circuitBreaker.Record(req, res, err)
+	rateLimited(cl.rateLimiter, req, res)
 	if shouldRetry(res, err) {
-		res, err = c.retryClient.Retry(req, c.httpClient, opts...)
+		if res != nil {
+			res.Body.Close()
+		}
+		res, err = cl.retryClient.Retry(req, cl.httpClient, opts...)
 	}
 	if err != nil {
-		return nil, &ErrorResponse{
-			StatusCode: res.StatusCode,
+		// an open circuit or a spent budget while retrying is reported like before the first attempt.
+		if errors.Is(err, ErrCircuitOpen) || errors.Is(err, ErrRateLimited) {
+			return nil, nil, err
+		}
+		statusCode := http.StatusInternalServerError
+		if res != nil {
+			statusCode = res.StatusCode
+		}
+		return res, nil, &ErrorResponse{
+			StatusCode: statusCode,
 			Message:    "error sending request: " + err.Error(),
+			err:        err,
 		}
 	}
 
@@ -80,7 +231,7 @@ func (cl *client) Send(req *http.Request, opts ...Option) ([]byte, error) {
 
 	response, err := io.ReadAll(res.Body)
 	if err != nil {
-		return nil, &ErrorResponse{
+		return res, nil, &ErrorResponse{
 			StatusCode: res.StatusCode,
 			Message:    "error reading response body: " + err.Error(),
 			Headers:    res.Header,
@@ -88,28 +239,35 @@ func (cl *client) Send(req *http.Request, opts ...Option) ([]byte, error) {
 	}
 
 	if res.StatusCode < 200 || res.StatusCode > 299 {
-		return nil, &ErrorResponse{
+		return res, response, &ErrorResponse{
 			StatusCode: res.StatusCode,
 			Message:    string(response),
 			Headers:    res.Header,
 		}
 	}
 
-	return response, nil
+	return res, response, nil
 }
 
-func (cl *client) prepareRequest(req *http.Request, opts ...Option) {
+// prepareRequest returns a copy of req carrying the timeout, the telemetry and the headers of the call.
+// The returned cancel function must be called once the response body has been read.
+func (cl *client) prepareRequest(req *http.Request, options *options) (*http.Request, context.CancelFunc) {
 	timeout := defaultTimeout
-
-	options := &options{}
-	for _, opt := range opts {
-		opt.apply(options)
-	}
 	if options.timeout > 0 {
 		timeout = options.timeout
 	}
-	ctx, cancel := context.WithTimeout(req.Context(), timeout)
-	defer cancel()
+	operation := options.operation
+	if operation == "" {
+		operation = req.Method + " " + req.URL.Path
+	}
+
+	parent := req.Context()
+	if options.ctx != nil {
+		parent = options.ctx
+	}
+	ctx, cancel := context.WithTimeout(parent, timeout)
+	ctx = context.WithValue(ctx, clientKey{}, cl)
+	ctx, _ = startObservation(ctx, cl, req, operation)
 	req = req.WithContext(ctx)
 	if options.customHeaders != nil {
 		for k, v := range options.customHeaders {
@@ -117,12 +275,21 @@ func (cl *client) prepareRequest(req *http.Request, opts ...Option) {
 			req.Header[canonicalKey] = v
 		}
 	}
-	setDefaultHeaders(req)
+	if options.idempotencyKey != "" {
+		req.Header.Set(idempotencyHeader, idempotency.DeriveKey(operation, options.idempotencyKey))
+	}
+	cl.setDefaultHeaders(req, options)
+
+	return req, cancel
 }
 
-func setDefaultHeaders(req *http.Request) {
-	req.Header.Add(authorizationHeader, "Bearer "+c.accessToken)
-	req.Header.Add(productIDHeader, c.productID)
+func (cl *client) setDefaultHeaders(req *http.Request, options *options) {
+	accessToken := cl.accessToken
+	if options.accessToken != "" {
+		accessToken = options.accessToken
+	}
+	req.Header.Set(authorizationHeader, "Bearer "+accessToken)
+	req.Header.Add(productIDHeader, cl.productID)
 
 	if _, ok := req.Header[idempotencyHeader]; !ok {
 		req.Header.Add(idempotencyHeader, uuid.New().String())
@@ -130,5 +297,16 @@ func setDefaultHeaders(req *http.Request) {
 }
 
 func shouldRetry(res *http.Response, err error) bool {
-	return err != nil || res.StatusCode >= http.StatusInternalServerError
+	return err != nil || res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= http.StatusInternalServerError
+}
+
+type clientKey struct{}
+
+// clientFromContext returns the client sending the request, so that the retry client
+// uses its circuit breaker and rate limiter. It falls back to the default client.
+func clientFromContext(ctx context.Context) *client {
+	if cl, ok := ctx.Value(clientKey{}).(*client); ok {
+		return cl
+	}
+	return c
 }
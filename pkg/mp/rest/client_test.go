@@ -0,0 +1,107 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewClientWithConfig(t *testing.T) {
+	var tokens []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	def := NewClient("default-token")
+	seller := NewClientWithConfig(ClientConfig{AccessToken: "seller-token"})
+	// changing the default client must not change the others.
+	SetAT("rotated-token")
+
+	for _, rc := range []Client{def, seller} {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+"/v1/payments/1", nil)
+		if _, err := rc.Send(req); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/v1/payments/1", nil)
+	if _, err := seller.Send(req, WithAccessToken("other-token")); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	want := []string{"Bearer rotated-token", "Bearer seller-token", "Bearer other-token"}
+	for i := range want {
+		if i >= len(tokens) || tokens[i] != want[i] {
+			t.Fatalf("Authorization headers = %v, want %v", tokens, want)
+		}
+	}
+}
+
+func TestSendWithContext(t *testing.T) {
+	rc := NewClientWithConfig(ClientConfig{
+		AccessToken: "token",
+		RateLimiter: NewRateLimiter(RateLimiterConfig{PerToken: RateLimit{Rate: 0.001, Burst: 1}}),
+	})
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	if _, err := rc.Send(req); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	// the budget is spent, so the next request waits for the rate limiter until ctx is cancelled.
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	req, _ = http.NewRequest(http.MethodGet, srv.URL, nil)
+	start := time.Now()
+	_, err := rc.Send(req, WithContext(ctx))
+	var errResponse *ErrorResponse
+	if !errors.Is(err, context.Canceled) || errors.As(err, &errResponse) {
+		t.Errorf("Send() error = %v, want %v from the rate limiter", err, context.Canceled)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("Send() returned after %v, want it to wait until ctx is cancelled", elapsed)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("server received %d requests, want 1", got)
+	}
+}
+
+func TestSendWithResponseCapture(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("X-Request-Id", fmt.Sprintf("req-%d", calls))
+		if calls == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message":"invalid amount"}`))
+	}))
+	defer srv.Close()
+
+	rc := NewClientWithConfig(ClientConfig{AccessToken: "token"})
+	req, _ := http.NewRequest(http.MethodPost, srv.URL, nil)
+	var info ResponseInfo
+	res, err := rc.Send(req, WithResponseCapture(&info), WithRetryDelay(time.Millisecond), WithIdempotencyKey("order-1"))
+	if err == nil || res != nil {
+		t.Fatalf("Send() = %s, %v, want an error", res, err)
+	}
+
+	if info.StatusCode != http.StatusBadRequest || info.RequestID != "req-2" || info.Attempts != 2 ||
+		string(info.Body) != `{"message":"invalid amount"}` || info.IdempotencyKey == "" || info.Latency <= 0 {
+		t.Errorf("Send() captured %+v", info)
+	}
+}
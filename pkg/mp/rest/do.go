@@ -0,0 +1,161 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// NoBody is the Req of Do for the requests without a body, and its Res for the responses whose body is ignored.
+type NoBody struct{}
+
+// Do sends a request to a JSON endpoint, encoding body, if not nil, as JSON, and decodes the JSON response
+// into a new Res. pathTemplate is the URL of the endpoint, with its path parameters in braces,
+// e.g. "https://api.mercadopago.com/v1/payments/{id}": they are replaced by the escaped values of pathParams.
+// query, if not empty, is the query string. ctx is the context of the request, so the call is cancelled when ctx
+// is done; like for Send, WithContext replaces it. The response is decoded with a json.Decoder: Client.Send
+// returns the whole body, so the decoder reads it from memory rather than from the connection.
+// With strict decoding, see WithStrictDecoding, a response with fields the SDK does not model fails with
+// an *UnknownFieldError.
+// The errors creating the request are *ErrorResponse with status 500, like the errors of Send.
+//
+// It is the body of the methods of the resource clients:
+//
+//	func (c *client) Get(id int64, opts ...rest.Option) (*Response, error) {
+//		return rest.Do[rest.NoBody, Response](context.Background(), c.rc, http.MethodGet, getURL,
+//			map[string]string{"id": strconv.FormatInt(id, 10)}, nil, nil,
+//			append([]rest.Option{rest.WithOperation("payment.Get")}, opts...)...)
+//	}
+//
+// Endpoints that do not send or return JSON, such as file uploads and downloads, build their request
+// and call Client.Send instead.
+func Do[Req, Res any](ctx context.Context, c Client, method, pathTemplate string, pathParams map[string]string,
+	query url.Values, body *Req, opts ...Option) (*Res, error) {
+	u, err := expandPath(pathTemplate, pathParams)
+	if err != nil {
+		return nil, &ErrorResponse{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "error creating request: " + err.Error(),
+		}
+	}
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, &ErrorResponse{
+				StatusCode: http.StatusInternalServerError,
+				Message:    "error marshaling request body: " + err.Error(),
+			}
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return nil, &ErrorResponse{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "error creating request: " + err.Error(),
+		}
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.Send(req, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	formatted := new(Res)
+	if _, ok := any(formatted).(*NoBody); ok {
+		return formatted, nil
+	}
+	if err := decode(bytes.NewReader(res), formatted); err != nil {
+		return nil, err
+	}
+	if strictDecoding(c, opts) {
+		if err := checkExtra(reflect.ValueOf(formatted)); err != nil {
+			return nil, err
+		}
+	}
+	return formatted, nil
+}
+
+// decode decodes the JSON value read from r into v, which must be the only value of r.
+func decode(r io.Reader, v any) error {
+	dec := json.NewDecoder(r)
+	if err := dec.Decode(v); err != nil {
+		if errors.Is(err, io.EOF) {
+			return errors.New("unexpected end of JSON input")
+		}
+		return err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return errors.New("invalid data after top-level value")
+	}
+	return nil
+}
+
+// strictDecoding reports whether Do decodes the response strictly: as set with WithStrictDecoding,
+// or else as configured for c, if it is a client created by this package.
+func strictDecoding(c Client, opts []Option) bool {
+	options := &options{}
+	for _, opt := range opts {
+		opt.apply(options)
+	}
+	if options.strictDecoding != nil {
+		return *options.strictDecoding
+	}
+	if sc, ok := c.(interface{ strict() bool }); ok {
+		return sc.strict()
+	}
+	return false
+}
+
+// expandPath replaces the path parameters of a template by their escaped values.
+// Every parameter of the template must have a value, and every value a parameter.
+func expandPath(template string, params map[string]string) (string, error) {
+	var b strings.Builder
+	used := map[string]bool{}
+	for {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated path parameter in %q", template)
+		}
+		name := template[start+1 : start+end]
+		value, ok := params[name]
+		if !ok {
+			return "", fmt.Errorf("missing path parameter %q", name)
+		}
+		b.WriteString(template[:start])
+		b.WriteString(url.PathEscape(value))
+		template = template[start+end+1:]
+		used[name] = true
+	}
+	b.WriteString(template)
+
+	for name := range params {
+		if !used[name] {
+			return "", fmt.Errorf("unknown path parameter %q", name)
+		}
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,130 @@
+package rest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+type doRequest struct {
+	Name string `json:"name"`
+}
+
+type doResponse struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestDo(t *testing.T) {
+	type want struct {
+		url         string
+		body        string
+		contentType string
+	}
+	tests := []struct {
+		name     string
+		call     func(c Client) (any, error)
+		response string
+		want     want
+		wantRes  any
+		wantErr  string
+	}{
+		{
+			name: "should_send_json_body_and_decode_response",
+			call: func(c Client) (any, error) {
+				return Do[doRequest, doResponse](context.Background(), c, http.MethodPut, "https://api.mercadopago.com/v1/items/{id}",
+					map[string]string{"id": "a/b c"}, url.Values{"q": {"x y"}}, &doRequest{Name: "item"})
+			},
+			response: `{"id":1,"name":"item"}`,
+			want: want{
+				url:         "https://api.mercadopago.com/v1/items/a%2Fb%20c?q=x+y",
+				body:        `{"name":"item"}`,
+				contentType: "application/json",
+			},
+			wantRes: &doResponse{ID: 1, Name: "item"},
+		},
+		{
+			name: "should_decode_array",
+			call: func(c Client) (any, error) {
+				return Do[NoBody, []doResponse](context.Background(), c, http.MethodGet, "https://api.mercadopago.com/v1/items", nil, nil, nil)
+			},
+			response: `[{"id":1},{"id":2}]`,
+			want:     want{url: "https://api.mercadopago.com/v1/items"},
+			wantRes:  &[]doResponse{{ID: 1}, {ID: 2}},
+		},
+		{
+			name: "should_ignore_response_body",
+			call: func(c Client) (any, error) {
+				return Do[NoBody, NoBody](context.Background(), c, http.MethodDelete, "https://api.mercadopago.com/v1/items", nil, nil, nil)
+			},
+			want:    want{url: "https://api.mercadopago.com/v1/items"},
+			wantRes: &NoBody{},
+		},
+		{
+			name: "should_fail_on_missing_path_param",
+			call: func(c Client) (any, error) {
+				return Do[NoBody, doResponse](context.Background(), c, http.MethodGet, "https://api.mercadopago.com/v1/items/{id}", nil, nil, nil)
+			},
+			wantErr: `error creating request: missing path parameter "id"`,
+		},
+		{
+			name: "should_fail_on_unknown_path_param",
+			call: func(c Client) (any, error) {
+				return Do[NoBody, doResponse](context.Background(), c, http.MethodGet, "https://api.mercadopago.com/v1/items",
+					map[string]string{"id": "1"}, nil, nil)
+			},
+			wantErr: `error creating request: unknown path parameter "id"`,
+		},
+		{
+			name: "should_fail_on_empty_response",
+			call: func(c Client) (any, error) {
+				return Do[NoBody, doResponse](context.Background(), c, http.MethodGet, "https://api.mercadopago.com/v1/items", nil, nil, nil)
+			},
+			want:    want{url: "https://api.mercadopago.com/v1/items"},
+			wantErr: "unexpected end of JSON input",
+		},
+		{
+			name: "should_fail_on_trailing_data",
+			call: func(c Client) (any, error) {
+				return Do[NoBody, doResponse](context.Background(), c, http.MethodGet, "https://api.mercadopago.com/v1/items", nil, nil, nil)
+			},
+			response: `{"id":1} {"id":2}`,
+			want:     want{url: "https://api.mercadopago.com/v1/items"},
+			wantErr:  "invalid data after top-level value",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got want
+			c := &Mock{
+				SendMock: func(req *http.Request, opts ...Option) ([]byte, error) {
+					got.url = req.URL.String()
+					got.contentType = req.Header.Get("Content-Type")
+					if req.Body != nil {
+						b, _ := io.ReadAll(req.Body)
+						got.body = string(b)
+					}
+					return []byte(tt.response), nil
+				},
+			}
+
+			res, err := tt.call(c)
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if gotErr != tt.wantErr {
+				t.Fatalf("Do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Do() request = %+v, want %+v", got, tt.want)
+			}
+			if err == nil && !reflect.DeepEqual(res, tt.wantRes) {
+				t.Errorf("Do() = %+v, want %+v", res, tt.wantRes)
+			}
+		})
+	}
+}
@@ -8,9 +8,17 @@ type ErrorResponse struct {
 	StatusCode int    `json:"status_code"`
 
 	Headers http.Header `json:"headers"`
+
+	// err is the error the response was made from, if any, e.g. the error of the HTTP client.
+	err error
 }
 
 // Error implements error.
 func (e *ErrorResponse) Error() string {
 	return e.Message
 }
+
+// Unwrap returns the error the response was made from, e.g. context.DeadlineExceeded, or nil.
+func (e *ErrorResponse) Unwrap() error {
+	return e.err
+}
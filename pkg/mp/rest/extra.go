@@ -0,0 +1,246 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownField is matched, with errors.Is, by the errors of strict decoding.
+var ErrUnknownField = errors.New("unknown field")
+
+// UnknownFieldError is the error of strict decoding.
+type UnknownFieldError struct {
+	Type   string
+	Fields []string
+}
+
+// Error implements error.
+func (e *UnknownFieldError) Error() string {
+	return fmt.Sprintf("unknown field(s) %s in %s", strings.Join(e.Fields, ", "), e.Type)
+}
+
+// Is reports whether target is ErrUnknownField.
+func (e *UnknownFieldError) Is(target error) bool {
+	return target == ErrUnknownField
+}
+
+// structFields are the fields of a struct type decoded from JSON, by JSON name.
+type structFields struct {
+	exact  map[string][]int
+	folded map[string][]int
+}
+
+// lookup returns the index of the field of the JSON name. Like encoding/json, it prefers an exact match
+// but also accepts a case-insensitive one.
+func (f *structFields) lookup(name string) ([]int, bool) {
+	if index, ok := f.exact[name]; ok {
+		return index, true
+	}
+	index, ok := f.folded[strings.ToLower(name)]
+	return index, ok
+}
+
+// knownFields caches the fields of each struct type.
+var knownFields sync.Map
+
+// fieldsOf returns the fields of the struct type t, including the fields promoted from its embedded structs.
+func fieldsOf(t reflect.Type) *structFields {
+	if fields, ok := knownFields.Load(t); ok {
+		return fields.(*structFields)
+	}
+
+	fields := &structFields{exact: map[string][]int{}, folded: map[string][]int{}}
+	for _, f := range reflect.VisibleFields(t) {
+		tag := f.Tag.Get("json")
+		if !f.IsExported() || tag == "-" || f.Anonymous && tag == "" && isStruct(f.Type) {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = f.Name
+		}
+		if index, ok := fields.exact[name]; !ok || len(f.Index) < len(index) {
+			fields.exact[name] = f.Index
+		}
+		if index, ok := fields.folded[strings.ToLower(name)]; !ok || len(f.Index) < len(index) {
+			fields.folded[strings.ToLower(name)] = f.Index
+		}
+	}
+	knownFields.Store(t, fields)
+	return fields
+}
+
+// isStruct reports whether t is a struct or a pointer to a struct, whose fields are promoted when it is embedded.
+func isStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+// DecodeExtra decodes data, a JSON object, into v, a pointer to a struct, and the fields v does not have into
+// extra, which is reset first. The object is split into its fields once, and each field is decoded into the field
+// of v of the same name, so that the nested structs are decoded once too.
+// Response structs call it from UnmarshalJSON, with v of a type without the method to avoid the recursion:
+//
+//	func (r *Response) UnmarshalJSON(b []byte) error {
+//		type plain Response
+//		return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+//	}
+//
+// With strict decoding, set with ClientConfig.StrictDecoding or WithStrictDecoding, Do fails with
+// an *UnknownFieldError instead when a decoded struct has extra fields.
+func DecodeExtra(data []byte, v any, extra *map[string]json.RawMessage) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return nil
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		// the error of encoding/json, e.g. when data is not an object.
+		return json.Unmarshal(data, v)
+	}
+
+	*extra = nil
+	s := reflect.ValueOf(v).Elem()
+	fields := fieldsOf(s.Type())
+	keys := make([]string, 0, len(all))
+	for k := range all {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	// like encoding/json, a field of the wrong type does not stop the decoding of the others.
+	var typeErr error
+	for _, k := range keys {
+		index, ok := fields.lookup(k)
+		if !ok {
+			if *extra == nil {
+				*extra = map[string]json.RawMessage{}
+			}
+			(*extra)[k] = all[k]
+			continue
+		}
+		f, err := s.FieldByIndexErr(index)
+		if err != nil {
+			// a field promoted from a nil embedded pointer.
+			f = allocField(s, index)
+		}
+		err = json.Unmarshal(all[k], f.Addr().Interface())
+		var ute *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &ute):
+			ute.Struct = s.Type().Name()
+			if ute.Field == "" {
+				ute.Field = k
+			} else {
+				ute.Field = k + "." + ute.Field
+			}
+			if typeErr == nil {
+				typeErr = ute
+			}
+		case err != nil:
+			return err
+		}
+	}
+	return typeErr
+}
+
+// allocField returns the field of s at index, allocating the embedded pointers on the way.
+func allocField(s reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && s.Kind() == reflect.Pointer {
+			if s.IsNil() {
+				s.Set(reflect.New(s.Type().Elem()))
+			}
+			s = s.Elem()
+		}
+		s = s.Field(x)
+	}
+	return s
+}
+
+var extraType = reflect.TypeFor[map[string]json.RawMessage]()
+
+// checkExtra returns an *UnknownFieldError for the first struct of v whose Extra field holds unknown fields,
+// the outer structs first, or nil.
+func checkExtra(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			return checkExtra(v.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			if err := checkExtra(v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			if err := checkExtra(iter.Value()); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		t := v.Type()
+		if f, ok := t.FieldByName("Extra"); ok && len(f.Index) == 1 && f.Type == extraType {
+			if extra := v.FieldByIndex(f.Index); extra.Len() > 0 {
+				unknown := make([]string, 0, extra.Len())
+				for _, k := range extra.MapKeys() {
+					unknown = append(unknown, k.String())
+				}
+				sort.Strings(unknown)
+				return &UnknownFieldError{Type: t.String(), Fields: unknown}
+			}
+		}
+		for i := range t.NumField() {
+			if f := t.Field(i); f.IsExported() && f.Type != extraType {
+				if err := checkExtra(v.Field(i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// EncodeExtra encodes v, a struct, followed by the fields of extra it does not have,
+// so that a decoded response is encoded with its unknown fields. See DecodeExtra.
+func EncodeExtra(v any, extra map[string]json.RawMessage) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return b, err
+	}
+
+	t := reflect.TypeOf(v)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	fields := fieldsOf(t)
+	keys := make([]string, 0, len(extra))
+	for k := range extra {
+		if _, ok := fields.lookup(k); !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	buf := bytes.NewBuffer(b[:len(b)-1])
+	for _, k := range keys {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(k)
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(extra[k])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,123 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type extraResponse struct {
+	ID     int64  `json:"id"`
+	Status string `json:"status,omitempty"`
+	Ignore string `json:"-"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r *extraResponse) UnmarshalJSON(b []byte) error {
+	type plain extraResponse
+	return DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r extraResponse) MarshalJSON() ([]byte, error) {
+	type plain extraResponse
+	return EncodeExtra(plain(r), r.Extra)
+}
+
+func TestDecodeExtra(t *testing.T) {
+	data := `{"ID":1,"status":"approved","new_field":{"a":[1,2]},"Ignore":"x"}`
+
+	var r extraResponse
+	if err := json.Unmarshal([]byte(data), &r); err != nil {
+		t.Fatalf("DecodeExtra() error = %v", err)
+	}
+	if r.ID != 1 || r.Status != "approved" || len(r.Extra) != 2 ||
+		string(r.Extra["new_field"]) != `{"a":[1,2]}` || string(r.Extra["Ignore"]) != `"x"` {
+		t.Errorf("DecodeExtra() = %+v", r)
+	}
+
+	b, err := json.Marshal(r)
+	if want := `{"id":1,"status":"approved","Ignore":"x","new_field":{"a":[1,2]}}`; err != nil || string(b) != want {
+		t.Errorf("EncodeExtra() = %s, %v, want %s", b, err, want)
+	}
+
+	var known extraResponse
+	if err := json.Unmarshal([]byte(`{"id":2}`), &known); err != nil || known.Extra != nil {
+		t.Errorf("DecodeExtra() = %+v, %v, want no extra fields", known, err)
+	}
+
+	// decoding again forgets the fields of the previous response.
+	if err := json.Unmarshal([]byte(`{"id":3,"other":1}`), &r); err != nil || r.ID != 3 || len(r.Extra) != 1 || r.Extra["other"] == nil {
+		t.Errorf("DecodeExtra() again = %+v, %v, want only the other field", r, err)
+	}
+
+	var typed extraResponse
+	err = json.Unmarshal([]byte(`{"id":"x","status":"approved"}`), &typed)
+	if want := "json: cannot unmarshal string into Go struct field plain.id of type int64"; err == nil || err.Error() != want ||
+		typed.Status != "approved" {
+		t.Errorf("DecodeExtra() = %+v, %v, want %s", typed, err, want)
+	}
+}
+
+type extraNested struct {
+	Items []extraResponse `json:"items"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (r *extraNested) UnmarshalJSON(b []byte) error {
+	type plain extraNested
+	return DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func TestDoStrictDecoding(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"items":[{"id":1},{"id":2,"new_field":1,"Ignore":"x"}]}`))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		strict  bool
+		opts    []Option
+		wantErr string
+	}{
+		{
+			name: "should_keep_unknown_fields",
+		},
+		{
+			name:    "should_fail_with_strict_option",
+			opts:    []Option{WithStrictDecoding(true)},
+			wantErr: "unknown field(s) Ignore, new_field in rest.extraResponse",
+		},
+		{
+			name:    "should_fail_with_strict_client",
+			strict:  true,
+			wantErr: "unknown field(s) Ignore, new_field in rest.extraResponse",
+		},
+		{
+			name:   "should_not_fail_when_option_overrides_strict_client",
+			strict: true,
+			opts:   []Option{WithStrictDecoding(false)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClientWithConfig(ClientConfig{AccessToken: "token", StrictDecoding: tt.strict})
+			got, err := Do[NoBody, extraNested](context.Background(), c, http.MethodGet, srv.URL, nil, nil, nil, tt.opts...)
+			gotErr := ""
+			if err != nil {
+				gotErr = err.Error()
+			}
+			if gotErr != tt.wantErr || (err != nil) != errors.Is(err, ErrUnknownField) {
+				t.Fatalf("Do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && string(got.Items[1].Extra["new_field"]) != "1" {
+				t.Errorf("Do() = %+v, want the unknown fields in Extra", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package rest_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/gdeandradero/sdk-go/pkg/chargeback"
+	"github.com/gdeandradero/sdk-go/pkg/customer"
+	"github.com/gdeandradero/sdk-go/pkg/payment"
+	"github.com/gdeandradero/sdk-go/pkg/paymentmethod"
+	"github.com/gdeandradero/sdk-go/pkg/report"
+)
+
+var (
+	extraType       = reflect.TypeOf(map[string]json.RawMessage(nil))
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	marshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// TestResponseTypesDecodeExtra checks that every struct reachable from the responses with an Extra field has
+// the JSON methods filling it, as a struct without them would silently drop the fields the SDK does not model.
+func TestResponseTypesDecodeExtra(t *testing.T) {
+	roots := []any{
+		chargeback.Response{},
+		chargeback.SearchResponse{},
+		customer.Response{},
+		customer.SearchResponse{},
+		payment.Response{},
+		payment.RefundResponse{},
+		payment.SearchResponse{},
+		paymentmethod.Response{},
+		report.Response{},
+	}
+	seen := map[reflect.Type]bool{}
+	var walk func(typ reflect.Type)
+	walk = func(typ reflect.Type) {
+		for typ.Kind() == reflect.Pointer || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array || typ.Kind() == reflect.Map {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct || seen[typ] {
+			return
+		}
+		seen[typ] = true
+		if f, ok := typ.FieldByName("Extra"); ok && f.Type == extraType {
+			if !reflect.PointerTo(typ).Implements(unmarshalerType) || !typ.Implements(marshalerType) {
+				t.Errorf("%s has an Extra field but no UnmarshalJSON and MarshalJSON methods, run go generate", typ)
+			}
+		}
+		for i := 0; i < typ.NumField(); i++ {
+			walk(typ.Field(i).Type)
+		}
+	}
+	for _, root := range roots {
+		walk(reflect.TypeOf(root))
+	}
+}
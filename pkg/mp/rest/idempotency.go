@@ -0,0 +1,149 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/mp/idempotency"
+)
+
+// idempotencyLocks serializes the calls of the process sharing an idempotency key, across clients.
+var idempotencyLocks keyLocks
+
+// sendIdempotent sends a request with an idempotency key derived from a business key.
+// A completed request is replayed from the idempotency store, and reusing the key with
+// a different request fails with idempotency.ErrKeyReused. Records are kept per access token,
+// so sellers sharing a store never replay each other's responses.
+func (cl *client) sendIdempotent(req *http.Request, opts ...Option) (*http.Response, []byte, error) {
+	ctx := req.Context()
+	store := cl.idempotencyStore
+	token := accessTokenOf(req)
+	key := idempotency.TenantKey(token, req.Header.Get(idempotencyHeader))
+
+	// a concurrent call with the same key waits for this one, and then replays its response.
+	unlock, err := idempotencyLocks.lock(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer unlock()
+
+	body, err := requestBody(req)
+	if err != nil {
+		return nil, nil, &ErrorResponse{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "error reading request body: " + err.Error(),
+		}
+	}
+	fingerprint := idempotency.Fingerprint(token, req.Method, req.URL.String(), body)
+
+	record := &idempotency.Record{Key: key, Fingerprint: fingerprint, CreatedAt: time.Now()}
+	existing, err := store.Create(ctx, record)
+	switch {
+	case err == nil:
+	case !errors.Is(err, idempotency.ErrExists):
+		return nil, nil, &ErrorResponse{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "error storing idempotency record: " + err.Error(),
+		}
+	case existing.Fingerprint != fingerprint:
+		return nil, nil, fmt.Errorf("%w: %s", idempotency.ErrKeyReused, req.Header.Get(idempotencyHeader))
+	case !existing.Pending():
+		observationFromContext(ctx).replayed(existing.StatusCode)
+		return nil, existing.Body, nil
+	default:
+		record = existing
+	}
+
+	// a pending record is sent again with the same key, so the API does not process it twice.
+	res, response, err := cl.send(req, opts...)
+	switch {
+	case err == nil:
+		record.StatusCode = res.StatusCode
+		record.Body = response
+		// the request already succeeded, so failing to store it is not reported:
+		// a replay will be sent again and deduplicated by the API.
+		_ = store.Put(ctx, record)
+	case res != nil && res.StatusCode < http.StatusInternalServerError && res.StatusCode != http.StatusTooManyRequests:
+		// the API rejected the request, so the key may be reused with a fixed request.
+		_ = store.Delete(ctx, key)
+	}
+
+	return res, response, err
+}
+
+// keyLocks is a set of mutexes, one per key in use. Its zero value is ready to use.
+type keyLocks struct {
+	mu    sync.Mutex
+	locks map[string]*keyLock
+}
+
+type keyLock struct {
+	sem  chan struct{}
+	refs int
+}
+
+// lock locks the mutex of key, waiting until it is unlocked or ctx is done. It returns the unlock function.
+func (l *keyLocks) lock(ctx context.Context, key string) (func(), error) {
+	l.mu.Lock()
+	if l.locks == nil {
+		l.locks = map[string]*keyLock{}
+	}
+	kl, ok := l.locks[key]
+	if !ok {
+		kl = &keyLock{sem: make(chan struct{}, 1)}
+		l.locks[key] = kl
+	}
+	kl.refs++
+	l.mu.Unlock()
+
+	release := func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if kl.refs--; kl.refs == 0 {
+			delete(l.locks, key)
+		}
+	}
+	select {
+	case kl.sem <- struct{}{}:
+		return func() {
+			<-kl.sem
+			release()
+		}, nil
+	case <-ctx.Done():
+		release()
+		return nil, ctx.Err()
+	}
+}
+
+// requestBody returns the body of req, leaving it readable for sending.
+func requestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	if req.GetBody != nil {
+		rc, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return io.ReadAll(rc)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return body, nil
+}
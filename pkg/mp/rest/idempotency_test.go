@@ -0,0 +1,101 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/mp/idempotency"
+)
+
+func TestSendIdempotent(t *testing.T) {
+	var keys []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("X-Idempotency-Key"))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":1}`))
+	}))
+	defer srv.Close()
+
+	rc := NewClient("token")
+	SetIdempotencyStore(idempotency.NewMemoryStore(0))
+
+	send := func(body string) ([]byte, error) {
+		req, _ := http.NewRequest(http.MethodPost, srv.URL+"/v1/payments", strings.NewReader(body))
+		return rc.Send(req, WithOperation("payment.Create"), WithIdempotencyKey("order-1"))
+	}
+
+	first, err := send(`{"transaction_amount":10}`)
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	replayed, err := send(`{"transaction_amount": 10}`)
+	if err != nil {
+		t.Fatalf("Send() replay error = %v", err)
+	}
+	if string(replayed) != string(first) {
+		t.Errorf("Send() replay = %s, want %s", replayed, first)
+	}
+	if len(keys) != 1 {
+		t.Fatalf("API got %d requests, want 1", len(keys))
+	}
+	if want := idempotency.DeriveKey("payment.Create", "order-1"); keys[0] != want {
+		t.Errorf("X-Idempotency-Key = %q, want %q", keys[0], want)
+	}
+
+	if _, err := send(`{"transaction_amount":20}`); !errors.Is(err, idempotency.ErrKeyReused) {
+		t.Errorf("Send() with a different body error = %v, want ErrKeyReused", err)
+	}
+}
+
+func TestSendIdempotentPerTenant(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get("Authorization")
+		mu.Lock()
+		calls[token]++
+		mu.Unlock()
+		// a slow API lets the concurrent calls overlap.
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"token":"` + token + `"}`))
+	}))
+	defer srv.Close()
+
+	rc := NewClientWithConfig(ClientConfig{AccessToken: "seller-1", IdempotencyStore: idempotency.NewMemoryStore(0)})
+	send := func(token string) ([]byte, error) {
+		req, _ := http.NewRequest(http.MethodPost, srv.URL+"/v1/payments", strings.NewReader(`{"transaction_amount":10}`))
+		return rc.Send(req, WithOperation("payment.Create"), WithIdempotencyKey("order-1"), WithAccessToken(token))
+	}
+
+	var wg sync.WaitGroup
+	responses := make([][]byte, 6)
+	for i := range responses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token := []string{"seller-1", "seller-2"}[i%2]
+			res, err := send(token)
+			if err != nil {
+				t.Errorf("Send() with %s error = %v", token, err)
+			}
+			responses[i] = res
+		}()
+	}
+	wg.Wait()
+
+	if want := map[string]int{"Bearer seller-1": 1, "Bearer seller-2": 1}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("API calls = %v, want %v", calls, want)
+	}
+	for i, res := range responses {
+		if want := `{"token":"Bearer ` + []string{"seller-1", "seller-2"}[i%2] + `"}`; string(res) != want {
+			t.Errorf("Send() #%d = %s, want %s", i, res, want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"context"
 	"net/http"
 	"time"
 )
@@ -12,6 +13,15 @@ type options struct {
 	retryDelay    time.Duration
 	timeout       time.Duration
 	customHeaders http.Header
+	operation     string
+
+	idempotencyKey string
+	accessToken    string
+	ctx            context.Context
+	responseInfo   *ResponseInfo
+	strictDecoding *bool
+
+	values map[any]any
 }
 
 type Option interface {
@@ -67,3 +77,131 @@ func (c customHeadersOption) apply(opts *options) {
 func WithCustomHeaders(h http.Header) Option {
 	return customHeadersOption(h)
 }
+
+type operationOption string
+
+func (o operationOption) apply(opts *options) {
+	opts.operation = string(o)
+}
+
+// WithOperation sets the name of the SDK operation (e.g. "payment.Create") used by telemetry.
+// Resource clients set it on every call, so callers usually do not need it.
+func WithOperation(name string) Option {
+	return operationOption(name)
+}
+
+type idempotencyKeyOption string
+
+func (i idempotencyKeyOption) apply(opts *options) {
+	opts.idempotencyKey = string(i)
+}
+
+// WithIdempotencyKey sets a business key, such as an order ID, from which the X-Idempotency-Key header is derived.
+// The same operation with the same business key is always sent with the same idempotency key.
+// If an idempotency store is set, a completed request is replayed from the store instead of being sent again.
+func WithIdempotencyKey(businessKey string) Option {
+	return idempotencyKeyOption(businessKey)
+}
+
+type accessTokenOption string
+
+func (a accessTokenOption) apply(opts *options) {
+	opts.accessToken = string(a)
+}
+
+// WithAccessToken sends the request with the access token instead of the one of the client,
+// e.g. to act on behalf of a seller with its OAuth token.
+func WithAccessToken(at string) Option {
+	return accessTokenOption(at)
+}
+
+type contextOption struct {
+	ctx context.Context
+}
+
+func (c contextOption) apply(opts *options) {
+	opts.ctx = c.ctx
+}
+
+// WithContext sends the request with ctx, so that it is cancelled, including the wait for the rate limiter,
+// when ctx is done. Resource clients create their requests without a context.
+func WithContext(ctx context.Context) Option {
+	return contextOption{ctx: ctx}
+}
+
+type responseCaptureOption struct {
+	info *ResponseInfo
+}
+
+func (r responseCaptureOption) apply(opts *options) {
+	opts.responseInfo = r.info
+}
+
+// WithResponseCapture fills in info with the metadata and the raw body of the response, whether the call
+// succeeded or not, e.g. to quote the request ID in a support ticket.
+func WithResponseCapture(info *ResponseInfo) Option {
+	return responseCaptureOption{info: info}
+}
+
+type strictDecodingOption bool
+
+func (s strictDecodingOption) apply(opts *options) {
+	strict := bool(s)
+	opts.strictDecoding = &strict
+}
+
+// WithStrictDecoding makes Do fail with an *UnknownFieldError, or not, when the response has fields the SDK
+// does not model, instead of keeping them in the Extra field of the response structs, whatever
+// ClientConfig.StrictDecoding is. It is meant for contract tests catching schema drift.
+func WithStrictDecoding(strict bool) Option {
+	return strictDecodingOption(strict)
+}
+
+type valueOption struct {
+	key, value any
+}
+
+func (v valueOption) apply(opts *options) {
+	if opts.values == nil {
+		opts.values = map[any]any{}
+	}
+	opts.values[v.key] = v.value
+}
+
+// WithValue sets a value that resource clients read with Value, so that they can define their own options.
+// The rest client ignores it. Like context keys, key should be of an unexported type.
+func WithValue(key, value any) Option {
+	return valueOption{key: key, value: value}
+}
+
+// Value returns the value set with WithValue for key in opts, or nil.
+func Value(opts []Option, key any) any {
+	options := &options{}
+	for _, opt := range opts {
+		opt.apply(options)
+	}
+	return options.values[key]
+}
+
+// AccessToken returns the access token set with WithAccessToken in opts, or "" if the call uses the one of the client.
+func AccessToken(opts []Option) string {
+	options := &options{}
+	for _, opt := range opts {
+		opt.apply(options)
+	}
+	return options.accessToken
+}
+
+// SharedOptions returns opts without the options bound to a single call, WithContext, WithIdempotencyKey
+// and WithResponseCapture, so that a resource client can reuse them for calls made on behalf of many callers.
+func SharedOptions(opts []Option) []Option {
+	shared := make([]Option, 0, len(opts))
+	for _, opt := range opts {
+		switch opt.(type) {
+		case contextOption, idempotencyKeyOption, responseCaptureOption:
+			continue
+		}
+		shared = append(shared, opt)
+	}
+	return shared
+}
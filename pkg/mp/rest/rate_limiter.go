@@ -0,0 +1,255 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var retryAfterHeader = http.CanonicalHeaderKey("retry-after")
+
+// ErrRateLimited is returned when the rate limiter is in RateLimitFail mode and there is no budget left.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// RateLimiter is the interface that defines the client-side rate limiting signature.
+type RateLimiter interface {
+	// Wait blocks until a request may be sent with the access token, or returns an error.
+	Wait(ctx context.Context, accessToken string) error
+
+	// Backoff stops requests with the access token for d.
+	// It is called when the API responds with 429 Too Many Requests.
+	Backoff(accessToken string, d time.Duration)
+}
+
+// RateLimitMode defines what the rate limiter does when there is no budget left.
+type RateLimitMode int
+
+const (
+	// RateLimitWait blocks until the budget is refilled or the request context is done.
+	RateLimitWait RateLimitMode = iota
+
+	// RateLimitFail returns ErrRateLimited immediately.
+	RateLimitFail
+)
+
+// RateLimit is a token bucket budget: Rate requests per second, with bursts of up to Burst requests.
+// A zero Rate means no limit.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimiterConfig configures the default RateLimiter.
+type RateLimiterConfig struct {
+	Mode RateLimitMode
+
+	// Global is the budget shared by every access token.
+	Global RateLimit
+
+	// PerToken is the budget of each access token not listed in Tokens.
+	PerToken RateLimit
+
+	// Tokens are the budgets of specific access tokens, e.g. marketplace sellers.
+	Tokens map[string]RateLimit
+
+	// IdleTTL is how long the state of an access token is kept after its last request, 10 minutes by default.
+	// The state is dropped only once its budget is refilled and its backoff is over, so that dropping it
+	// changes nothing but the memory used by the tokens no longer in use.
+	IdleTTL time.Duration
+}
+
+// defaultIdleTTL is the default RateLimiterConfig.IdleTTL.
+const defaultIdleTTL = 10 * time.Minute
+
+// rateLimiter is the default implementation of RateLimiter.
+type rateLimiter struct {
+	mu sync.Mutex
+
+	config  RateLimiterConfig
+	global  *bucket
+	buckets map[string]*bucket
+
+	// swept is when the idle buckets were last dropped.
+	swept time.Time
+}
+
+// NewRateLimiter returns a token bucket RateLimiter.
+func NewRateLimiter(config RateLimiterConfig) RateLimiter {
+	if config.IdleTTL <= 0 {
+		config.IdleTTL = defaultIdleTTL
+	}
+	return &rateLimiter{
+		config:  config,
+		global:  newBucket(config.Global),
+		buckets: map[string]*bucket{},
+		swept:   time.Now(),
+	}
+}
+
+func (l *rateLimiter) Wait(ctx context.Context, accessToken string) error {
+	l.mu.Lock()
+	now := time.Now()
+	b := l.bucket(accessToken, now)
+	if l.config.Mode == RateLimitFail && (l.global.delay(now) > 0 || b.delay(now) > 0) {
+		l.mu.Unlock()
+		return ErrRateLimited
+	}
+	wait := max(l.global.take(now), b.take(now))
+	l.mu.Unlock()
+
+	return sleep(ctx, wait)
+}
+
+func (l *rateLimiter) Backoff(accessToken string, d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	b := l.bucket(accessToken, now)
+	if until := now.Add(d); until.After(b.blockedUntil) {
+		b.blockedUntil = until
+	}
+}
+
+// bucket returns the bucket of the access token, creating it if needed, and drops the idle buckets once per IdleTTL.
+// It must be called with l.mu held.
+func (l *rateLimiter) bucket(accessToken string, now time.Time) *bucket {
+	if now.Sub(l.swept) >= l.config.IdleTTL {
+		l.sweep(now)
+	}
+
+	b, ok := l.buckets[accessToken]
+	if !ok {
+		limit, ok := l.config.Tokens[accessToken]
+		if !ok {
+			limit = l.config.PerToken
+		}
+		b = newBucket(limit)
+		l.buckets[accessToken] = b
+	}
+	b.used = now
+	return b
+}
+
+// sweep drops the buckets unused for IdleTTL that are back to the state of a new bucket.
+// It must be called with l.mu held.
+func (l *rateLimiter) sweep(now time.Time) {
+	for token, b := range l.buckets {
+		if now.Sub(b.used) >= l.config.IdleTTL && b.idle(now) {
+			delete(l.buckets, token)
+		}
+	}
+	l.swept = now
+}
+
+// bucket is a token bucket. Tokens may go negative, which represents requests waiting for their turn.
+type bucket struct {
+	rate         float64
+	burst        float64
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time
+
+	// used is when the bucket was last used.
+	used time.Time
+}
+
+func newBucket(limit RateLimit) *bucket {
+	burst := math.Max(float64(limit.Burst), 1)
+	return &bucket{
+		rate:   limit.Rate,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// delay returns how long a request would wait to take a token.
+func (b *bucket) delay(now time.Time) time.Duration {
+	var wait time.Duration
+	if b.rate > 0 {
+		b.refill(now)
+		if b.tokens < 1 {
+			wait = time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		}
+	}
+	return max(wait, b.blockedUntil.Sub(now))
+}
+
+// idle reports whether the bucket is full and not blocked, like a new bucket.
+func (b *bucket) idle(now time.Time) bool {
+	if b.rate > 0 {
+		b.refill(now)
+	}
+	return b.tokens >= b.burst && !b.blockedUntil.After(now)
+}
+
+// take takes a token and returns how long the request must wait before being sent.
+func (b *bucket) take(now time.Time) time.Duration {
+	wait := b.delay(now)
+	if b.rate > 0 {
+		b.tokens--
+	}
+	return wait
+}
+
+func (b *bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed.Seconds()*b.rate)
+		b.last = now
+	}
+}
+
+// accessTokenOf returns the access token the request is authorized with.
+func accessTokenOf(req *http.Request) string {
+	return strings.TrimPrefix(req.Header.Get(authorizationHeader), "Bearer ")
+}
+
+// rateLimited informs the rate limiter when the API responds with 429 Too Many Requests.
+func rateLimited(rl RateLimiter, req *http.Request, res *http.Response) {
+	if res == nil || res.StatusCode != http.StatusTooManyRequests {
+		return
+	}
+	rl.Backoff(accessTokenOf(req), retryAfter(res))
+}
+
+// retryAfter parses the Retry-After header, which is either a number of seconds or an HTTP date.
+func retryAfter(res *http.Response) time.Duration {
+	if res == nil {
+		return 0
+	}
+
+	v := res.Header.Get(retryAfterHeader)
+	if v == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
+
+// sleep pauses for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
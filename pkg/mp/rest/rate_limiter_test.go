@@ -0,0 +1,143 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterWait(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  RateLimiterConfig
+		token   string
+		calls   int
+		backoff time.Duration
+		wantErr error
+		minWait time.Duration
+	}{
+		{
+			name:  "should_not_limit_without_budget",
+			calls: 10,
+		},
+		{
+			name:    "should_fail_when_global_budget_is_exhausted",
+			config:  RateLimiterConfig{Mode: RateLimitFail, Global: RateLimit{Rate: 1, Burst: 2}},
+			calls:   3,
+			wantErr: ErrRateLimited,
+		},
+		{
+			name: "should_fail_when_token_budget_is_exhausted",
+			config: RateLimiterConfig{
+				Mode:     RateLimitFail,
+				PerToken: RateLimit{Rate: 100, Burst: 100},
+				Tokens:   map[string]RateLimit{"seller": {Rate: 1, Burst: 1}},
+			},
+			token:   "seller",
+			calls:   2,
+			wantErr: ErrRateLimited,
+		},
+		{
+			name:    "should_fail_after_backoff",
+			config:  RateLimiterConfig{Mode: RateLimitFail},
+			calls:   1,
+			backoff: time.Minute,
+			wantErr: ErrRateLimited,
+		},
+		{
+			name:    "should_wait_for_refill",
+			config:  RateLimiterConfig{Global: RateLimit{Rate: 50, Burst: 1}},
+			calls:   3,
+			minWait: 40 * time.Millisecond,
+		},
+		{
+			name:    "should_wait_for_backoff",
+			config:  RateLimiterConfig{},
+			calls:   1,
+			backoff: 30 * time.Millisecond,
+			minWait: 30 * time.Millisecond,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewRateLimiter(tt.config)
+			if tt.backoff > 0 {
+				l.Backoff(tt.token, tt.backoff)
+			}
+
+			start := time.Now()
+			var err error
+			for i := 0; i < tt.calls && err == nil; i++ {
+				err = l.Wait(context.Background(), tt.token)
+			}
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("rateLimiter.Wait() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if elapsed := time.Since(start); elapsed < tt.minWait {
+				t.Errorf("rateLimiter.Wait() waited %v, want at least %v", elapsed, tt.minWait)
+			}
+		})
+	}
+}
+
+func TestRateLimiterIdleBuckets(t *testing.T) {
+	l := NewRateLimiter(RateLimiterConfig{
+		Mode:     RateLimitFail,
+		PerToken: RateLimit{Rate: 1000, Burst: 1},
+		Tokens:   map[string]RateLimit{"slow": {Rate: 0.001, Burst: 1}},
+		IdleTTL:  20 * time.Millisecond,
+	}).(*rateLimiter)
+
+	for _, token := range []string{"seller-1", "seller-2", "slow", "blocked"} {
+		if err := l.Wait(context.Background(), token); err != nil {
+			t.Fatalf("rateLimiter.Wait(%s) error = %v", token, err)
+		}
+	}
+	l.Backoff("blocked", time.Hour)
+
+	time.Sleep(30 * time.Millisecond)
+	if err := l.Wait(context.Background(), "seller-3"); err != nil {
+		t.Fatalf("rateLimiter.Wait() error = %v", err)
+	}
+
+	// the refilled buckets are dropped, the ones still waiting for a refill or a backoff are kept.
+	var got []string
+	for token := range l.buckets {
+		got = append(got, token)
+	}
+	sort.Strings(got)
+	if want := []string{"blocked", "seller-3", "slow"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rateLimiter buckets = %v, want %v", got, want)
+	}
+	if err := l.Wait(context.Background(), "slow"); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("rateLimiter.Wait(slow) error = %v, want %v", err, ErrRateLimited)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "should_parse_seconds", value: "3", want: 3 * time.Second},
+		{name: "should_ignore_missing_header", value: "", want: 0},
+		{name: "should_ignore_malformed_header", value: "soon", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := &http.Response{Header: http.Header{}}
+			if tt.value != "" {
+				res.Header.Set("Retry-After", tt.value)
+			}
+			if got := retryAfter(res); got != tt.want {
+				t.Errorf("retryAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
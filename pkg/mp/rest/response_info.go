@@ -0,0 +1,56 @@
+package rest
+
+import (
+	"net/http"
+	"time"
+)
+
+// ResponseInfo is the metadata and the raw body of a response, filled in by WithResponseCapture.
+type ResponseInfo struct {
+	// StatusCode is the HTTP status of the last attempt, zero if no response was received.
+	StatusCode int
+
+	// Header is the header of the response, e.g. with the rate limit headers.
+	Header http.Header
+
+	// RequestID is the x-request-id header of the response, which identifies the request at Mercado Pago.
+	RequestID string
+
+	// IdempotencyKey is the x-idempotency-key header of the request.
+	IdempotencyKey string
+
+	// Attempts is the number of requests sent, retries included.
+	// It is zero when the request was not sent, e.g. when the circuit is open or the response was replayed.
+	Attempts int
+
+	// Latency is the duration of the call, retries and rate limiter waits included.
+	Latency time.Duration
+
+	// Body is the raw body of the response, including an error response.
+	Body []byte
+
+	// Replayed reports whether the response was replayed from the idempotency store.
+	Replayed bool
+}
+
+// newResponseInfo returns the info of the response of a call to Send.
+func newResponseInfo(req *http.Request, res *http.Response, body []byte, obs *observation) ResponseInfo {
+	info := ResponseInfo{
+		IdempotencyKey: req.Header.Get(idempotencyHeader),
+		Body:           body,
+	}
+	if obs != nil {
+		info.Attempts = obs.attempts
+		info.Latency = time.Since(obs.start)
+		if obs.fromStore {
+			info.StatusCode = obs.replayedStatus
+			info.Replayed = true
+		}
+	}
+	if res != nil {
+		info.StatusCode = res.StatusCode
+		info.Header = res.Header.Clone()
+		info.RequestID = res.Header.Get(requestIDHeader)
+	}
+	return info
+}
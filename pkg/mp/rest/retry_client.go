@@ -43,10 +43,30 @@ func (*retryClient) Retry(req *http.Request, httpClient *http.Client, opts ...Op
 		retryDelay = options.retryDelay
 	}
 
+	cl := clientFromContext(req.Context())
+	obs := observationFromContext(req.Context())
 	for i := 0; i < maxRetries; i++ {
-		time.Sleep(retryDelay)
+		// a 429 response tells how long to wait, and it may be longer than the current delay.
+		delay := max(retryDelay, min(retryAfter(res), maxBackoff))
+		if res != nil {
+			res.Body.Close()
+		}
+		if err := sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+		if err := cl.rateLimiter.Wait(req.Context(), accessTokenOf(req)); err != nil {
+			return nil, err
+		}
+		// the circuit is checked right before sending, so that a half-open circuit does not keep its probe slot
+		// for a request still waiting, and only the requests sent are recorded.
+		if err := cl.circuitBreaker.Allow(req); err != nil {
+			return nil, err
+		}
 
 		res, err = httpClient.Do(req)
+		cl.circuitBreaker.Record(req, res, err)
+		obs.retried(res, err)
+		rateLimited(cl.rateLimiter, req, res)
 		if shouldStop(res, err) {
 			break
 		}
@@ -59,5 +79,5 @@ func (*retryClient) Retry(req *http.Request, httpClient *http.Client, opts ...Op
 }
 
 func shouldStop(res *http.Response, err error) bool {
-	return err == nil && res.StatusCode < http.StatusInternalServerError
+	return err == nil && res.StatusCode != http.StatusTooManyRequests && res.StatusCode < http.StatusInternalServerError
 }
@@ -0,0 +1,235 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Attribute keys set on spans and metrics recorded by the rest client.
+const (
+	AttributeOperation      = "mercadopago.operation"
+	AttributeHTTPMethod     = "http.request.method"
+	AttributeHTTPStatusCode = "http.response.status_code"
+	AttributeRetryCount     = "mercadopago.retry_count"
+	AttributeRequestID      = "mercadopago.request_id"
+	AttributeStatus         = "mercadopago.status"
+	AttributeErrorClass     = "error.type"
+	AttributeReplayed       = "mercadopago.idempotency.replayed"
+)
+
+var requestIDHeader = http.CanonicalHeaderKey("x-request-id")
+
+// ErrorClass groups errors returned by the API for metrics purposes.
+type ErrorClass string
+
+const (
+	ErrorClassNetwork     ErrorClass = "network"
+	ErrorClassTimeout     ErrorClass = "timeout"
+	ErrorClassRateLimited ErrorClass = "rate_limited"
+	ErrorClassClient      ErrorClass = "client_error"
+	ErrorClassServer      ErrorClass = "server_error"
+	ErrorClassCircuitOpen ErrorClass = "circuit_open"
+)
+
+// Attribute is a key-value pair describing a span or a measurement.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// Tracer is the interface that wraps the Start method.
+// It is invoked once per call to Send, with the name of the SDK operation (e.g. "payment.Create").
+type Tracer interface {
+	Start(ctx context.Context, operation string) (context.Context, Span)
+}
+
+// Span is the interface of a single traced SDK operation.
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	AddEvent(name string, attrs ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Meter is the interface that defines the metrics recorded by the rest client.
+type Meter interface {
+	// RecordLatency records the duration of an SDK operation, retries included.
+	RecordLatency(ctx context.Context, operation string, d time.Duration, attrs ...Attribute)
+
+	// AddError counts a failed SDK operation by its error class.
+	AddError(ctx context.Context, operation string, class ErrorClass, attrs ...Attribute)
+}
+
+// observation holds the telemetry state of a single call to Send.
+type observation struct {
+	operation string
+	start     time.Time
+	span      Span
+	meter     Meter
+	retries   int
+
+	// attempts counts the requests sent, retries included.
+	attempts int
+
+	// fromStore is set when the response is replayed from the idempotency store, with its status code.
+	fromStore      bool
+	replayedStatus int
+}
+
+type observationKey struct{}
+
+func startObservation(ctx context.Context, cl *client, req *http.Request, operation string) (context.Context, *observation) {
+	ctx, span := cl.tracer.Start(ctx, operation)
+	span.SetAttributes(
+		Attribute{Key: AttributeOperation, Value: operation},
+		Attribute{Key: AttributeHTTPMethod, Value: req.Method},
+	)
+
+	obs := &observation{
+		operation: operation,
+		start:     time.Now(),
+		span:      span,
+		meter:     cl.meter,
+	}
+	return context.WithValue(ctx, observationKey{}, obs), obs
+}
+
+func observationFromContext(ctx context.Context) *observation {
+	obs, _ := ctx.Value(observationKey{}).(*observation)
+	return obs
+}
+
+// sent is called before the first attempt of the request is sent.
+func (o *observation) sent() {
+	if o == nil {
+		return
+	}
+	o.attempts++
+}
+
+// retried is called by the retry client after each retry attempt.
+func (o *observation) retried(res *http.Response, err error) {
+	if o == nil {
+		return
+	}
+	o.retries++
+	o.attempts++
+
+	attrs := []Attribute{{Key: AttributeRetryCount, Value: o.retries}}
+	if res != nil {
+		attrs = append(attrs, Attribute{Key: AttributeHTTPStatusCode, Value: res.StatusCode})
+	}
+	if err != nil {
+		attrs = append(attrs, Attribute{Key: AttributeErrorClass, Value: string(classify(nil, err))})
+	}
+	o.span.AddEvent("retry", attrs...)
+}
+
+// replayed is called when the response is replayed from the idempotency store instead of being sent.
+func (o *observation) replayed(statusCode int) {
+	if o == nil {
+		return
+	}
+	o.fromStore = true
+	o.replayedStatus = statusCode
+	o.span.SetAttributes(Attribute{Key: AttributeReplayed, Value: true})
+}
+
+// finish records the outcome of the call and ends its span.
+func (o *observation) finish(ctx context.Context, res *http.Response, body []byte, err error) {
+	if o == nil {
+		return
+	}
+
+	attrs := []Attribute{
+		{Key: AttributeOperation, Value: o.operation},
+		{Key: AttributeRetryCount, Value: o.retries},
+	}
+	if res != nil {
+		attrs = append(attrs, Attribute{Key: AttributeHTTPStatusCode, Value: res.StatusCode})
+		if id := res.Header.Get(requestIDHeader); id != "" {
+			o.span.SetAttributes(Attribute{Key: AttributeRequestID, Value: id})
+		}
+	}
+	if status := statusOf(body); status != "" {
+		o.span.SetAttributes(Attribute{Key: AttributeStatus, Value: status})
+	}
+	o.span.SetAttributes(attrs...)
+
+	if err != nil {
+		class := classify(res, errors.Join(err, ctx.Err()))
+		o.span.SetAttributes(Attribute{Key: AttributeErrorClass, Value: string(class)})
+		o.span.RecordError(err)
+		o.meter.AddError(ctx, o.operation, class, attrs...)
+	}
+	o.meter.RecordLatency(ctx, o.operation, time.Since(o.start), attrs...)
+	o.span.End()
+}
+
+// classify returns the error class of a failed call.
+// A nil response means that the request never got an answer from the API.
+func classify(res *http.Response, err error) ErrorClass {
+	if res == nil || res.StatusCode < 400 {
+		switch {
+		case errors.Is(err, ErrCircuitOpen):
+			return ErrorClassCircuitOpen
+		case errors.Is(err, ErrRateLimited):
+			return ErrorClassRateLimited
+		case errors.Is(err, context.DeadlineExceeded):
+			return ErrorClassTimeout
+		default:
+			return ErrorClassNetwork
+		}
+	}
+
+	switch {
+	case res.StatusCode == http.StatusTooManyRequests:
+		return ErrorClassRateLimited
+	case res.StatusCode >= http.StatusInternalServerError:
+		return ErrorClassServer
+	default:
+		return ErrorClassClient
+	}
+}
+
+// statusOf extracts the status field of a JSON object body, such as the payment status.
+func statusOf(body []byte) string {
+	if len(body) == 0 || body[0] != '{' {
+		return ""
+	}
+
+	var s struct {
+		Status json.RawMessage `json:"status"`
+	}
+	if err := json.Unmarshal(body, &s); err != nil || len(s.Status) == 0 {
+		return ""
+	}
+	if v, err := strconv.Unquote(string(s.Status)); err == nil {
+		return v
+	}
+	return string(s.Status)
+}
+
+// noopTracer is the default Tracer, it does nothing.
+type noopTracer struct{}
+
+func (noopTracer) Start(ctx context.Context, _ string) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...Attribute)    {}
+func (noopSpan) AddEvent(string, ...Attribute) {}
+func (noopSpan) RecordError(error)             {}
+func (noopSpan) End()                          {}
+
+// noopMeter is the default Meter, it does nothing.
+type noopMeter struct{}
+
+func (noopMeter) RecordLatency(context.Context, string, time.Duration, ...Attribute) {}
+func (noopMeter) AddError(context.Context, string, ErrorClass, ...Attribute)         {}
@@ -0,0 +1,253 @@
+package payment
+
+import (
+	"maps"
+	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/money"
+	"github.com/gdeandradero/sdk-go/pkg/paymentmethod"
+)
+
+// Builder builds a Request for a payment flow. It is created with NewCardPayment, NewPixPayment,
+// NewBoletoPayment or NewAccountMoneyPayment, and its methods return the builder so calls can be chained.
+// Build checks the fields required by the flow.
+type Builder struct {
+	request     Request
+	paymentType PaymentType
+	expiresIn   time.Duration
+}
+
+// NewCardPayment returns a builder of a credit or debit card payment of amount, paid with the card token.
+// The payment method and the issuer of the card must be given with WithPaymentMethod and WithIssuer.
+// Installments default to 1.
+func NewCardPayment(token string, amount money.Amount) *Builder {
+	return &Builder{
+		request: Request{
+			Token:             token,
+			TransactionAmount: amount,
+			Installments:      1,
+		},
+		paymentType: PaymentTypeCreditCard,
+	}
+}
+
+// NewPixPayment returns a builder of a pix payment of amount, paid by the payer with the email.
+func NewPixPayment(amount money.Amount, email string) *Builder {
+	return &Builder{
+		request: Request{
+			PaymentMethodID:   "pix",
+			TransactionAmount: amount,
+			Payer:             &PayerRequest{Email: email},
+		},
+		paymentType: PaymentTypeBankTransfer,
+	}
+}
+
+// NewBoletoPayment returns a builder of a boleto payment of amount.
+// The payer must have a name, an identification and an address.
+func NewBoletoPayment(amount money.Amount, payer PayerRequest) *Builder {
+	b := &Builder{
+		request: Request{
+			PaymentMethodID:   "bolbradesco",
+			TransactionAmount: amount,
+		},
+		paymentType: PaymentTypeTicket,
+	}
+	return b.WithPayer(payer)
+}
+
+// NewAccountMoneyPayment returns a builder of a payment of amount with the balance of a Mercado Pago account.
+func NewAccountMoneyPayment(amount money.Amount) *Builder {
+	return &Builder{
+		request: Request{
+			PaymentMethodID:   "account_money",
+			TransactionAmount: amount,
+		},
+		paymentType: PaymentTypeAccountMoney,
+	}
+}
+
+// WithPaymentMethod sets the payment method, e.g. "visa" or "master" for card payments.
+func (b *Builder) WithPaymentMethod(id string) *Builder {
+	b.request.PaymentMethodID = id
+	return b
+}
+
+// WithIssuer sets the issuer of the card.
+func (b *Builder) WithIssuer(id string) *Builder {
+	b.request.IssuerID = id
+	return b
+}
+
+// WithInstallments sets the number of installments of a card payment.
+func (b *Builder) WithInstallments(n int) *Builder {
+	b.request.Installments = n
+	return b
+}
+
+// WithPayer sets the payer. The identification and the address are copied.
+// If the payer has no email, the one already set, e.g. by NewPixPayment, is kept.
+func (b *Builder) WithPayer(payer PayerRequest) *Builder {
+	if payer.Email == "" && b.request.Payer != nil {
+		payer.Email = b.request.Payer.Email
+	}
+	b.request.Payer = clonePayer(&payer)
+	return b
+}
+
+// WithDescription sets the description of the payment.
+func (b *Builder) WithDescription(description string) *Builder {
+	b.request.Description = description
+	return b
+}
+
+// WithExternalReference sets the reference of the payment in your system.
+func (b *Builder) WithExternalReference(reference string) *Builder {
+	b.request.ExternalReference = reference
+	return b
+}
+
+// WithNotificationURL sets the URL notified of the payment updates.
+func (b *Builder) WithNotificationURL(url string) *Builder {
+	b.request.NotificationURL = url
+	return b
+}
+
+// WithStatementDescriptor sets how the payment appears on the payer's card statement.
+func (b *Builder) WithStatementDescriptor(descriptor string) *Builder {
+	b.request.StatementDescriptor = descriptor
+	return b
+}
+
+// WithApplicationFee sets the fee kept by the marketplace.
+func (b *Builder) WithApplicationFee(fee money.Amount) *Builder {
+	b.request.ApplicationFee = fee
+	return b
+}
+
+// WithBinaryMode makes the payment either approved or rejected, never pending.
+func (b *Builder) WithBinaryMode() *Builder {
+	b.request.BinaryMode = true
+	return b
+}
+
+// WithThreeDSecure sets the 3-D Secure mode of a card payment, "optional" to let the issuer ask for a challenge.
+// See the threeds package for the challenge flow.
+func (b *Builder) WithThreeDSecure(mode string) *Builder {
+	b.request.ThreeDSecureMode = mode
+	return b
+}
+
+// WithMetadata adds a metadata entry to the payment.
+func (b *Builder) WithMetadata(key string, value any) *Builder {
+	if b.request.Metadata == nil {
+		b.request.Metadata = map[string]any{}
+	}
+	b.request.Metadata[key] = value
+	return b
+}
+
+// WithItems adds items to the additional information of the payment.
+func (b *Builder) WithItems(items ...ItemRequest) *Builder {
+	if b.request.AdditionalInfo == nil {
+		b.request.AdditionalInfo = &AdditionalInfoRequest{}
+	}
+	b.request.AdditionalInfo.Items = append(b.request.AdditionalInfo.Items, items...)
+	return b
+}
+
+// ExpiresIn makes the payment expire d after Build is called.
+func (b *Builder) ExpiresIn(d time.Duration) *Builder {
+	b.expiresIn = d
+	b.request.DateOfExpiration = nil
+	return b
+}
+
+// ExpiresAt makes the payment expire at t.
+func (b *Builder) ExpiresAt(t time.Time) *Builder {
+	b.expiresIn = 0
+	b.request.DateOfExpiration = &t
+	return b
+}
+
+// Build returns the request, or a *ValidationError if a field required by the flow is missing or invalid.
+// The builder can be reused: the returned request does not share its maps and pointers with it.
+func (b *Builder) Build() (Request, error) {
+	r := b.request
+	r.Payer = clonePayer(r.Payer)
+	r.AdditionalInfo = cloneAdditionalInfo(r.AdditionalInfo)
+	r.DateOfExpiration = clone(r.DateOfExpiration)
+	r.Metadata = maps.Clone(r.Metadata)
+	at := now()
+	if b.expiresIn != 0 {
+		expiration := at.Add(b.expiresIn)
+		r.DateOfExpiration = &expiration
+	}
+
+	pm := &paymentmethod.Response{ID: r.PaymentMethodID, PaymentTypeID: string(b.paymentType)}
+	if err := r.validateAt(pm, at); err != nil {
+		return Request{}, err
+	}
+	return r, nil
+}
+
+// clone returns a copy of *p, or nil.
+func clone[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+// clonePayer returns a deep copy of the payer, or nil.
+func clonePayer(p *PayerRequest) *PayerRequest {
+	p = clone(p)
+	if p != nil {
+		p.Identification = clone(p.Identification)
+		p.Address = clone(p.Address)
+	}
+	return p
+}
+
+// cloneAdditionalInfo returns a deep copy of the additional information, or nil.
+func cloneAdditionalInfo(info *AdditionalInfoRequest) *AdditionalInfoRequest {
+	info = clone(info)
+	if info == nil {
+		return nil
+	}
+	if info.Payer != nil {
+		info.Payer = clone(info.Payer)
+		info.Payer.RegistrationDate = clone(info.Payer.RegistrationDate)
+		info.Payer.LastPurchase = clone(info.Payer.LastPurchase)
+		info.Payer.Phone = clone(info.Payer.Phone)
+		info.Payer.Address = clone(info.Payer.Address)
+	}
+	if info.Shipments != nil {
+		info.Shipments = clone(info.Shipments)
+		info.Shipments.ReceiverAddress = clone(info.Shipments.ReceiverAddress)
+	}
+	info.Barcode = clone(info.Barcode)
+	if info.Items != nil {
+		items := make([]ItemRequest, len(info.Items))
+		for i, item := range info.Items {
+			item.EventDate = clone(item.EventDate)
+			if d := item.CategoryDescriptor; d != nil {
+				d = clone(d)
+				if d.Passenger != nil {
+					d.Passenger = clone(d.Passenger)
+					d.Passenger.Identification = clone(d.Passenger.Identification)
+				}
+				if d.Route != nil {
+					d.Route = clone(d.Route)
+					d.Route.DepartureDateTime = clone(d.Route.DepartureDateTime)
+					d.Route.ArrivalDateTime = clone(d.Route.ArrivalDateTime)
+				}
+				item.CategoryDescriptor = d
+			}
+			items[i] = item
+		}
+		info.Items = items
+	}
+	return info
+}
@@ -0,0 +1,206 @@
+package payment
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/money"
+)
+
+func TestBuilderBuild(t *testing.T) {
+	fixed := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return fixed }
+	defer func() { now = time.Now }()
+	expiration := fixed.Add(time.Hour)
+
+	boletoPayer := PayerRequest{
+		Email:          "payer@example.com",
+		FirstName:      "John",
+		LastName:       "Doe",
+		Identification: &IdentificationRequest{Type: "CPF", Number: "19119119100"},
+		Address: &PayerAddressRequest{
+			ZipCode:      "06233200",
+			StreetName:   "Av. das Nações Unidas",
+			StreetNumber: "3003",
+			Neighborhood: "Bonfim",
+			City:         "Osasco",
+			FederalUnit:  "SP",
+		},
+	}
+
+	tests := []struct {
+		name    string
+		builder *Builder
+		want    Request
+		wantErr []string
+	}{
+		{
+			name: "card",
+			builder: NewCardPayment("token", money.FromInt(100)).
+				WithPaymentMethod("visa").
+				WithIssuer("25").
+				WithInstallments(3).
+				WithPayer(PayerRequest{Email: "payer@example.com"}),
+			want: Request{
+				Token:             "token",
+				PaymentMethodID:   "visa",
+				IssuerID:          "25",
+				Installments:      3,
+				TransactionAmount: money.FromInt(100),
+				Payer:             &PayerRequest{Email: "payer@example.com"},
+			},
+		},
+		{
+			name:    "card_missing_method_and_issuer",
+			builder: NewCardPayment("token", money.FromInt(100)),
+			wantErr: []string{"payment_method_id", "issuer_id"},
+		},
+		{
+			name: "pix",
+			builder: NewPixPayment(money.MustParse("1.5"), "payer@example.com").
+				ExpiresIn(time.Hour).
+				WithPayer(PayerRequest{FirstName: "John"}),
+			want: Request{
+				PaymentMethodID:   "pix",
+				TransactionAmount: money.MustParse("1.5"),
+				DateOfExpiration:  &expiration,
+				Payer:             &PayerRequest{Email: "payer@example.com", FirstName: "John"},
+			},
+		},
+		{
+			name:    "pix_missing_email",
+			builder: NewPixPayment(money.MustParse("1.5"), ""),
+			wantErr: []string{"payer.email"},
+		},
+		{
+			name:    "boleto",
+			builder: NewBoletoPayment(money.FromInt(100), boletoPayer).ExpiresAt(expiration),
+			want: Request{
+				PaymentMethodID:   "bolbradesco",
+				TransactionAmount: money.FromInt(100),
+				DateOfExpiration:  &expiration,
+				Payer:             &boletoPayer,
+			},
+		},
+		{
+			name:    "boleto_missing_address",
+			builder: NewBoletoPayment(money.FromInt(100), PayerRequest{FirstName: "John", LastName: "Doe", Identification: boletoPayer.Identification}),
+			wantErr: []string{"payer.address"},
+		},
+		{
+			name:    "account_money",
+			builder: NewAccountMoneyPayment(money.FromInt(10)).WithMetadata("order", 1),
+			want: Request{
+				PaymentMethodID:   "account_money",
+				TransactionAmount: money.FromInt(10),
+				Metadata:          map[string]any{"order": 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.builder.Build()
+			if tt.wantErr != nil {
+				var verr *ValidationError
+				if !errors.As(err, &verr) {
+					t.Fatalf("Builder.Build() error = %v, want *ValidationError", err)
+				}
+				var fields []string
+				for _, fe := range verr.Errors {
+					fields = append(fields, fe.Field)
+				}
+				if !reflect.DeepEqual(fields, tt.wantErr) {
+					t.Errorf("Builder.Build() fields = %v, want %v", fields, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Builder.Build() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Builder.Build() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuilderBuildPixExpirationBounds(t *testing.T) {
+	// The clock advances on every read, like time.Now, so that Build and the validation see different instants.
+	fixed := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time {
+		fixed = fixed.Add(time.Millisecond)
+		return fixed
+	}
+	defer func() { now = time.Now }()
+
+	tests := []struct {
+		name      string
+		expiresIn time.Duration
+		wantErr   bool
+	}{
+		{name: "should_accept_30_minutes", expiresIn: pixMinExpiration},
+		{name: "should_accept_30_days", expiresIn: pixMaxExpiration},
+		{name: "should_reject_less_than_30_minutes", expiresIn: pixMinExpiration - time.Second, wantErr: true},
+		{name: "should_reject_more_than_30_days", expiresIn: pixMaxExpiration + time.Second, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPixPayment(money.FromInt(10), "payer@example.com").ExpiresIn(tt.expiresIn).Build()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Builder.Build() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuilderBuildReuse(t *testing.T) {
+	fixed := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return fixed }
+	defer func() { now = time.Now }()
+
+	event := fixed.Add(24 * time.Hour)
+	b := NewBoletoPayment(money.FromInt(100), PayerRequest{
+		Email:          "payer@example.com",
+		FirstName:      "John",
+		LastName:       "Doe",
+		Identification: &IdentificationRequest{Type: "CPF", Number: "19119119100"},
+		Address: &PayerAddressRequest{
+			ZipCode:      "06233200",
+			StreetName:   "Av. das Nações Unidas",
+			StreetNumber: "3003",
+			Neighborhood: "Bonfim",
+			City:         "Osasco",
+			FederalUnit:  "SP",
+		},
+	}).
+		WithItems(ItemRequest{ID: "sku-1", EventDate: &event}).
+		WithMetadata("order", "1").
+		ExpiresAt(fixed.Add(72 * time.Hour))
+
+	first, err := b.Build()
+	if err != nil {
+		t.Fatalf("Builder.Build() error = %v", err)
+	}
+	want, _ := b.Build()
+
+	// changing the first request, however deep, must not change the next ones.
+	first.Payer.Identification.Number = "00000000000"
+	first.Payer.Address.City = "Other"
+	*first.DateOfExpiration = fixed
+	first.AdditionalInfo.Items[0].ID = "sku-2"
+	*first.AdditionalInfo.Items[0].EventDate = fixed
+	first.Metadata["order"] = "2"
+
+	got, err := b.Build()
+	if err != nil {
+		t.Fatalf("Builder.Build() again error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Builder.Build() again = %+v, want %+v", got, want)
+	}
+	if got.Payer.Identification.Number != "19119119100" || !got.DateOfExpiration.Equal(fixed.Add(72*time.Hour)) {
+		t.Errorf("Builder.Build() again shares its pointers with the first request")
+	}
+}
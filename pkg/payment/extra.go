@@ -0,0 +1,337 @@
+// Code generated by internal/gen from pkg/payment. DO NOT EDIT.
+
+package payment
+
+import "github.com/gdeandradero/sdk-go/pkg/mp/rest"
+
+// The response structs keep the fields they do not model in Extra, see rest.DecodeExtra.
+
+func (r *Response) UnmarshalJSON(b []byte) error {
+	type plain Response
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r Response) MarshalJSON() ([]byte, error) {
+	type plain Response
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *PayerResponse) UnmarshalJSON(b []byte) error {
+	type plain PayerResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r PayerResponse) MarshalJSON() ([]byte, error) {
+	type plain PayerResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *IdentificationResponse) UnmarshalJSON(b []byte) error {
+	type plain IdentificationResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r IdentificationResponse) MarshalJSON() ([]byte, error) {
+	type plain IdentificationResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *AdditionalInfoResponse) UnmarshalJSON(b []byte) error {
+	type plain AdditionalInfoResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r AdditionalInfoResponse) MarshalJSON() ([]byte, error) {
+	type plain AdditionalInfoResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *ItemResponse) UnmarshalJSON(b []byte) error {
+	type plain ItemResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r ItemResponse) MarshalJSON() ([]byte, error) {
+	type plain ItemResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *AdditionalInfoPayerResponse) UnmarshalJSON(b []byte) error {
+	type plain AdditionalInfoPayerResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r AdditionalInfoPayerResponse) MarshalJSON() ([]byte, error) {
+	type plain AdditionalInfoPayerResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *PhoneResponse) UnmarshalJSON(b []byte) error {
+	type plain PhoneResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r PhoneResponse) MarshalJSON() ([]byte, error) {
+	type plain PhoneResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *AddressResponse) UnmarshalJSON(b []byte) error {
+	type plain AddressResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r AddressResponse) MarshalJSON() ([]byte, error) {
+	type plain AddressResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *ShipmentsResponse) UnmarshalJSON(b []byte) error {
+	type plain ShipmentsResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r ShipmentsResponse) MarshalJSON() ([]byte, error) {
+	type plain ShipmentsResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *ReceiverAddressResponse) UnmarshalJSON(b []byte) error {
+	type plain ReceiverAddressResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r ReceiverAddressResponse) MarshalJSON() ([]byte, error) {
+	type plain ReceiverAddressResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *OrderResponse) UnmarshalJSON(b []byte) error {
+	type plain OrderResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r OrderResponse) MarshalJSON() ([]byte, error) {
+	type plain OrderResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *TransactionDetailsResponse) UnmarshalJSON(b []byte) error {
+	type plain TransactionDetailsResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r TransactionDetailsResponse) MarshalJSON() ([]byte, error) {
+	type plain TransactionDetailsResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *BarcodeResponse) UnmarshalJSON(b []byte) error {
+	type plain BarcodeResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r BarcodeResponse) MarshalJSON() ([]byte, error) {
+	type plain BarcodeResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *CardResponse) UnmarshalJSON(b []byte) error {
+	type plain CardResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r CardResponse) MarshalJSON() ([]byte, error) {
+	type plain CardResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *CardholderResponse) UnmarshalJSON(b []byte) error {
+	type plain CardholderResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r CardholderResponse) MarshalJSON() ([]byte, error) {
+	type plain CardholderResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *PointOfInteractionResponse) UnmarshalJSON(b []byte) error {
+	type plain PointOfInteractionResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r PointOfInteractionResponse) MarshalJSON() ([]byte, error) {
+	type plain PointOfInteractionResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *ApplicationDataResponse) UnmarshalJSON(b []byte) error {
+	type plain ApplicationDataResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r ApplicationDataResponse) MarshalJSON() ([]byte, error) {
+	type plain ApplicationDataResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *TransactionDataResponse) UnmarshalJSON(b []byte) error {
+	type plain TransactionDataResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r TransactionDataResponse) MarshalJSON() ([]byte, error) {
+	type plain TransactionDataResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *BankInfoResponse) UnmarshalJSON(b []byte) error {
+	type plain BankInfoResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r BankInfoResponse) MarshalJSON() ([]byte, error) {
+	type plain BankInfoResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *BankInfoPayerResponse) UnmarshalJSON(b []byte) error {
+	type plain BankInfoPayerResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r BankInfoPayerResponse) MarshalJSON() ([]byte, error) {
+	type plain BankInfoPayerResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *BankInfoCollectorResponse) UnmarshalJSON(b []byte) error {
+	type plain BankInfoCollectorResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r BankInfoCollectorResponse) MarshalJSON() ([]byte, error) {
+	type plain BankInfoCollectorResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *PaymentMethodResponse) UnmarshalJSON(b []byte) error {
+	type plain PaymentMethodResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r PaymentMethodResponse) MarshalJSON() ([]byte, error) {
+	type plain PaymentMethodResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *DataResponse) UnmarshalJSON(b []byte) error {
+	type plain DataResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r DataResponse) MarshalJSON() ([]byte, error) {
+	type plain DataResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *RulesResponse) UnmarshalJSON(b []byte) error {
+	type plain RulesResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r RulesResponse) MarshalJSON() ([]byte, error) {
+	type plain RulesResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *DiscountResponse) UnmarshalJSON(b []byte) error {
+	type plain DiscountResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r DiscountResponse) MarshalJSON() ([]byte, error) {
+	type plain DiscountResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *FeeResponse) UnmarshalJSON(b []byte) error {
+	type plain FeeResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r FeeResponse) MarshalJSON() ([]byte, error) {
+	type plain FeeResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *ThreeDSInfoResponse) UnmarshalJSON(b []byte) error {
+	type plain ThreeDSInfoResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r ThreeDSInfoResponse) MarshalJSON() ([]byte, error) {
+	type plain ThreeDSInfoResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *FeeDetailResponse) UnmarshalJSON(b []byte) error {
+	type plain FeeDetailResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r FeeDetailResponse) MarshalJSON() ([]byte, error) {
+	type plain FeeDetailResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *TaxResponse) UnmarshalJSON(b []byte) error {
+	type plain TaxResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r TaxResponse) MarshalJSON() ([]byte, error) {
+	type plain TaxResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *RefundResponse) UnmarshalJSON(b []byte) error {
+	type plain RefundResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r RefundResponse) MarshalJSON() ([]byte, error) {
+	type plain RefundResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *SourceResponse) UnmarshalJSON(b []byte) error {
+	type plain SourceResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r SourceResponse) MarshalJSON() ([]byte, error) {
+	type plain SourceResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *SearchResponse) UnmarshalJSON(b []byte) error {
+	type plain SearchResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r SearchResponse) MarshalJSON() ([]byte, error) {
+	type plain SearchResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
+
+func (r *PagingResponse) UnmarshalJSON(b []byte) error {
+	type plain PagingResponse
+	return rest.DecodeExtra(b, (*plain)(r), &r.Extra)
+}
+
+func (r PagingResponse) MarshalJSON() ([]byte, error) {
+	type plain PagingResponse
+	return rest.EncodeExtra(plain(r), r.Extra)
+}
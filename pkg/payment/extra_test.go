@@ -0,0 +1,108 @@
+package payment
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+)
+
+// extraPayment is a payment with fields the SDK does not model at every level.
+const extraPayment = `{
+	"id": 1,
+	"status": "approved",
+	"transaction_amount": 100.5,
+	"date_created": "2024-01-02T10:00:00-04:00",
+	"new_top_level": {"a": [1, 2]},
+	"payer": {
+		"email": "payer@example.com",
+		"identification": {"type": "CPF", "number": "12345678909", "new_identification": true},
+		"new_payer": "x"
+	},
+	"additional_info": {
+		"ip_address": "127.0.0.1",
+		"items": [{"id": "sku-1", "quantity": 2, "unit_price": 50.25, "new_item": null}]
+	},
+	"fee_details": [{"type": "mercadopago_fee", "amount": 4.99, "new_fee": 1}]
+}`
+
+func TestResponseExtra(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		opts     []rest.Option
+		wantErr  string
+	}{
+		{
+			name:     "should_keep_unknown_fields_and_encode_them_back",
+			response: extraPayment,
+		},
+		{
+			name:     "should_fail_with_strict_decoding",
+			response: extraPayment,
+			opts:     []rest.Option{rest.WithStrictDecoding(true)},
+			wantErr:  "unknown field(s) new_top_level in payment.Response",
+		},
+		{
+			name:     "should_fail_with_strict_decoding_of_nested_struct",
+			response: `{"id":1,"payer":{"email":"payer@example.com","new_payer":"x"}}`,
+			opts:     []rest.Option{rest.WithStrictDecoding(true)},
+			wantErr:  "unknown field(s) new_payer in payment.PayerResponse",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewClient(&rest.Mock{
+				SendMock: func(req *http.Request, opts ...rest.Option) ([]byte, error) {
+					return []byte(tt.response), nil
+				},
+			})
+			got, err := c.Get(1, tt.opts...)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr || !errors.Is(err, rest.ErrUnknownField) {
+					t.Errorf("client.Get() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("client.Get() error = %v", err)
+			}
+
+			extra := map[string]json.RawMessage{
+				"top level":      got.Extra["new_top_level"],
+				"payer":          got.Payer.Extra["new_payer"],
+				"identification": got.Payer.Identification.Extra["new_identification"],
+				"item":           got.AdditionalInfo.Items[0].Extra["new_item"],
+				"fee":            got.FeeDetails[0].Extra["new_fee"],
+			}
+			want := map[string]string{"top level": `{"a": [1, 2]}`, "payer": `"x"`, "identification": "true", "item": "null", "fee": "1"}
+			for k, v := range want {
+				if string(extra[k]) != v {
+					t.Errorf("client.Get() %s extra = %s, want %s", k, extra[k], v)
+				}
+			}
+			if got.AdditionalInfo.Extra != nil {
+				t.Errorf("client.Get() additional info extra = %v, want none", got.AdditionalInfo.Extra)
+			}
+
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			var roundTrip, original any
+			_ = json.Unmarshal(b, &roundTrip)
+			_ = json.Unmarshal([]byte(extraPayment), &original)
+			if !reflect.DeepEqual(roundTrip, original) {
+				t.Errorf("json.Marshal() = %s, want %s", b, extraPayment)
+			}
+
+			// decoding again into the same payment forgets its unknown fields.
+			if err := json.Unmarshal([]byte(`{"id":2}`), got); err != nil || got.ID != 2 || got.Extra != nil {
+				t.Errorf("json.Unmarshal() again = %+v, %v, want no extra fields", got, err)
+			}
+		})
+	}
+}
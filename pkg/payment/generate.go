@@ -0,0 +1,4 @@
+package payment
+
+//go:generate go run ../../internal/gen -extra
+//go:generate go run ../../internal/gen -fake
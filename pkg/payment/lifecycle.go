@@ -0,0 +1,214 @@
+package payment
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gdeandradero/sdk-go/pkg/money"
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+)
+
+// ErrActionNotPermitted is matched, with errors.Is, by the errors returned when an action is not permitted.
+var ErrActionNotPermitted = errors.New("action not permitted")
+
+// Action is an operation that changes a payment.
+type Action string
+
+const (
+	ActionCancel        Action = "cancel"
+	ActionCapture       Action = "capture"
+	ActionCaptureAmount Action = "capture_amount"
+	ActionRefund        Action = "refund"
+	ActionRefundAmount  Action = "refund_amount"
+)
+
+// ActionError tells why an action is not permitted on a payment.
+type ActionError struct {
+	Action    Action
+	PaymentID int64
+	Status    Status
+	Reason    string
+}
+
+// Error implements error.
+func (e *ActionError) Error() string {
+	return fmt.Sprintf("cannot %s payment %d: %s", e.Action, e.PaymentID, e.Reason)
+}
+
+// Is reports whether target is ErrActionNotPermitted.
+func (e *ActionError) Is(target error) bool {
+	return target == ErrActionNotPermitted
+}
+
+// transitions are the status changes a payment may go through.
+var transitions = map[Status][]Status{
+	StatusPending:     {StatusApproved, StatusAuthorized, StatusInProcess, StatusRejected, StatusCancelled},
+	StatusInProcess:   {StatusPending, StatusApproved, StatusAuthorized, StatusRejected, StatusCancelled},
+	StatusAuthorized:  {StatusApproved, StatusCancelled},
+	StatusApproved:    {StatusRefunded, StatusInMediation, StatusChargedBack},
+	StatusInMediation: {StatusApproved, StatusRefunded, StatusChargedBack},
+	StatusChargedBack: {StatusApproved},
+}
+
+// Lifecycle tells which actions are permitted on a payment, given its last known state.
+type Lifecycle struct {
+	payment *Response
+}
+
+// NewLifecycle returns the Lifecycle of the payment.
+func NewLifecycle(payment *Response) *Lifecycle {
+	return &Lifecycle{
+		payment: payment,
+	}
+}
+
+// Permitted returns the actions permitted on the payment.
+func (l *Lifecycle) Permitted() []Action {
+	var permitted []Action
+	for _, a := range []Action{ActionCancel, ActionCapture, ActionCaptureAmount, ActionRefund, ActionRefundAmount} {
+		if l.Can(a) == nil {
+			permitted = append(permitted, a)
+		}
+	}
+	return permitted
+}
+
+// Can returns nil if the action is permitted on the payment, or an *ActionError telling why not.
+// Amount actions are checked for any amount, use CanCaptureAmount and CanRefundAmount for a specific one.
+func (l *Lifecycle) Can(a Action) error {
+	switch a {
+	case ActionCancel:
+		return l.CanCancel()
+	case ActionCapture, ActionCaptureAmount:
+		return l.CanCapture()
+	case ActionRefund, ActionRefundAmount:
+		return l.CanRefund()
+	default:
+		return l.deny(a, "unknown action")
+	}
+}
+
+// CanCancel returns nil if the payment can be cancelled.
+// Only pending and in process payments can be cancelled: an authorized payment is released by the API
+// when its authorization expires, and an approved one is refunded.
+func (l *Lifecycle) CanCancel() error {
+	switch l.payment.Status {
+	case StatusPending, StatusInProcess:
+		return nil
+	default:
+		return l.deny(ActionCancel, fmt.Sprintf("payment is %s, want pending or in_process", l.payment.Status))
+	}
+}
+
+// CanCapture returns nil if the payment can be captured.
+// Only authorized payments not yet captured can be captured.
+func (l *Lifecycle) CanCapture() error {
+	return l.canCapture(ActionCapture)
+}
+
+// CanCaptureAmount returns nil if the amount of the payment can be captured.
+// The amount must be positive and at most the authorized amount.
+func (l *Lifecycle) CanCaptureAmount(amount money.Amount) error {
+	if err := l.canCapture(ActionCaptureAmount); err != nil {
+		return err
+	}
+	if !amount.IsPositive() {
+		return l.deny(ActionCaptureAmount, fmt.Sprintf("amount %s is not positive", amount))
+	}
+	if amount.Cmp(l.payment.TransactionAmount) > 0 {
+		return l.deny(ActionCaptureAmount, fmt.Sprintf("amount %s exceeds the authorized amount %s", amount, l.payment.TransactionAmount))
+	}
+	return nil
+}
+
+// CanRefund returns nil if the payment can be refunded.
+// Only approved payments not fully refunded can be refunded.
+func (l *Lifecycle) CanRefund() error {
+	return l.canRefund(ActionRefund)
+}
+
+// CanRefundAmount returns nil if the amount of the payment can be refunded.
+// The amount must be positive and at most the amount not refunded yet.
+func (l *Lifecycle) CanRefundAmount(amount money.Amount) error {
+	if err := l.canRefund(ActionRefundAmount); err != nil {
+		return err
+	}
+	if !amount.IsPositive() {
+		return l.deny(ActionRefundAmount, fmt.Sprintf("amount %s is not positive", amount))
+	}
+	if refundable := l.Refundable(); amount.Cmp(refundable) > 0 {
+		return l.deny(ActionRefundAmount, fmt.Sprintf("amount %s exceeds the refundable amount %s", amount, refundable))
+	}
+	return nil
+}
+
+// Refundable returns the amount of the payment not refunded yet.
+func (l *Lifecycle) Refundable() money.Amount {
+	return l.payment.TransactionAmount.Sub(l.payment.TransactionAmountRefunded)
+}
+
+// CanTransition returns nil if the payment may change from its status to the status to.
+// Staying in the same status is always valid.
+func (l *Lifecycle) CanTransition(to Status) error {
+	from := l.payment.Status
+	if from == to {
+		return nil
+	}
+	for _, s := range transitions[from] {
+		if s == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid payment %d status transition from %s to %s", l.payment.ID, from, to)
+}
+
+func (l *Lifecycle) canCapture(a Action) error {
+	if l.payment.Status != StatusAuthorized {
+		return l.deny(a, fmt.Sprintf("payment is %s, want authorized", l.payment.Status))
+	}
+	if l.payment.Captured {
+		return l.deny(a, "payment is already captured")
+	}
+	return nil
+}
+
+func (l *Lifecycle) canRefund(a Action) error {
+	if l.payment.Status != StatusApproved {
+		return l.deny(a, fmt.Sprintf("payment is %s, want approved", l.payment.Status))
+	}
+	if !l.Refundable().IsPositive() {
+		return l.deny(a, "payment is already fully refunded")
+	}
+	return nil
+}
+
+func (l *Lifecycle) deny(a Action, reason string) error {
+	return &ActionError{
+		Action:    a,
+		PaymentID: l.payment.ID,
+		Status:    l.payment.Status,
+		Reason:    reason,
+	}
+}
+
+type preCheckKey struct{}
+
+// WithPreCheck makes Cancel, Capture, CaptureAmount, Refund and RefundAmount check locally,
+// against the last known state of the payment, that the action is permitted before calling the API.
+// When it is not, they return an *ActionError without sending the request. They fail without sending
+// the request as well if current is not the payment they act on.
+func WithPreCheck(current *Response) rest.Option {
+	return rest.WithValue(preCheckKey{}, current)
+}
+
+// preCheck runs check against the payment set with WithPreCheck, if any, which must be the payment id.
+func preCheck(opts []rest.Option, id int64, check func(l *Lifecycle) error) error {
+	current, _ := rest.Value(opts, preCheckKey{}).(*Response)
+	if current == nil {
+		return nil
+	}
+	if current.ID != id {
+		return fmt.Errorf("cannot pre-check payment %d against the state of payment %d", id, current.ID)
+	}
+	return check(NewLifecycle(current))
+}
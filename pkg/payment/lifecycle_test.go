@@ -0,0 +1,143 @@
+package payment
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/gdeandradero/sdk-go/pkg/money"
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+)
+
+func TestLifecyclePermitted(t *testing.T) {
+	tests := []struct {
+		name    string
+		payment *Response
+		want    []Action
+	}{
+		{
+			name:    "pending",
+			payment: &Response{Status: StatusPending},
+			want:    []Action{ActionCancel},
+		},
+		{
+			name:    "in_process",
+			payment: &Response{Status: StatusInProcess},
+			want:    []Action{ActionCancel},
+		},
+		{
+			name:    "authorized",
+			payment: &Response{Status: StatusAuthorized, TransactionAmount: money.FromInt(10)},
+			want:    []Action{ActionCapture, ActionCaptureAmount},
+		},
+		{
+			name:    "approved",
+			payment: &Response{Status: StatusApproved, Captured: true, TransactionAmount: money.FromInt(10)},
+			want:    []Action{ActionRefund, ActionRefundAmount},
+		},
+		{
+			name: "approved_fully_refunded",
+			payment: &Response{
+				Status:                    StatusApproved,
+				TransactionAmount:         money.FromInt(10),
+				TransactionAmountRefunded: money.FromInt(10),
+			},
+			want: nil,
+		},
+		{
+			name:    "rejected",
+			payment: &Response{Status: StatusRejected},
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewLifecycle(tt.payment).Permitted(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lifecycle.Permitted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLifecycleAmounts(t *testing.T) {
+	approved := NewLifecycle(&Response{
+		Status:                    StatusApproved,
+		TransactionAmount:         money.FromInt(100),
+		TransactionAmountRefunded: money.FromInt(30),
+	})
+	if err := approved.CanRefundAmount(money.FromInt(70)); err != nil {
+		t.Errorf("Lifecycle.CanRefundAmount(70) error = %v, want nil", err)
+	}
+	if err := approved.CanRefundAmount(money.MustParse("70.01")); !errors.Is(err, ErrActionNotPermitted) {
+		t.Errorf("Lifecycle.CanRefundAmount(70.01) error = %v, want ErrActionNotPermitted", err)
+	}
+
+	authorized := NewLifecycle(&Response{Status: StatusAuthorized, TransactionAmount: money.FromInt(100)})
+	if err := authorized.CanCaptureAmount(money.FromInt(0)); !errors.Is(err, ErrActionNotPermitted) {
+		t.Errorf("Lifecycle.CanCaptureAmount(0) error = %v, want ErrActionNotPermitted", err)
+	}
+	if err := authorized.CanCaptureAmount(money.FromInt(101)); !errors.Is(err, ErrActionNotPermitted) {
+		t.Errorf("Lifecycle.CanCaptureAmount(101) error = %v, want ErrActionNotPermitted", err)
+	}
+}
+
+func TestLifecycleCanTransition(t *testing.T) {
+	tests := []struct {
+		from    Status
+		to      Status
+		wantErr bool
+	}{
+		{from: StatusPending, to: StatusApproved},
+		{from: StatusAuthorized, to: StatusApproved},
+		{from: StatusApproved, to: StatusRefunded},
+		{from: StatusApproved, to: StatusApproved},
+		{from: StatusApproved, to: StatusPending, wantErr: true},
+		{from: StatusRejected, to: StatusApproved, wantErr: true},
+		{from: StatusCancelled, to: StatusPending, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"_to_"+string(tt.to), func(t *testing.T) {
+			err := NewLifecycle(&Response{Status: tt.from}).CanTransition(tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Lifecycle.CanTransition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClientPreCheck(t *testing.T) {
+	sent := false
+	c := &client{
+		rc: &rest.Mock{
+			SendMock: func(req *http.Request, opts ...rest.Option) ([]byte, error) {
+				sent = true
+				return []byte(`{}`), nil
+			},
+		},
+	}
+
+	current := &Response{ID: 1, Status: StatusApproved, TransactionAmount: money.FromInt(10)}
+	_, err := c.Cancel(1, WithPreCheck(current))
+	var actionErr *ActionError
+	if !errors.As(err, &actionErr) || actionErr.Action != ActionCancel {
+		t.Fatalf("client.Cancel() error = %v, want an *ActionError", err)
+	}
+	if sent {
+		t.Error("client.Cancel() sent a request that is not permitted")
+	}
+
+	if _, err := c.RefundAmount(2, money.FromInt(5), WithPreCheck(current)); err == nil || errors.Is(err, ErrActionNotPermitted) {
+		t.Errorf("client.RefundAmount() of another payment error = %v, want a mismatch error", err)
+	}
+	if sent {
+		t.Error("client.RefundAmount() sent a request pre-checked against another payment")
+	}
+
+	if _, err := c.RefundAmount(1, money.FromInt(5), WithPreCheck(current)); err != nil {
+		t.Errorf("client.RefundAmount() error = %v, want nil", err)
+	}
+	if !sent {
+		t.Error("client.RefundAmount() did not send a permitted request")
+	}
+}
@@ -1,12 +1,13 @@
 package payment
 
 import (
-	"encoding/json"
+	"context"
 	"net/http"
 	"net/url"
 	"strconv"
-	"strings"
+	"sync"
 
+	"github.com/gdeandradero/sdk-go/pkg/money"
 	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
 )
 
@@ -15,6 +16,7 @@ const (
 	searchURL = "https://api.mercadopago.com/v1/payments/search"
 	getURL    = "https://api.mercadopago.com/v1/payments/{id}"
 	putURL    = "https://api.mercadopago.com/v1/payments/{id}"
+	refundURL = "https://api.mercadopago.com/v1/payments/{id}/refunds"
 )
 
 // Client contains the methods to interact with the Payments API.
@@ -44,12 +46,32 @@ type Client interface {
 
 	// CaptureAmount captures amount of a payment by its ID.
 	// It is a put request to the endpoint: https://api.mercadopago.com/v1/payments/{id}
-	CaptureAmount(id int64, amount float64, opts ...rest.Option) (*Response, error)
+	CaptureAmount(id int64, amount money.Amount, opts ...rest.Option) (*Response, error)
+
+	// Refund refunds the total amount of a payment by its ID.
+	// It is a post request to the endpoint: https://api.mercadopago.com/v1/payments/{id}/refunds
+	Refund(id int64, opts ...rest.Option) (*RefundResponse, error)
+
+	// RefundAmount refunds amount of a payment by its ID.
+	// It is a post request to the endpoint: https://api.mercadopago.com/v1/payments/{id}/refunds
+	RefundAmount(id int64, amount money.Amount, opts ...rest.Option) (*RefundResponse, error)
+
+	// Watch polls a payment, with backoff, until it reaches a final status, the condition set with WithWatchUntil
+	// is met, or ctx is done. It sends the payment on the returned channel when its status changes, starting with
+	// its current status, and closes the channel at the end. A client error, such as an unknown payment, is sent
+	// and ends the watch, other errors are retried.
+	// Concurrent watches of a payment with the same access token share the polling, with the options of the first one
+	// except those bound to a single call, such as WithResponseCapture. A slow receiver may miss intermediate changes,
+	// never the last one.
+	Watch(ctx context.Context, id int64, opts ...rest.Option) <-chan WatchEvent
 }
 
 // client is the implementation of Client.
 type client struct {
 	rc rest.Client
+
+	mu    sync.Mutex
+	polls map[pollKey]*poll
 }
 
 // NewClient returns a new Payments API Client.
@@ -60,33 +82,12 @@ func NewClient(restClient rest.Client) Client {
 }
 
 func (c *client) Create(dto Request, opts ...rest.Option) (*Response, error) {
-	body, err := json.Marshal(&dto)
-	if err != nil {
-		return nil, &rest.ErrorResponse{
-			StatusCode: http.StatusInternalServerError,
-			Message:    "error marshaling request body: " + err.Error(),
-		}
-	}
-
-	req, err := http.NewRequest(http.MethodPost, postURL, strings.NewReader(string(body)))
-	if err != nil {
-		return nil, &rest.ErrorResponse{
-			StatusCode: http.StatusInternalServerError,
-			Message:    "error creating request: " + err.Error(),
-		}
-	}
-
-	res, err := c.rc.Send(req, opts...)
-	if err != nil {
+	if err := validateRequest(opts, &dto); err != nil {
 		return nil, err
 	}
 
-	formatted := &Response{}
-	if err := json.Unmarshal(res, &formatted); err != nil {
-		return nil, err
-	}
-
-	return formatted, nil
+	return rest.Do[Request, Response](context.Background(), c.rc, http.MethodPost, postURL, nil, nil, &dto,
+		append([]rest.Option{rest.WithOperation("payment.Create")}, opts...)...)
 }
 
 func (c *client) Search(f Filters, opts ...rest.Option) (*SearchResponse, error) {
@@ -94,138 +95,80 @@ func (c *client) Search(f Filters, opts ...rest.Option) (*SearchResponse, error)
 	params.Add("sort", f.Sort)
 	params.Add("criteria", f.Criteria)
 	params.Add("external_reference", f.ExternalReference)
+	if f.Status != "" {
+		params.Add("status", f.Status)
+	}
 	params.Add("range", f.Range)
 	params.Add("begin_date", f.BeginDate)
 	params.Add("end_date", f.EndDate)
-
-	req, err := http.NewRequest(http.MethodGet, searchURL+"?"+params.Encode(), nil)
-	if err != nil {
-		return nil, &rest.ErrorResponse{
-			StatusCode: http.StatusInternalServerError,
-			Message:    "error creating request: " + err.Error(),
-		}
-	}
-
-	res, err := c.rc.Send(req, opts...)
-	if err != nil {
-		return nil, err
+	if f.Limit != 0 {
+		params.Add("limit", strconv.FormatInt(f.Limit, 10))
 	}
-
-	var formatted *SearchResponse
-	if err := json.Unmarshal(res, &formatted); err != nil {
-		return nil, err
+	if f.Offset != 0 {
+		params.Add("offset", strconv.FormatInt(f.Offset, 10))
 	}
 
-	return formatted, nil
+	return rest.Do[rest.NoBody, SearchResponse](context.Background(), c.rc, http.MethodGet, searchURL, nil, params, nil,
+		append([]rest.Option{rest.WithOperation("payment.Search")}, opts...)...)
 }
 
 func (c *client) Get(id int64, opts ...rest.Option) (*Response, error) {
-	conv := strconv.Itoa(int(id))
-
-	req, err := http.NewRequest(http.MethodGet, strings.Replace(getURL, "{id}", conv, 1), nil)
-	if err != nil {
-		return nil, &rest.ErrorResponse{
-			StatusCode: http.StatusInternalServerError,
-			Message:    "error creating request: " + err.Error(),
-		}
-	}
-
-	res, err := c.rc.Send(req, opts...)
-	if err != nil {
-		return nil, err
-	}
-
-	formatted := &Response{}
-	if err := json.Unmarshal(res, &formatted); err != nil {
-		return nil, err
-	}
-
-	return formatted, nil
+	return rest.Do[rest.NoBody, Response](context.Background(), c.rc, http.MethodGet, getURL, idParam(id), nil, nil,
+		append([]rest.Option{rest.WithOperation("payment.Get")}, opts...)...)
 }
 
 func (c *client) Cancel(id int64, opts ...rest.Option) (*Response, error) {
-	dto := &CancelRequest{Status: "cancelled"}
-	body, err := json.Marshal(dto)
-	if err != nil {
+	if err := preCheck(opts, id, (*Lifecycle).CanCancel); err != nil {
 		return nil, err
 	}
 
-	conv := strconv.Itoa(int(id))
-	req, err := http.NewRequest(http.MethodPut, strings.Replace(putURL, "{id}", conv, 1), strings.NewReader(string(body)))
-	if err != nil {
-		return nil, &rest.ErrorResponse{
-			StatusCode: http.StatusInternalServerError,
-			Message:    "error creating request: " + err.Error(),
-		}
-	}
-
-	res, err := c.rc.Send(req, opts...)
-	if err != nil {
-		return nil, err
-	}
-
-	formatted := &Response{}
-	if err := json.Unmarshal(res, &formatted); err != nil {
-		return nil, err
-	}
-
-	return formatted, nil
+	dto := &CancelRequest{Status: StatusCancelled}
+	return rest.Do[CancelRequest, Response](context.Background(), c.rc, http.MethodPut, putURL, idParam(id), nil, dto,
+		append([]rest.Option{rest.WithOperation("payment.Cancel")}, opts...)...)
 }
 
 func (c *client) Capture(id int64, opts ...rest.Option) (*Response, error) {
-	dto := &CaptureRequest{Capture: true}
-	body, err := json.Marshal(dto)
-	if err != nil {
+	if err := preCheck(opts, id, (*Lifecycle).CanCapture); err != nil {
 		return nil, err
 	}
 
-	conv := strconv.Itoa(int(id))
-	req, err := http.NewRequest(http.MethodPut, strings.Replace(putURL, "{id}", conv, 1), strings.NewReader(string(body)))
-	if err != nil {
-		return nil, &rest.ErrorResponse{
-			StatusCode: http.StatusInternalServerError,
-			Message:    "error creating request: " + err.Error(),
-		}
-	}
+	dto := &CaptureRequest{Capture: true}
+	return rest.Do[CaptureRequest, Response](context.Background(), c.rc, http.MethodPut, putURL, idParam(id), nil, dto,
+		append([]rest.Option{rest.WithOperation("payment.Capture")}, opts...)...)
+}
 
-	res, err := c.rc.Send(req, opts...)
+func (c *client) CaptureAmount(id int64, amount money.Amount, opts ...rest.Option) (*Response, error) {
+	err := preCheck(opts, id, func(l *Lifecycle) error { return l.CanCaptureAmount(amount) })
 	if err != nil {
 		return nil, err
 	}
 
-	formatted := &Response{}
-	if err := json.Unmarshal(res, &formatted); err != nil {
-		return nil, err
-	}
-
-	return formatted, nil
+	dto := &CaptureRequest{TransactionAmount: amount, Capture: true}
+	return rest.Do[CaptureRequest, Response](context.Background(), c.rc, http.MethodPut, putURL, idParam(id), nil, dto,
+		append([]rest.Option{rest.WithOperation("payment.CaptureAmount")}, opts...)...)
 }
 
-func (c *client) CaptureAmount(id int64, amount float64, opts ...rest.Option) (*Response, error) {
-	dto := &CaptureRequest{TransactionAmount: amount, Capture: true}
-	body, err := json.Marshal(dto)
-	if err != nil {
+func (c *client) Refund(id int64, opts ...rest.Option) (*RefundResponse, error) {
+	if err := preCheck(opts, id, (*Lifecycle).CanRefund); err != nil {
 		return nil, err
 	}
 
-	conv := strconv.Itoa(int(id))
-	req, err := http.NewRequest(http.MethodPut, strings.Replace(putURL, "{id}", conv, 1), strings.NewReader(string(body)))
-	if err != nil {
-		return nil, &rest.ErrorResponse{
-			StatusCode: http.StatusInternalServerError,
-			Message:    "error creating request: " + err.Error(),
-		}
-	}
+	return rest.Do[rest.NoBody, RefundResponse](context.Background(), c.rc, http.MethodPost, refundURL, idParam(id), nil, nil,
+		append([]rest.Option{rest.WithOperation("payment.Refund")}, opts...)...)
+}
 
-	res, err := c.rc.Send(req, opts...)
+func (c *client) RefundAmount(id int64, amount money.Amount, opts ...rest.Option) (*RefundResponse, error) {
+	err := preCheck(opts, id, func(l *Lifecycle) error { return l.CanRefundAmount(amount) })
 	if err != nil {
 		return nil, err
 	}
 
-	formatted := &Response{}
-	if err := json.Unmarshal(res, &formatted); err != nil {
-		return nil, err
-	}
+	dto := &RefundRequest{Amount: amount}
+	return rest.Do[RefundRequest, RefundResponse](context.Background(), c.rc, http.MethodPost, refundURL, idParam(id), nil, dto,
+		append([]rest.Option{rest.WithOperation("payment.RefundAmount")}, opts...)...)
+}
 
-	return formatted, nil
+// idParam returns the path parameter of the ID of a payment.
+func idParam(id int64) map[string]string {
+	return map[string]string{"id": strconv.FormatInt(id, 10)}
 }
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/gdeandradero/sdk-go/pkg/money"
 	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
 )
 
@@ -30,7 +31,7 @@ func TestClientCreate(t *testing.T) {
 			fields: fields{},
 			args: args{
 				dto: Request{
-					TransactionAmount: math.Inf(1),
+					Metadata: map[string]any{"amount": math.Inf(1)},
 				},
 			},
 			want:    nil,
@@ -72,7 +73,7 @@ func TestClientCreate(t *testing.T) {
 				},
 			},
 			args:    args{},
-			want:    &Response{TransactionAmount: 123.5},
+			want:    &Response{TransactionAmount: money.MustParse("123.5")},
 			wantErr: "",
 		},
 	}
@@ -0,0 +1,96 @@
+package payment
+
+// PaymentType is the type of a payment method.
+// Values unknown to this SDK are kept as they come from the API.
+type PaymentType string
+
+const (
+	PaymentTypeAccountMoney    PaymentType = "account_money"
+	PaymentTypeTicket          PaymentType = "ticket"
+	PaymentTypeBankTransfer    PaymentType = "bank_transfer"
+	PaymentTypeATM             PaymentType = "atm"
+	PaymentTypeCreditCard      PaymentType = "credit_card"
+	PaymentTypeDebitCard       PaymentType = "debit_card"
+	PaymentTypePrepaidCard     PaymentType = "prepaid_card"
+	PaymentTypeDigitalCurrency PaymentType = "digital_currency"
+	PaymentTypeDigitalWallet   PaymentType = "digital_wallet"
+	PaymentTypeVoucherCard     PaymentType = "voucher_card"
+	PaymentTypeCryptoTransfer  PaymentType = "crypto_transfer"
+)
+
+// String implements fmt.Stringer.
+func (t PaymentType) String() string {
+	return string(t)
+}
+
+// IsCard reports whether the payment type is a credit, debit or prepaid card.
+func (t PaymentType) IsCard() bool {
+	return t == PaymentTypeCreditCard || t == PaymentTypeDebitCard || t == PaymentTypePrepaidCard
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t PaymentType) MarshalText() ([]byte, error) {
+	return []byte(t), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, keeping unknown values.
+func (t *PaymentType) UnmarshalText(b []byte) error {
+	*t = PaymentType(b)
+	return nil
+}
+
+// OperationType is the type of operation of a payment.
+// Values unknown to this SDK are kept as they come from the API.
+type OperationType string
+
+const (
+	OperationTypeRegularPayment    OperationType = "regular_payment"
+	OperationTypeMoneyTransfer     OperationType = "money_transfer"
+	OperationTypeRecurringPayment  OperationType = "recurring_payment"
+	OperationTypeAccountFund       OperationType = "account_fund"
+	OperationTypePaymentAddition   OperationType = "payment_addition"
+	OperationTypeCellphoneRecharge OperationType = "cellphone_recharge"
+	OperationTypePOSPayment        OperationType = "pos_payment"
+	OperationTypeMoneyExchange     OperationType = "money_exchange"
+)
+
+// String implements fmt.Stringer.
+func (t OperationType) String() string {
+	return string(t)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t OperationType) MarshalText() ([]byte, error) {
+	return []byte(t), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, keeping unknown values.
+func (t *OperationType) UnmarshalText(b []byte) error {
+	*t = OperationType(b)
+	return nil
+}
+
+// ProcessingMode is the processing mode of a payment.
+// Values unknown to this SDK are kept as they come from the API.
+type ProcessingMode string
+
+const (
+	ProcessingModeAggregator ProcessingMode = "aggregator"
+	ProcessingModeGateway    ProcessingMode = "gateway"
+)
+
+// String implements fmt.Stringer.
+func (m ProcessingMode) String() string {
+	return string(m)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (m ProcessingMode) MarshalText() ([]byte, error) {
+	return []byte(m), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, keeping unknown values.
+func (m *ProcessingMode) UnmarshalText(b []byte) error {
+	*m = ProcessingMode(b)
+	return nil
+}
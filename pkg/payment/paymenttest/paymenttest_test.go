@@ -0,0 +1,184 @@
+package paymenttest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/gdeandradero/sdk-go/pkg/money"
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+	"github.com/gdeandradero/sdk-go/pkg/payment"
+)
+
+type fakeTB struct {
+	errors []string
+}
+
+func (t *fakeTB) Helper() {}
+
+func (t *fakeTB) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestClient(t *testing.T) {
+	c := &Client{
+		GetFunc: func(id int64, opts ...rest.Option) (*payment.Response, error) {
+			return Approved(id), nil
+		},
+		CancelFunc: func(id int64, opts ...rest.Option) (*payment.Response, error) {
+			return Cancelled(id), nil
+		},
+	}
+
+	got, err := c.Get(1)
+	if err != nil || !reflect.DeepEqual(got, Approved(1)) {
+		t.Errorf("Get() = %+v, %v, want %+v", got, err, Approved(1))
+	}
+	if _, err := c.Cancel(1, rest.WithIdempotencyKey("key")); err != nil {
+		t.Errorf("Cancel() error = %v", err)
+	}
+	if _, err := c.RefundAmount(1, money.MustParse("10.00")); !errors.Is(err, ErrNotConfigured) {
+		t.Errorf("RefundAmount() error = %v, want %v", err, ErrNotConfigured)
+	}
+
+	// untyped integers match the int64 IDs.
+	c.AssertCalls(t, Expect("Get", 1), Expect("Cancel", int64(1)), Expect("RefundAmount"))
+	c.AssertCalled(t, "RefundAmount", 1, money.MustParse("10.00"))
+	c.AssertNotCalled(t, "Create")
+	if calls := c.CallsTo("Cancel"); len(calls) != 1 || len(calls[0].Opts) != 1 {
+		t.Errorf("CallsTo(Cancel) = %v, want a call with an option", calls)
+	}
+
+	tb := &fakeTB{}
+	if c.AssertCalls(tb, Expect("Get", int64(2))) {
+		t.Error("AssertCalls() = true, want false")
+	}
+	want := "calls = Get(1), Cancel(1), RefundAmount(1, " + fmt.Sprintf("%#v", money.MustParse("10.00")) + "), want Get(2)"
+	if len(tb.errors) != 1 || tb.errors[0] != want {
+		t.Errorf("AssertCalls() errors = %q, want %q", tb.errors, want)
+	}
+	if c.AssertNotCalled(tb, "Get") || c.AssertCalled(tb, "Capture") || c.AssertCalled(tb, "Get", "1") || c.AssertCalled(tb, "Get", -1) {
+		t.Error("AssertNotCalled(Get), AssertCalled(Capture) or AssertCalled(Get) with other arguments = true, want false")
+	}
+
+	c.Reset()
+	c.AssertCalls(t)
+}
+
+func TestClientWatch(t *testing.T) {
+	c := &Client{
+		WatchFunc: func(ctx context.Context, id int64, opts ...rest.Option) <-chan payment.WatchEvent {
+			ch := make(chan payment.WatchEvent, 1)
+			ch <- payment.WatchEvent{Payment: Pending(id)}
+			close(ch)
+			return ch
+		},
+	}
+
+	var events []payment.WatchEvent
+	for e := range c.Watch(context.Background(), 1) {
+		events = append(events, e)
+	}
+	if want := []payment.WatchEvent{{Payment: Pending(1)}}; !reflect.DeepEqual(events, want) {
+		t.Errorf("Watch() events = %+v, want %+v", events, want)
+	}
+	c.AssertCalls(t, Expect("Watch", 1))
+
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, ErrNotConfigured) {
+			t.Errorf("Watch() panic = %v, want %v", err, ErrNotConfigured)
+		}
+	}()
+	(&Client{}).Watch(context.Background(), 1)
+}
+
+func TestResponseBuilder(t *testing.T) {
+	tests := []struct {
+		name          string
+		got           *payment.Response
+		status        payment.Status
+		detail        payment.StatusDetail
+		captured      bool
+		approved      bool
+		amount        string
+		refunded      string
+		paymentMethod string
+	}{
+		{
+			name:          "should_build_approved",
+			got:           Approved(1),
+			status:        payment.StatusApproved,
+			detail:        payment.StatusDetailAccredited,
+			captured:      true,
+			approved:      true,
+			amount:        "100.00",
+			refunded:      "0",
+			paymentMethod: "visa",
+		},
+		{
+			name:          "should_build_authorized",
+			got:           Authorized(1),
+			status:        payment.StatusAuthorized,
+			detail:        payment.StatusDetailPendingCapture,
+			amount:        "100.00",
+			refunded:      "0",
+			paymentMethod: "visa",
+		},
+		{
+			name:          "should_build_rejected",
+			got:           Rejected(1, payment.StatusDetailCCRejectedInsufficientAmount),
+			status:        payment.StatusRejected,
+			detail:        payment.StatusDetailCCRejectedInsufficientAmount,
+			captured:      true,
+			amount:        "100.00",
+			refunded:      "0",
+			paymentMethod: "visa",
+		},
+		{
+			name:          "should_build_refunded",
+			got:           Refunded(1),
+			status:        payment.StatusRefunded,
+			detail:        payment.StatusDetailRefunded,
+			captured:      true,
+			approved:      true,
+			amount:        "100.00",
+			refunded:      "100.00",
+			paymentMethod: "visa",
+		},
+		{
+			name: "should_build_partially_refunded_pix",
+			got: NewResponse(1).
+				WithPaymentMethod("pix", payment.PaymentTypeBankTransfer).
+				WithAmount(money.MustParse("50.00")).
+				WithRefunded(money.MustParse("20.00")).
+				Build(),
+			status:        payment.StatusApproved,
+			detail:        payment.StatusDetailPartiallyRefunded,
+			captured:      true,
+			approved:      true,
+			amount:        "50.00",
+			refunded:      "20.00",
+			paymentMethod: "pix",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got.ID != 1 || tt.got.Status != tt.status || tt.got.StatusDetail != tt.detail || tt.got.Captured != tt.captured {
+				t.Errorf("got ID %d, status %s/%s, captured %v, want 1, %s/%s, %v",
+					tt.got.ID, tt.got.Status, tt.got.StatusDetail, tt.got.Captured, tt.status, tt.detail, tt.captured)
+			}
+			if (tt.got.DateApproved != nil) != tt.approved {
+				t.Errorf("got DateApproved %v, want set %v", tt.got.DateApproved, tt.approved)
+			}
+			if !tt.got.TransactionAmount.Equal(money.MustParse(tt.amount)) || !tt.got.TransactionAmountRefunded.Equal(money.MustParse(tt.refunded)) {
+				t.Errorf("got amount %s, refunded %s, want %s, %s",
+					tt.got.TransactionAmount, tt.got.TransactionAmountRefunded, tt.amount, tt.refunded)
+			}
+			if tt.got.PaymentMethodID != tt.paymentMethod {
+				t.Errorf("got PaymentMethodID %s, want %s", tt.got.PaymentMethodID, tt.paymentMethod)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package paymenttest
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/money"
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+	"github.com/gdeandradero/sdk-go/pkg/payment"
+)
+
+// DefaultAmount is the amount of the payments built by NewResponse.
+var DefaultAmount = money.MustParse("100.00")
+
+// created is the creation date of the payments built by NewResponse, fixed so that they compare equal.
+var created = time.Date(2024, time.January, 2, 10, 0, 0, 0, time.UTC)
+
+// ResponseBuilder builds a canned payment. Its methods return the builder so calls can be chained.
+type ResponseBuilder struct {
+	response payment.Response
+}
+
+// NewResponse returns a builder of an approved and captured credit card payment of DefaultAmount in BRL,
+// created on 2024-01-02.
+func NewResponse(id int64) *ResponseBuilder {
+	created, approved := created, created
+	return &ResponseBuilder{
+		response: payment.Response{
+			ID:                id,
+			Status:            payment.StatusApproved,
+			StatusDetail:      payment.StatusDetailAccredited,
+			PaymentMethodID:   "visa",
+			PaymentTypeID:     payment.PaymentTypeCreditCard,
+			CurrencyID:        "BRL",
+			Installments:      1,
+			TransactionAmount: DefaultAmount,
+			Captured:          true,
+			DateCreated:       &created,
+			DateApproved:      &approved,
+		},
+	}
+}
+
+// WithStatus sets the status and its detail. The payment is captured unless the status is authorized.
+func (b *ResponseBuilder) WithStatus(status payment.Status, detail payment.StatusDetail) *ResponseBuilder {
+	b.response.Status = status
+	b.response.StatusDetail = detail
+	b.response.Captured = status != payment.StatusAuthorized
+	if !status.IsApproved() && status != payment.StatusRefunded {
+		b.response.DateApproved = nil
+	}
+	return b
+}
+
+// WithAmount sets the transaction amount.
+func (b *ResponseBuilder) WithAmount(amount money.Amount) *ResponseBuilder {
+	b.response.TransactionAmount = amount
+	return b
+}
+
+// WithRefunded sets the amount refunded, and the status: refunded when the whole amount is refunded,
+// approved and partially refunded otherwise.
+func (b *ResponseBuilder) WithRefunded(amount money.Amount) *ResponseBuilder {
+	b.response.TransactionAmountRefunded = amount
+	if amount.Cmp(b.response.TransactionAmount) >= 0 {
+		return b.WithStatus(payment.StatusRefunded, payment.StatusDetailRefunded)
+	}
+	return b.WithStatus(payment.StatusApproved, payment.StatusDetailPartiallyRefunded)
+}
+
+// WithPaymentMethod sets the payment method and its type, e.g. "pix" and payment.PaymentTypeBankTransfer.
+func (b *ResponseBuilder) WithPaymentMethod(id string, paymentType payment.PaymentType) *ResponseBuilder {
+	b.response.PaymentMethodID = id
+	b.response.PaymentTypeID = paymentType
+	return b
+}
+
+// WithExternalReference sets the external reference.
+func (b *ResponseBuilder) WithExternalReference(ref string) *ResponseBuilder {
+	b.response.ExternalReference = ref
+	return b
+}
+
+// WithPayer sets the email of the payer.
+func (b *ResponseBuilder) WithPayer(email string) *ResponseBuilder {
+	b.response.Payer = &payment.PayerResponse{Email: email}
+	return b
+}
+
+// WithChallenge makes the payment wait for the payer to complete a 3-D Secure challenge.
+func (b *ResponseBuilder) WithChallenge(url, creq string) *ResponseBuilder {
+	b.WithStatus(payment.StatusPending, payment.StatusDetailPendingChallenge)
+	b.response.ThreeDSInfo = &payment.ThreeDSInfoResponse{ExternalResourceURL: url, Creq: creq}
+	return b
+}
+
+// With calls fn to set any other field.
+func (b *ResponseBuilder) With(fn func(r *payment.Response)) *ResponseBuilder {
+	fn(&b.response)
+	return b
+}
+
+// Build returns the payment. Each call returns a new payment, so a builder can build many.
+func (b *ResponseBuilder) Build() *payment.Response {
+	r := b.response
+	return &r
+}
+
+// Approved returns an approved payment, see NewResponse.
+func Approved(id int64) *payment.Response {
+	return NewResponse(id).Build()
+}
+
+// Authorized returns an authorized payment waiting to be captured.
+func Authorized(id int64) *payment.Response {
+	return NewResponse(id).WithStatus(payment.StatusAuthorized, payment.StatusDetailPendingCapture).Build()
+}
+
+// Pending returns a pending payment waiting to be paid, e.g. a boleto.
+func Pending(id int64) *payment.Response {
+	return NewResponse(id).WithStatus(payment.StatusPending, payment.StatusDetailPendingWaitingPayment).Build()
+}
+
+// Rejected returns a payment rejected with the detail.
+func Rejected(id int64, detail payment.StatusDetail) *payment.Response {
+	return NewResponse(id).WithStatus(payment.StatusRejected, detail).Build()
+}
+
+// Cancelled returns a payment cancelled by the collector.
+func Cancelled(id int64) *payment.Response {
+	return NewResponse(id).WithStatus(payment.StatusCancelled, payment.StatusDetailByCollector).Build()
+}
+
+// Refunded returns a payment refunded in full.
+func Refunded(id int64) *payment.Response {
+	return NewResponse(id).WithRefunded(DefaultAmount).Build()
+}
+
+// Refund returns the refund of amount of a payment.
+func Refund(id, paymentID int64, amount money.Amount) *payment.RefundResponse {
+	created := created
+	return &payment.RefundResponse{
+		ID:          id,
+		PaymentID:   paymentID,
+		Amount:      amount,
+		Status:      "approved",
+		DateCreated: &created,
+	}
+}
+
+// SearchResult returns the result of a search finding the payments.
+func SearchResult(payments ...*payment.Response) *payment.SearchResponse {
+	res := &payment.SearchResponse{
+		Results: make([]payment.Response, len(payments)),
+		Paging:  payment.PagingResponse{Total: int64(len(payments)), Limit: int64(max(len(payments), 30))},
+	}
+	for i, p := range payments {
+		res.Results[i] = *p
+	}
+	return res
+}
+
+// NotFound returns the error of the API for an unknown payment.
+func NotFound() error {
+	return &rest.ErrorResponse{
+		StatusCode: http.StatusNotFound,
+		Message:    `{"message":"Payment not found","error":"not_found","status":404,"cause":[]}`,
+	}
+}
@@ -2,6 +2,8 @@ package payment
 
 import (
 	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/money"
 )
 
 // Request represents a request for creating or updating a payment.
@@ -14,7 +16,7 @@ type Request struct {
 	MerchantAccountID     string         `json:"merchant_account_id,omitempty"`
 	NotificationURL       string         `json:"notification_url,omitempty"`
 	PaymentMethodID       string         `json:"payment_method_id,omitempty"`
-	ProcessingMode        string         `json:"processing_mode,omitempty"`
+	ProcessingMode        ProcessingMode `json:"processing_mode,omitempty"`
 	Token                 string         `json:"token,omitempty"`
 	PaymentMethodOptionID string         `json:"payment_method_option_id,omitempty"`
 	StatementDescriptor   string         `json:"statement_descriptor,omitempty"`
@@ -25,10 +27,10 @@ type Request struct {
 	SponsorID             int64          `json:"sponsor_id,omitempty"`
 	BinaryMode            bool           `json:"binary_mode,omitempty"`
 	Capture               bool           `json:"capture,omitempty"`
-	ApplicationFee        float64        `json:"application_fee,omitempty"`
-	CouponAmount          float64        `json:"coupon_amount,omitempty"`
-	NetAmount             float64        `json:"net_amount,omitempty"`
-	TransactionAmount     float64        `json:"transaction_amount,omitempty"`
+	ApplicationFee        money.Amount   `json:"application_fee,omitzero"`
+	CouponAmount          money.Amount   `json:"coupon_amount,omitzero"`
+	NetAmount             money.Amount   `json:"net_amount,omitzero"`
+	TransactionAmount     money.Amount   `json:"transaction_amount,omitzero"`
 	Metadata              map[string]any `json:"metadata,omitempty"`
 
 	DateOfExpiration   *time.Time                 `json:"date_of_expiration,omitempty"`
@@ -108,14 +110,14 @@ type AdditionalInfoBarcodeRequest struct {
 
 // ItemRequest represents an item request within AdditionalInfoRequest.
 type ItemRequest struct {
-	ID          string  `json:"id,omitempty"`
-	Title       string  `json:"title,omitempty"`
-	Description string  `json:"description,omitempty"`
-	PictureURL  string  `json:"picture_url,omitempty"`
-	CategoryID  string  `json:"category_id,omitempty"`
-	Quantity    int     `json:"quantity,omitempty"`
-	UnitPrice   float64 `json:"unit_price,omitempty"`
-	Warranty    bool    `json:"warranty,omitempty"`
+	ID          string       `json:"id,omitempty"`
+	Title       string       `json:"title,omitempty"`
+	Description string       `json:"description,omitempty"`
+	PictureURL  string       `json:"picture_url,omitempty"`
+	CategoryID  string       `json:"category_id,omitempty"`
+	Quantity    int          `json:"quantity,omitempty"`
+	UnitPrice   money.Amount `json:"unit_price,omitzero"`
+	Warranty    bool         `json:"warranty,omitempty"`
 
 	EventDate          *time.Time                 `json:"event_date,omitempty"`
 	CategoryDescriptor *CategoryDescriptorRequest `json:"category_descriptor,omitempty"`
@@ -1,19 +1,22 @@
 package payment
 
 import (
+	"encoding/json"
 	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/money"
 )
 
 // Response is the response from the Payments API.
 type Response struct {
 	DifferentialPricingID     string         `json:"differential_pricing_id,omitempty"`
 	MoneyReleaseSchema        string         `json:"money_release_schema,omitempty"`
-	OperationType             string         `json:"operation_type,omitempty"`
+	OperationType             OperationType  `json:"operation_type,omitempty"`
 	IssuerID                  string         `json:"issuer_id,omitempty"`
 	PaymentMethodID           string         `json:"payment_method_id,omitempty"`
-	PaymentTypeID             string         `json:"payment_type_id,omitempty"`
-	Status                    string         `json:"status,omitempty"`
-	StatusDetail              string         `json:"status_detail,omitempty"`
+	PaymentTypeID             PaymentType    `json:"payment_type_id,omitempty"`
+	Status                    Status         `json:"status,omitempty"`
+	StatusDetail              StatusDetail   `json:"status_detail,omitempty"`
 	CurrencyID                string         `json:"currency_id,omitempty"`
 	Description               string         `json:"description,omitempty"`
 	AuthorizationCode         string         `json:"authorization_code,omitempty"`
@@ -22,7 +25,7 @@ type Response struct {
 	CorporationID             string         `json:"corporation_id,omitempty"`
 	NotificationURL           string         `json:"notification_url,omitempty"`
 	CallbackURL               string         `json:"callback_url,omitempty"`
-	ProcessingMode            string         `json:"processing_mode,omitempty"`
+	ProcessingMode            ProcessingMode `json:"processing_mode,omitempty"`
 	MerchantAccountID         string         `json:"merchant_account_id,omitempty"`
 	MerchantNumber            string         `json:"merchant_number,omitempty"`
 	CouponCode                string         `json:"coupon_code,omitempty"`
@@ -38,12 +41,12 @@ type Response struct {
 	ID                        int64          `json:"id,omitempty"`
 	SponsorID                 int64          `json:"sponsor_id,omitempty"`
 	CollectorID               int64          `json:"collector_id,omitempty"`
-	TransactionAmount         float64        `json:"transaction_amount,omitempty"`
-	TransactionAmountRefunded float64        `json:"transaction_amount_refunded,omitempty"`
-	CouponAmount              float64        `json:"coupon_amount,omitempty"`
-	TaxesAmount               float64        `json:"taxes_amount,omitempty"`
-	ShippingAmount            float64        `json:"shipping_amount,omitempty"`
-	NetAmount                 float64        `json:"net_amount,omitempty"`
+	TransactionAmount         money.Amount   `json:"transaction_amount,omitzero"`
+	TransactionAmountRefunded money.Amount   `json:"transaction_amount_refunded,omitzero"`
+	CouponAmount              money.Amount   `json:"coupon_amount,omitzero"`
+	TaxesAmount               money.Amount   `json:"taxes_amount,omitzero"`
+	ShippingAmount            money.Amount   `json:"shipping_amount,omitzero"`
+	NetAmount                 money.Amount   `json:"net_amount,omitzero"`
 	LiveMode                  bool           `json:"live_mode,omitempty"`
 	Captured                  bool           `json:"captured,omitempty"`
 	BinaryMode                bool           `json:"binary_mode,omitempty"`
@@ -66,6 +69,14 @@ type Response struct {
 	FeeDetails         []FeeDetailResponse         `json:"fee_details,omitempty"`
 	Taxes              []TaxResponse               `json:"taxes,omitempty"`
 	Refunds            []RefundResponse            `json:"refunds,omitempty"`
+
+	// Extra holds the fields of the response the SDK does not model.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// Currency returns the currency of the payment amounts.
+func (r *Response) Currency() money.Currency {
+	return money.Currency(r.CurrencyID)
 }
 
 // PayerResponse represents the payer of the payment.
@@ -78,12 +89,16 @@ type PayerResponse struct {
 	EntityType string `json:"entity_type,omitempty"`
 
 	Identification *IdentificationResponse `json:"identification,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // IdentificationResponse represents payer's personal identification.
 type IdentificationResponse struct {
 	Type   string `json:"type,omitempty"`
 	Number string `json:"number,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // AdditionalInfoResponse represents additional information about a payment.
@@ -93,17 +108,21 @@ type AdditionalInfoResponse struct {
 	Payer     *AdditionalInfoPayerResponse `json:"payer,omitempty"`
 	Shipments *ShipmentsResponse           `json:"shipments,omitempty"`
 	Items     []ItemResponse               `json:"items,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // ItemResponse represents an item.
 type ItemResponse struct {
-	ID          string  `json:"id,omitempty"`
-	Title       string  `json:"title,omitempty"`
-	Description string  `json:"description,omitempty"`
-	PictureURL  string  `json:"picture_url,omitempty"`
-	CategoryID  string  `json:"category_id,omitempty"`
-	Quantity    int     `json:"quantity,omitempty"`
-	UnitPrice   float64 `json:"unit_price,omitempty"`
+	ID          string       `json:"id,omitempty"`
+	Title       string       `json:"title,omitempty"`
+	Description string       `json:"description,omitempty"`
+	PictureURL  string       `json:"picture_url,omitempty"`
+	CategoryID  string       `json:"category_id,omitempty"`
+	Quantity    int          `json:"quantity,omitempty"`
+	UnitPrice   money.Amount `json:"unit_price,omitzero"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // AdditionalInfoPayerResponse represents payer's additional information.
@@ -114,12 +133,16 @@ type AdditionalInfoPayerResponse struct {
 	RegistrationDate *time.Time       `json:"registration_date,omitempty"`
 	Phone            *PhoneResponse   `json:"phone,omitempty"`
 	Address          *AddressResponse `json:"address,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // PhoneResponse represents phone information.
 type PhoneResponse struct {
 	AreaCode string `json:"area_code,omitempty"`
 	Number   string `json:"number,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // AddressResponse represents address information.
@@ -127,11 +150,15 @@ type AddressResponse struct {
 	ZipCode      string `json:"zip_code,omitempty"`
 	StreetName   string `json:"street_name,omitempty"`
 	StreetNumber string `json:"street_number,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // ShipmentsResponse represents shipment information.
 type ShipmentsResponse struct {
 	ReceiverAddress *ReceiverAddressResponse `json:"receiver_address,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // ReceiverAddressResponse represents the receiver's address within ShipmentsResponse.
@@ -142,24 +169,40 @@ type ReceiverAddressResponse struct {
 	Apartment string `json:"apartment,omitempty"`
 
 	Address *AddressResponse `json:"address,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // OrderResponse represents order information.
 type OrderResponse struct {
 	ID   int    `json:"id,omitempty"`
 	Type string `json:"type,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // TransactionDetailsResponse represents transaction details.
 type TransactionDetailsResponse struct {
-	FinancialInstitution     string  `json:"financial_institution,omitempty"`
-	ExternalResourceURL      string  `json:"external_resource_url,omitempty"`
-	PaymentMethodReferenceID string  `json:"payment_method_reference_id,omitempty"`
-	AcquirerReference        string  `json:"acquirer_reference,omitempty"`
-	NetReceivedAmount        float64 `json:"net_received_amount,omitempty"`
-	TotalPaidAmount          float64 `json:"total_paid_amount,omitempty"`
-	InstallmentAmount        float64 `json:"installment_amount,omitempty"`
-	OverpaidAmount           float64 `json:"overpaid_amount,omitempty"`
+	FinancialInstitution     string       `json:"financial_institution,omitempty"`
+	ExternalResourceURL      string       `json:"external_resource_url,omitempty"`
+	PaymentMethodReferenceID string       `json:"payment_method_reference_id,omitempty"`
+	AcquirerReference        string       `json:"acquirer_reference,omitempty"`
+	NetReceivedAmount        money.Amount `json:"net_received_amount,omitzero"`
+	TotalPaidAmount          money.Amount `json:"total_paid_amount,omitzero"`
+	InstallmentAmount        money.Amount `json:"installment_amount,omitzero"`
+	OverpaidAmount           money.Amount `json:"overpaid_amount,omitzero"`
+	DigitableLine            string       `json:"digitable_line,omitempty"`
+
+	Barcode *BarcodeResponse `json:"barcode,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// BarcodeResponse represents the barcode of a boleto within TransactionDetailsResponse.
+type BarcodeResponse struct {
+	Content string `json:"content,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // CardResponse represents card information.
@@ -173,6 +216,8 @@ type CardResponse struct {
 	DateCreated     *time.Time          `json:"date_created,omitempty"`
 	DateLastUpdated *time.Time          `json:"date_last_updated,omitempty"`
 	Cardholder      *CardholderResponse `json:"cardholder,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // CardholderResponse represents cardholder information.
@@ -180,6 +225,8 @@ type CardholderResponse struct {
 	Name string `json:"name,omitempty"`
 
 	Identification *IdentificationResponse `json:"identification,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // PointOfInteractionResponse represents point of interaction information.
@@ -190,12 +237,16 @@ type PointOfInteractionResponse struct {
 
 	ApplicationData *ApplicationDataResponse `json:"application_data,omitempty"`
 	TransactionData *TransactionDataResponse `json:"transaction_data,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // ApplicationDataResponse represents application data within PointOfInteractionResponse.
 type ApplicationDataResponse struct {
 	Name    string `json:"name,omitempty"`
 	Version string `json:"version,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // TransactionDataResponse represents transaction data within PointOfInteractionResponse.
@@ -208,6 +259,8 @@ type TransactionDataResponse struct {
 	FinancialInstitution int64  `json:"financial_institution,omitempty"`
 
 	BankInfo *BankInfoResponse `json:"bank_info,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // BankInfoResponse represents bank information.
@@ -216,6 +269,8 @@ type BankInfoResponse struct {
 
 	Payer     *BankInfoPayerResponse     `json:"payer,omitempty"`
 	Collector *BankInfoCollectorResponse `json:"collector,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // BankInfoPayerResponse represents payer information within BankInfoResponse.
@@ -223,22 +278,30 @@ type BankInfoPayerResponse struct {
 	Email     string `json:"email,omitempty"`
 	LongName  string `json:"long_name,omitempty"`
 	AccountID int64  `json:"account_id,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // BankInfoCollectorResponse represents collector information within BankInfoResponse.
 type BankInfoCollectorResponse struct {
 	LongName  string `json:"long_name,omitempty"`
 	AccountID int64  `json:"account_id,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // PaymentMethodResponse represents payment method information.
 type PaymentMethodResponse struct {
 	Data *DataResponse `json:"data,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // DataResponse represents data within PaymentMethodResponse.
 type DataResponse struct {
 	Rules *RulesResponse `json:"rules,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // RulesResponse represents payment rules.
@@ -246,6 +309,8 @@ type RulesResponse struct {
 	Fine      *FeeResponse       `json:"fine,omitempty"`
 	Interest  *FeeResponse       `json:"interest,omitempty"`
 	Discounts []DiscountResponse `json:"discounts,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // DiscountResponse represents payment discount information.
@@ -254,46 +319,58 @@ type DiscountResponse struct {
 	Value float64 `json:"value,omitempty"`
 
 	LimitDate *time.Time `json:"limit_date,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // FeeResponse represents payment fee information.
 type FeeResponse struct {
 	Type  string  `json:"type,omitempty"`
 	Value float64 `json:"value,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // ThreeDSInfoResponse represents 3DS (Three-Domain Secure) information.
 type ThreeDSInfoResponse struct {
 	ExternalResourceURL string `json:"external_resource_url,omitempty"`
 	Creq                string `json:"creq,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // FeeDetailResponse represents payment fee detail information.
 type FeeDetailResponse struct {
-	Type     string  `json:"type,omitempty"`
-	FeePayer string  `json:"fee_payer,omitempty"`
-	Amount   float64 `json:"amount,omitempty"`
+	Type     string       `json:"type,omitempty"`
+	FeePayer string       `json:"fee_payer,omitempty"`
+	Amount   money.Amount `json:"amount,omitzero"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // TaxResponse represents tax information.
 type TaxResponse struct {
 	Type  string  `json:"type,omitempty"`
 	Value float64 `json:"value,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // RefundResponse represents refund information.
 type RefundResponse struct {
-	Status               string  `json:"status,omitempty"`
-	RefundMode           string  `json:"refund_mode,omitempty"`
-	Reason               string  `json:"reason,omitempty"`
-	UniqueSequenceNumber string  `json:"unique_sequence_number,omitempty"`
-	ID                   int64   `json:"id,omitempty"`
-	PaymentID            int64   `json:"payment_id,omitempty"`
-	Amount               float64 `json:"amount,omitempty"`
-	AdjustmentAmount     float64 `json:"adjustment_amount,omitempty"`
+	Status               string       `json:"status,omitempty"`
+	RefundMode           string       `json:"refund_mode,omitempty"`
+	Reason               string       `json:"reason,omitempty"`
+	UniqueSequenceNumber string       `json:"unique_sequence_number,omitempty"`
+	ID                   int64        `json:"id,omitempty"`
+	PaymentID            int64        `json:"payment_id,omitempty"`
+	Amount               money.Amount `json:"amount,omitzero"`
+	AdjustmentAmount     money.Amount `json:"adjustment_amount,omitzero"`
 
 	DateCreated *time.Time      `json:"date_created,omitempty"`
 	Source      *SourceResponse `json:"source,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // SourceResponse represents source information.
@@ -301,4 +378,6 @@ type SourceResponse struct {
 	ID   string `json:"id,omitempty"`
 	Name string `json:"name,omitempty"`
 	Type string `json:"type,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
@@ -19,6 +19,9 @@ type Filters struct {
 	// It can be, for example, a hashcode from the Central Bank, working as an identifier of the transaction origin.
 	ExternalReference string
 
+	// Status is the status of the payments, e.g. "approved". All statuses if not informed.
+	Status string
+
 	// Range is a field used to define the range of the search.
 	// The Range can be related to the following attributes:
 	//	- "date_created"
@@ -37,4 +40,10 @@ type Filters struct {
 	// Its format can be a relative date - "NOW-XDAYS", "NOW-XMONTHS" - or an absolute date - ISO8601.
 	// If not informed, it uses "NOW" by default.
 	EndDate string
+
+	// Limit is the maximum number of results, 30 if not informed.
+	Limit int64
+
+	// Offset is the number of results to skip, used to page through the results.
+	Offset int64
 }
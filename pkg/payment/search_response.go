@@ -1,9 +1,13 @@
 package payment
 
+import "encoding/json"
+
 // SearchResponse represents the response from the search endpoint.
 type SearchResponse struct {
 	Results []Response     `json:"results"`
 	Paging  PagingResponse `json:"paging"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // PagingResponse represents the paging information within SearchResponse.
@@ -11,4 +15,6 @@ type PagingResponse struct {
 	Total  int64 `json:"total"`
 	Limit  int64 `json:"limit"`
 	Offset int64 `json:"offset"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
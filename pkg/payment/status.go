@@ -0,0 +1,75 @@
+package payment
+
+// Status is the status of a payment.
+// Values unknown to this SDK are kept as they come from the API.
+type Status string
+
+const (
+	StatusPending     Status = "pending"
+	StatusApproved    Status = "approved"
+	StatusAuthorized  Status = "authorized"
+	StatusInProcess   Status = "in_process"
+	StatusInMediation Status = "in_mediation"
+	StatusRejected    Status = "rejected"
+	StatusCancelled   Status = "cancelled"
+	StatusRefunded    Status = "refunded"
+	StatusChargedBack Status = "charged_back"
+)
+
+// String implements fmt.Stringer.
+func (s Status) String() string {
+	return string(s)
+}
+
+// IsKnown reports whether s is one of the statuses defined in this package.
+func (s Status) IsKnown() bool {
+	switch s {
+	case StatusPending, StatusApproved, StatusAuthorized, StatusInProcess, StatusInMediation,
+		StatusRejected, StatusCancelled, StatusRefunded, StatusChargedBack:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsFinal reports whether the payment will not change status without a new operation, such as a refund.
+func (s Status) IsFinal() bool {
+	switch s {
+	case StatusApproved, StatusRejected, StatusCancelled, StatusRefunded, StatusChargedBack:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsApproved reports whether the payment was approved and credited.
+func (s Status) IsApproved() bool {
+	return s == StatusApproved
+}
+
+// IsRejected reports whether the payment was rejected.
+func (s Status) IsRejected() bool {
+	return s == StatusRejected
+}
+
+// NeedsAction reports whether the payment waits for the payer or the collector,
+// e.g. to pay a boleto, to complete a challenge, to capture or to answer a dispute.
+func (s Status) NeedsAction() bool {
+	switch s {
+	case StatusPending, StatusAuthorized, StatusInMediation:
+		return true
+	default:
+		return false
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Status) MarshalText() ([]byte, error) {
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, keeping unknown values.
+func (s *Status) UnmarshalText(b []byte) error {
+	*s = Status(b)
+	return nil
+}
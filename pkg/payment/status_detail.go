@@ -0,0 +1,349 @@
+package payment
+
+import "strings"
+
+// StatusDetail details the status of a payment, e.g. why it was rejected.
+// Values unknown to this SDK are kept as they come from the API.
+type StatusDetail string
+
+const (
+	StatusDetailAccredited                       StatusDetail = "accredited"
+	StatusDetailPartiallyRefunded                StatusDetail = "partially_refunded"
+	StatusDetailPendingCapture                   StatusDetail = "pending_capture"
+	StatusDetailPendingContingency               StatusDetail = "pending_contingency"
+	StatusDetailPendingReviewManual              StatusDetail = "pending_review_manual"
+	StatusDetailPendingWaitingPayment            StatusDetail = "pending_waiting_payment"
+	StatusDetailPendingWaitingTransfer           StatusDetail = "pending_waiting_transfer"
+	StatusDetailPendingChallenge                 StatusDetail = "pending_challenge"
+	StatusDetailCCRejectedBadFilledCardNumber    StatusDetail = "cc_rejected_bad_filled_card_number"
+	StatusDetailCCRejectedBadFilledDate          StatusDetail = "cc_rejected_bad_filled_date"
+	StatusDetailCCRejectedBadFilledOther         StatusDetail = "cc_rejected_bad_filled_other"
+	StatusDetailCCRejectedBadFilledSecurityCode  StatusDetail = "cc_rejected_bad_filled_security_code"
+	StatusDetailCCRejectedBlacklist              StatusDetail = "cc_rejected_blacklist"
+	StatusDetailCCRejectedCallForAuthorize       StatusDetail = "cc_rejected_call_for_authorize"
+	StatusDetailCCRejectedCardDisabled           StatusDetail = "cc_rejected_card_disabled"
+	StatusDetailCCRejectedCardError              StatusDetail = "cc_rejected_card_error"
+	StatusDetailCCRejectedDuplicatedPayment      StatusDetail = "cc_rejected_duplicated_payment"
+	StatusDetailCCRejectedHighRisk               StatusDetail = "cc_rejected_high_risk"
+	StatusDetailCCRejectedInsufficientAmount     StatusDetail = "cc_rejected_insufficient_amount"
+	StatusDetailCCRejectedInvalidInstallments    StatusDetail = "cc_rejected_invalid_installments"
+	StatusDetailCCRejectedMaxAttempts            StatusDetail = "cc_rejected_max_attempts"
+	StatusDetailCCRejectedOtherReason            StatusDetail = "cc_rejected_other_reason"
+	StatusDetailCCRejected3DSMandatory           StatusDetail = "cc_rejected_3ds_mandatory"
+	StatusDetailCCRejected3DSChallenge           StatusDetail = "cc_rejected_3ds_challenge"
+	StatusDetailCCAmountRateLimitExceeded        StatusDetail = "cc_amount_rate_limit_exceeded"
+	StatusDetailRejectedInsufficientData         StatusDetail = "rejected_insufficient_data"
+	StatusDetailRejectedByBank                   StatusDetail = "rejected_by_bank"
+	StatusDetailRejectedByRegulations            StatusDetail = "rejected_by_regulations"
+	StatusDetailRejectedHighRisk                 StatusDetail = "rejected_high_risk"
+	StatusDetailBankError                        StatusDetail = "bank_error"
+	StatusDetailExpired                          StatusDetail = "expired"
+	StatusDetailByCollector                      StatusDetail = "by_collector"
+	StatusDetailByPayer                          StatusDetail = "by_payer"
+	StatusDetailRefunded                         StatusDetail = "refunded"
+	StatusDetailSettled                          StatusDetail = "settled"
+	StatusDetailReimbursed                       StatusDetail = "reimbursed"
+	StatusDetailBuyerProtectionRefunded          StatusDetail = "bpp_refunded"
+	StatusDetailInMediation                      StatusDetail = "in_mediation"
+	StatusDetailPendingReviewManualDocumentation StatusDetail = "pending_review_manual_documentation"
+)
+
+// Language is the language of the messages returned by StatusDetail.Message.
+type Language string
+
+const (
+	LanguagePortuguese Language = "pt"
+	LanguageSpanish    Language = "es"
+	LanguageEnglish    Language = "en"
+)
+
+// statusDetailInfo describes a status detail.
+type statusDetailInfo struct {
+	rejected    bool
+	needsAction bool
+
+	pt, es, en string
+}
+
+var statusDetails = map[StatusDetail]statusDetailInfo{
+	StatusDetailAccredited: {
+		pt: "Pronto, seu pagamento foi aprovado!",
+		es: "¡Listo! Se acreditó tu pago.",
+		en: "Done! Your payment was approved.",
+	},
+	StatusDetailPartiallyRefunded: {
+		pt: "O pagamento foi parcialmente devolvido.",
+		es: "El pago fue devuelto parcialmente.",
+		en: "The payment was partially refunded.",
+	},
+	StatusDetailPendingCapture: {
+		needsAction: true,
+		pt:          "O pagamento foi autorizado e aguarda a captura.",
+		es:          "El pago fue autorizado y espera la captura.",
+		en:          "The payment was authorized and is waiting to be captured.",
+	},
+	StatusDetailPendingContingency: {
+		pt: "Estamos processando o pagamento. Em até 2 dias úteis informaremos o resultado.",
+		es: "Estamos procesando tu pago. En menos de 2 días hábiles te avisaremos el resultado.",
+		en: "We are processing your payment. You will be notified of the result within 2 business days.",
+	},
+	StatusDetailPendingReviewManual: {
+		pt: "Estamos processando o pagamento. Em até 2 dias úteis informaremos se foi aprovado ou se precisamos de mais informações.",
+		es: "Estamos procesando tu pago. En menos de 2 días hábiles te diremos si se acreditó o si necesitamos más información.",
+		en: "We are processing your payment. Within 2 business days we will tell you whether it was approved or if we need more information.",
+	},
+	StatusDetailPendingReviewManualDocumentation: {
+		needsAction: true,
+		pt:          "O pagamento está em revisão e aguarda documentação.",
+		es:          "El pago está en revisión y espera documentación.",
+		en:          "The payment is under review and waiting for documentation.",
+	},
+	StatusDetailPendingWaitingPayment: {
+		needsAction: true,
+		pt:          "Aguardando o pagamento pelo comprador.",
+		es:          "Esperando que el comprador realice el pago.",
+		en:          "Waiting for the payer to pay.",
+	},
+	StatusDetailPendingWaitingTransfer: {
+		needsAction: true,
+		pt:          "Aguardando o comprador concluir a transferência bancária.",
+		es:          "Esperando que el comprador complete la transferencia bancaria.",
+		en:          "Waiting for the payer to complete the bank transfer.",
+	},
+	StatusDetailPendingChallenge: {
+		needsAction: true,
+		pt:          "Aguardando o comprador concluir a autenticação 3DS.",
+		es:          "Esperando que el comprador complete la autenticación 3DS.",
+		en:          "Waiting for the payer to complete the 3DS challenge.",
+	},
+	StatusDetailCCRejectedBadFilledCardNumber: {
+		rejected: true,
+		pt:       "Revise o número do cartão.",
+		es:       "Revisa el número de tarjeta.",
+		en:       "Check the card number.",
+	},
+	StatusDetailCCRejectedBadFilledDate: {
+		rejected: true,
+		pt:       "Revise a data de vencimento.",
+		es:       "Revisa la fecha de vencimiento.",
+		en:       "Check the expiration date.",
+	},
+	StatusDetailCCRejectedBadFilledOther: {
+		rejected: true,
+		pt:       "Revise os dados do cartão.",
+		es:       "Revisa los datos de la tarjeta.",
+		en:       "Check the card details.",
+	},
+	StatusDetailCCRejectedBadFilledSecurityCode: {
+		rejected: true,
+		pt:       "Revise o código de segurança do cartão.",
+		es:       "Revisa el código de seguridad de la tarjeta.",
+		en:       "Check the card security code.",
+	},
+	StatusDetailCCRejectedBlacklist: {
+		rejected: true,
+		pt:       "Não pudemos processar seu pagamento.",
+		es:       "No pudimos procesar tu pago.",
+		en:       "We could not process your payment.",
+	},
+	StatusDetailCCRejectedCallForAuthorize: {
+		rejected:    true,
+		needsAction: true,
+		pt:          "Você deve autorizar o pagamento junto ao emissor do cartão.",
+		es:          "Debes autorizar el pago ante el emisor de la tarjeta.",
+		en:          "You must authorize the payment with your card issuer.",
+	},
+	StatusDetailCCRejectedCardDisabled: {
+		rejected: true,
+		pt:       "Ligue para o emissor do cartão para ativá-lo.",
+		es:       "Llama al emisor de la tarjeta para activarla.",
+		en:       "Call your card issuer to activate your card.",
+	},
+	StatusDetailCCRejectedCardError: {
+		rejected: true,
+		pt:       "Não conseguimos processar seu pagamento.",
+		es:       "No pudimos procesar tu pago.",
+		en:       "We could not process your payment.",
+	},
+	StatusDetailCCRejectedDuplicatedPayment: {
+		rejected: true,
+		pt:       "Você já efetuou um pagamento com esse valor. Caso precise pagar novamente, utilize outro cartão ou outra forma de pagamento.",
+		es:       "Ya hiciste un pago por ese valor. Si necesitas volver a pagar, usa otra tarjeta u otro medio de pago.",
+		en:       "You already made a payment for this amount. If you need to pay again, use another card or payment method.",
+	},
+	StatusDetailCCRejectedHighRisk: {
+		rejected: true,
+		pt:       "Seu pagamento foi recusado. Escolha outra forma de pagamento.",
+		es:       "Tu pago fue rechazado. Elige otro de los medios de pago.",
+		en:       "Your payment was declined. Choose another payment method.",
+	},
+	StatusDetailCCRejectedInsufficientAmount: {
+		rejected: true,
+		pt:       "O cartão possui saldo insuficiente.",
+		es:       "La tarjeta no tiene fondos suficientes.",
+		en:       "The card has insufficient funds.",
+	},
+	StatusDetailCCRejectedInvalidInstallments: {
+		rejected: true,
+		pt:       "O cartão não processa pagamentos nessa quantidade de parcelas.",
+		es:       "La tarjeta no procesa pagos en esa cantidad de cuotas.",
+		en:       "The card does not process payments in this number of installments.",
+	},
+	StatusDetailCCRejectedMaxAttempts: {
+		rejected: true,
+		pt:       "Você atingiu o limite de tentativas permitidas. Escolha outro cartão ou outra forma de pagamento.",
+		es:       "Llegaste al límite de intentos permitidos. Elige otra tarjeta u otro medio de pago.",
+		en:       "You reached the limit of allowed attempts. Choose another card or payment method.",
+	},
+	StatusDetailCCRejectedOtherReason: {
+		rejected: true,
+		pt:       "O emissor do cartão não processou o pagamento.",
+		es:       "El emisor de la tarjeta no procesó el pago.",
+		en:       "The card issuer did not process the payment.",
+	},
+	StatusDetailCCRejected3DSMandatory: {
+		rejected: true,
+		pt:       "O pagamento exige autenticação 3DS.",
+		es:       "El pago requiere autenticación 3DS.",
+		en:       "The payment requires 3DS authentication.",
+	},
+	StatusDetailCCRejected3DSChallenge: {
+		rejected: true,
+		pt:       "O comprador não concluiu a autenticação 3DS.",
+		es:       "El comprador no completó la autenticación 3DS.",
+		en:       "The payer did not complete the 3DS challenge.",
+	},
+	StatusDetailCCAmountRateLimitExceeded: {
+		rejected: true,
+		pt:       "O pagamento ultrapassou o limite da forma de pagamento.",
+		es:       "El pago superó el límite del medio de pago.",
+		en:       "The payment exceeded the limit of the payment method.",
+	},
+	StatusDetailRejectedInsufficientData: {
+		rejected: true,
+		pt:       "O pagamento foi recusado por falta de informações obrigatórias.",
+		es:       "El pago fue rechazado por falta de información obligatoria.",
+		en:       "The payment was rejected because of missing required information.",
+	},
+	StatusDetailRejectedByBank: {
+		rejected: true,
+		pt:       "O pagamento foi recusado pelo banco.",
+		es:       "El pago fue rechazado por el banco.",
+		en:       "The payment was rejected by the bank.",
+	},
+	StatusDetailRejectedByRegulations: {
+		rejected: true,
+		pt:       "O pagamento foi recusado por questões regulatórias.",
+		es:       "El pago fue rechazado por regulaciones.",
+		en:       "The payment was rejected due to regulations.",
+	},
+	StatusDetailRejectedHighRisk: {
+		rejected: true,
+		pt:       "O pagamento foi recusado por risco de fraude.",
+		es:       "El pago fue rechazado por riesgo de fraude.",
+		en:       "The payment was rejected due to fraud risk.",
+	},
+	StatusDetailBankError: {
+		rejected: true,
+		pt:       "O pagamento foi recusado por um erro no banco.",
+		es:       "El pago fue rechazado por un error del banco.",
+		en:       "The payment was rejected due to a bank error.",
+	},
+	StatusDetailExpired: {
+		pt: "O pagamento expirou sem ser pago.",
+		es: "El pago venció sin ser pagado.",
+		en: "The payment expired without being paid.",
+	},
+	StatusDetailByCollector: {
+		pt: "O pagamento foi cancelado pelo vendedor.",
+		es: "El pago fue cancelado por el vendedor.",
+		en: "The payment was cancelled by the collector.",
+	},
+	StatusDetailByPayer: {
+		pt: "O pagamento foi cancelado pelo comprador.",
+		es: "El pago fue cancelado por el comprador.",
+		en: "The payment was cancelled by the payer.",
+	},
+	StatusDetailRefunded: {
+		pt: "O pagamento foi devolvido.",
+		es: "El pago fue devuelto.",
+		en: "The payment was refunded.",
+	},
+	StatusDetailSettled: {
+		pt: "O estorno foi concluído e o valor debitado do vendedor.",
+		es: "El contracargo fue resuelto y el dinero fue debitado al vendedor.",
+		en: "The chargeback was settled and the money was debited from the collector.",
+	},
+	StatusDetailReimbursed: {
+		pt: "O estorno foi resolvido a favor do vendedor.",
+		es: "El contracargo fue resuelto a favor del vendedor.",
+		en: "The chargeback was resolved in favor of the collector.",
+	},
+	StatusDetailBuyerProtectionRefunded: {
+		pt: "O pagamento foi devolvido pelo Programa de Proteção ao Comprador.",
+		es: "El pago fue devuelto por el Programa de Protección al Comprador.",
+		en: "The payment was refunded by the Buyer Protection Program.",
+	},
+	StatusDetailInMediation: {
+		needsAction: true,
+		pt:          "O pagamento está em disputa.",
+		es:          "El pago está en disputa.",
+		en:          "The payment is in dispute.",
+	},
+}
+
+// String implements fmt.Stringer.
+func (d StatusDetail) String() string {
+	return string(d)
+}
+
+// IsKnown reports whether d is one of the status details defined in this package.
+func (d StatusDetail) IsKnown() bool {
+	_, ok := statusDetails[d]
+	return ok
+}
+
+// IsRejected reports whether the status detail is a rejection reason.
+func (d StatusDetail) IsRejected() bool {
+	if info, ok := statusDetails[d]; ok {
+		return info.rejected
+	}
+	return strings.HasPrefix(string(d), "cc_rejected_") || strings.HasPrefix(string(d), "rejected_")
+}
+
+// NeedsAction reports whether the payment waits for the payer or the collector to do something.
+func (d StatusDetail) NeedsAction() bool {
+	return statusDetails[d].needsAction
+}
+
+// Message returns a human-readable description of the status detail in the language,
+// such as "pt", "es" or "en". Regional variants, like "pt-BR", use their base language,
+// unsupported languages fall back to English, and unknown status details are returned as is.
+func (d StatusDetail) Message(lang Language) string {
+	info, ok := statusDetails[d]
+	if !ok {
+		return string(d)
+	}
+
+	base, _, _ := strings.Cut(strings.ToLower(string(lang)), "-")
+	base, _, _ = strings.Cut(base, "_")
+	switch Language(base) {
+	case LanguagePortuguese:
+		return info.pt
+	case LanguageSpanish:
+		return info.es
+	default:
+		return info.en
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d StatusDetail) MarshalText() ([]byte, error) {
+	return []byte(d), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, keeping unknown values.
+func (d *StatusDetail) UnmarshalText(b []byte) error {
+	*d = StatusDetail(b)
+	return nil
+}
@@ -0,0 +1,91 @@
+package payment
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestStatusPredicates(t *testing.T) {
+	tests := []struct {
+		status      Status
+		final       bool
+		approved    bool
+		rejected    bool
+		needsAction bool
+	}{
+		{status: StatusPending, needsAction: true},
+		{status: StatusApproved, final: true, approved: true},
+		{status: StatusAuthorized, needsAction: true},
+		{status: StatusInProcess},
+		{status: StatusInMediation, needsAction: true},
+		{status: StatusRejected, final: true, rejected: true},
+		{status: StatusCancelled, final: true},
+		{status: StatusRefunded, final: true},
+		{status: StatusChargedBack, final: true},
+		{status: Status("some_new_status")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status.String(), func(t *testing.T) {
+			if got := tt.status.IsFinal(); got != tt.final {
+				t.Errorf("Status.IsFinal() = %v, want %v", got, tt.final)
+			}
+			if got := tt.status.IsApproved(); got != tt.approved {
+				t.Errorf("Status.IsApproved() = %v, want %v", got, tt.approved)
+			}
+			if got := tt.status.IsRejected(); got != tt.rejected {
+				t.Errorf("Status.IsRejected() = %v, want %v", got, tt.rejected)
+			}
+			if got := tt.status.NeedsAction(); got != tt.needsAction {
+				t.Errorf("Status.NeedsAction() = %v, want %v", got, tt.needsAction)
+			}
+		})
+	}
+}
+
+func TestStatusDetailMessage(t *testing.T) {
+	tests := []struct {
+		name   string
+		detail StatusDetail
+		lang   Language
+		want   string
+	}{
+		{name: "english", detail: StatusDetailCCRejectedInsufficientAmount, lang: LanguageEnglish, want: "The card has insufficient funds."},
+		{name: "portuguese_region", detail: StatusDetailCCRejectedInsufficientAmount, lang: "pt-BR", want: "O cartão possui saldo insuficiente."},
+		{name: "spanish_region", detail: StatusDetailCCRejectedInsufficientAmount, lang: "es_AR", want: "La tarjeta no tiene fondos suficientes."},
+		{name: "unsupported_language", detail: StatusDetailExpired, lang: "fr", want: "The payment expired without being paid."},
+		{name: "unknown_detail", detail: "some_new_detail", lang: LanguageEnglish, want: "some_new_detail"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.detail.Message(tt.lang); got != tt.want {
+				t.Errorf("StatusDetail.Message() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	for detail, info := range statusDetails {
+		if info.pt == "" || info.es == "" || info.en == "" {
+			t.Errorf("status detail %q is missing a message", detail)
+		}
+	}
+}
+
+func TestResponseKeepsUnknownValues(t *testing.T) {
+	body := `{"payment_type_id":"some_new_type","status":"some_new_status","status_detail":"some_new_detail"}`
+
+	res := &Response{}
+	if err := json.Unmarshal([]byte(body), res); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if res.Status.IsKnown() || res.StatusDetail.IsKnown() {
+		t.Errorf("unknown values reported as known: %q, %q", res.Status, res.StatusDetail)
+	}
+
+	b, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if string(b) != body {
+		t.Errorf("json.Marshal() = %s, want %s", b, body)
+	}
+}
@@ -1,12 +1,19 @@
 package payment
 
+import "github.com/gdeandradero/sdk-go/pkg/money"
+
 // CancelRequest represents a payment cancellation request.
 type CancelRequest struct {
-	Status string `json:"status,omitempty"`
+	Status Status `json:"status,omitempty"`
 }
 
 // CaptureRequest represents a payment capture request.
 type CaptureRequest struct {
-	TransactionAmount float64 `json:"transaction_amount,omitempty"`
-	Capture           bool    `json:"capture"`
+	TransactionAmount money.Amount `json:"transaction_amount,omitzero"`
+	Capture           bool         `json:"capture"`
+}
+
+// RefundRequest represents a partial refund request.
+type RefundRequest struct {
+	Amount money.Amount `json:"amount,omitzero"`
 }
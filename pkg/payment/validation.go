@@ -0,0 +1,355 @@
+package payment
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+	"github.com/gdeandradero/sdk-go/pkg/paymentmethod"
+)
+
+const (
+	pixMinExpiration = 30 * time.Minute
+	pixMaxExpiration = 30 * 24 * time.Hour
+)
+
+// ErrInvalidRequest is matched, with errors.Is, by the errors returned by Validate.
+var ErrInvalidRequest = errors.New("invalid request")
+
+// now is replaced in tests.
+var now = time.Now
+
+// boletoMethods and cardMethods are used to guess the payment type when the payment method is not given.
+var (
+	boletoMethods = map[string]bool{"bolbradesco": true, "boleto": true, "pec": true}
+	cardMethods   = map[string]bool{
+		"visa": true, "master": true, "amex": true, "elo": true, "hipercard": true, "cabal": true,
+		"naranja": true, "diners": true, "debvisa": true, "debmaster": true, "debelo": true, "maestro": true,
+	}
+)
+
+// FieldError is a validation error of a single field.
+type FieldError struct {
+	// Field is the JSON path of the field, e.g. "payer.identification.number".
+	Field   string
+	Message string
+}
+
+// Error implements error.
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationError lists every invalid field of a request.
+type ValidationError struct {
+	Errors []*FieldError
+}
+
+// Error implements error.
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		msgs[i] = fe.Error()
+	}
+	return ErrInvalidRequest.Error() + ": " + strings.Join(msgs, "; ")
+}
+
+// Is reports whether target is ErrInvalidRequest.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalidRequest
+}
+
+// Unwrap returns the field errors.
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, fe := range e.Errors {
+		errs[i] = fe
+	}
+	return errs
+}
+
+// validator collects field errors.
+type validator struct {
+	errors []*FieldError
+
+	// now is the instant the dates are validated against.
+	now time.Time
+}
+
+func newValidator(now time.Time) *validator {
+	return &validator{now: now}
+}
+
+func (v *validator) add(field, format string, args ...any) {
+	v.errors = append(v.errors, &FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) required(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.add(field, "is required")
+	}
+}
+
+func (v *validator) err() error {
+	if len(v.errors) == 0 {
+		return nil
+	}
+	return &ValidationError{Errors: v.errors}
+}
+
+func join(prefix, field string) string {
+	if prefix == "" {
+		return field
+	}
+	return prefix + "." + field
+}
+
+type validationKey struct{}
+
+// WithValidation makes Create validate the request before calling the API.
+// When the payment methods are given, for instance from paymentmethod.Client.List,
+// the one of the request is used for its payment type and allowed amounts.
+func WithValidation(methods ...paymentmethod.Response) rest.Option {
+	return rest.WithValue(validationKey{}, methods)
+}
+
+// validateRequest validates the request if WithValidation is among opts.
+func validateRequest(opts []rest.Option, dto *Request) error {
+	methods, ok := rest.Value(opts, validationKey{}).([]paymentmethod.Response)
+	if !ok {
+		return nil
+	}
+	return dto.ValidateWith(findMethod(methods, dto.PaymentMethodID))
+}
+
+// Validate validates the request, guessing the payment type from its payment method ID.
+// It returns a *ValidationError listing every invalid field.
+func (r *Request) Validate() error {
+	return r.ValidateWith(nil)
+}
+
+// ValidateWith validates the request for the payment method, which gives the payment type
+// and the allowed amounts. It returns a *ValidationError listing every invalid field.
+func (r *Request) ValidateWith(pm *paymentmethod.Response) error {
+	return r.validateAt(pm, now())
+}
+
+// validateAt validates the request as of at, e.g. the instant Build computed the expiration from.
+func (r *Request) validateAt(pm *paymentmethod.Response, at time.Time) error {
+	v := newValidator(at)
+	r.validate(v, pm)
+	return v.err()
+}
+
+func (r *Request) validate(v *validator, pm *paymentmethod.Response) {
+	v.required("payment_method_id", r.PaymentMethodID)
+	if !r.TransactionAmount.IsPositive() {
+		v.add("transaction_amount", "must be positive, got %s", r.TransactionAmount)
+	}
+	if r.Installments < 0 {
+		v.add("installments", "must not be negative, got %d", r.Installments)
+	}
+	if r.ApplicationFee.IsNegative() {
+		v.add("application_fee", "must not be negative, got %s", r.ApplicationFee)
+	} else if r.ApplicationFee.Cmp(r.TransactionAmount) >= 0 && r.ApplicationFee.IsPositive() {
+		v.add("application_fee", "must be less than transaction_amount %s, got %s", r.TransactionAmount, r.ApplicationFee)
+	}
+	if r.CouponAmount.IsNegative() {
+		v.add("coupon_amount", "must not be negative, got %s", r.CouponAmount)
+	}
+	if r.DateOfExpiration != nil && !r.DateOfExpiration.After(v.now) {
+		v.add("date_of_expiration", "must be in the future, got %s", r.DateOfExpiration.Format(time.RFC3339))
+	}
+
+	if pm != nil {
+		if pm.MinAllowedAmount.IsPositive() && r.TransactionAmount.Cmp(pm.MinAllowedAmount) < 0 {
+			v.add("transaction_amount", "must be at least %s for %s, got %s", pm.MinAllowedAmount, pm.ID, r.TransactionAmount)
+		}
+		if pm.MaxAllowedAmount.IsPositive() && r.TransactionAmount.Cmp(pm.MaxAllowedAmount) > 0 {
+			v.add("transaction_amount", "must be at most %s for %s, got %s", pm.MaxAllowedAmount, pm.ID, r.TransactionAmount)
+		}
+	}
+
+	switch paymentType := paymentTypeOf(r, pm); {
+	case r.PaymentMethodID == "pix":
+		r.validatePix(v)
+	case paymentType == PaymentTypeTicket:
+		r.validateBoleto(v)
+	case paymentType.IsCard():
+		r.validateCard(v)
+	}
+
+	if r.Payer != nil {
+		r.Payer.validate(v, "payer")
+	}
+	if r.AdditionalInfo != nil {
+		r.AdditionalInfo.validate(v, "additional_info")
+	}
+	if r.PaymentMethod != nil && r.PaymentMethod.Data != nil && r.PaymentMethod.Data.Rules != nil {
+		for i, d := range r.PaymentMethod.Data.Rules.Discounts {
+			field := fmt.Sprintf("payment_method.data.rules.discounts[%d].limit_date", i)
+			if d.LimitDate != nil && r.DateOfExpiration != nil && d.LimitDate.After(*r.DateOfExpiration) {
+				v.add(field, "must not be after date_of_expiration")
+			}
+		}
+	}
+}
+
+func (r *Request) validateCard(v *validator) {
+	v.required("token", r.Token)
+	v.required("issuer_id", r.IssuerID)
+	if r.Installments < 1 {
+		v.add("installments", "is required for card payments")
+	}
+}
+
+func (r *Request) validatePix(v *validator) {
+	if r.Payer == nil || strings.TrimSpace(r.Payer.Email) == "" {
+		v.add("payer.email", "is required for pix payments")
+	}
+	if r.DateOfExpiration != nil {
+		if ttl := r.DateOfExpiration.Sub(v.now); ttl > 0 && (ttl < pixMinExpiration || ttl > pixMaxExpiration) {
+			v.add("date_of_expiration", "must be between 30 minutes and 30 days from now for pix payments")
+		}
+	}
+}
+
+func (r *Request) validateBoleto(v *validator) {
+	if r.Payer == nil {
+		v.add("payer", "is required for boleto payments")
+		return
+	}
+	v.required("payer.first_name", r.Payer.FirstName)
+	v.required("payer.last_name", r.Payer.LastName)
+	if r.Payer.Identification == nil {
+		v.add("payer.identification", "is required for boleto payments")
+	}
+	if r.Payer.Address == nil {
+		v.add("payer.address", "is required for boleto payments")
+		return
+	}
+	a := r.Payer.Address
+	v.required("payer.address.zip_code", a.ZipCode)
+	v.required("payer.address.street_name", a.StreetName)
+	v.required("payer.address.street_number", a.StreetNumber)
+	v.required("payer.address.neighborhood", a.Neighborhood)
+	v.required("payer.address.city", a.City)
+	v.required("payer.address.federal_unit", a.FederalUnit)
+}
+
+// paymentTypeOf returns the payment type of the payment method, guessing it from the request if pm is nil.
+func paymentTypeOf(r *Request, pm *paymentmethod.Response) PaymentType {
+	switch {
+	case pm != nil:
+		return PaymentType(pm.PaymentTypeID)
+	case r.PaymentMethodID == "pix":
+		return PaymentTypeBankTransfer
+	case r.PaymentMethodID == "account_money":
+		return PaymentTypeAccountMoney
+	case boletoMethods[r.PaymentMethodID]:
+		return PaymentTypeTicket
+	case r.Token != "" || cardMethods[r.PaymentMethodID]:
+		return PaymentTypeCreditCard
+	default:
+		return ""
+	}
+}
+
+// findMethod returns the payment method with the ID, or nil.
+func findMethod(methods []paymentmethod.Response, id string) *paymentmethod.Response {
+	for i := range methods {
+		if methods[i].ID == id {
+			return &methods[i]
+		}
+	}
+	return nil
+}
+
+// Validate validates the payer. It returns a *ValidationError listing every invalid field.
+func (p *PayerRequest) Validate() error {
+	v := newValidator(now())
+	p.validate(v, "")
+	return v.err()
+}
+
+func (p *PayerRequest) validate(v *validator, prefix string) {
+	if p.Email != "" {
+		if _, err := mail.ParseAddress(p.Email); err != nil {
+			v.add(join(prefix, "email"), "is not a valid email address")
+		}
+	}
+	if p.Identification != nil {
+		p.Identification.validate(v, join(prefix, "identification"))
+	}
+}
+
+// Validate validates the identification. It returns a *ValidationError listing every invalid field.
+func (i *IdentificationRequest) Validate() error {
+	v := newValidator(now())
+	i.validate(v, "")
+	return v.err()
+}
+
+func (i *IdentificationRequest) validate(v *validator, prefix string) {
+	v.required(join(prefix, "type"), i.Type)
+	v.required(join(prefix, "number"), i.Number)
+}
+
+// Validate validates the additional information. It returns a *ValidationError listing every invalid field.
+func (a *AdditionalInfoRequest) Validate() error {
+	v := newValidator(now())
+	a.validate(v, "")
+	return v.err()
+}
+
+func (a *AdditionalInfoRequest) validate(v *validator, prefix string) {
+	if a.Payer != nil {
+		if d := a.Payer.RegistrationDate; d != nil && d.After(v.now) {
+			v.add(join(prefix, "payer.registration_date"), "must not be in the future")
+		}
+		if d := a.Payer.LastPurchase; d != nil && d.After(v.now) {
+			v.add(join(prefix, "payer.last_purchase"), "must not be in the future")
+		}
+	}
+	for i := range a.Items {
+		a.Items[i].validate(v, join(prefix, fmt.Sprintf("items[%d]", i)))
+	}
+}
+
+// Validate validates the item. It returns a *ValidationError listing every invalid field.
+func (i *ItemRequest) Validate() error {
+	v := newValidator(now())
+	i.validate(v, "")
+	return v.err()
+}
+
+func (i *ItemRequest) validate(v *validator, prefix string) {
+	if i.Quantity < 0 {
+		v.add(join(prefix, "quantity"), "must not be negative, got %d", i.Quantity)
+	}
+	if i.UnitPrice.IsNegative() {
+		v.add(join(prefix, "unit_price"), "must not be negative, got %s", i.UnitPrice)
+	}
+
+	route := i.route()
+	if route != nil && route.DepartureDateTime != nil && route.ArrivalDateTime != nil &&
+		route.ArrivalDateTime.Before(*route.DepartureDateTime) {
+		v.add(join(prefix, "category_descriptor.route.arrival_date_time"), "must not be before departure_date_time")
+	}
+	if route != nil && route.DepartureDateTime == nil && route.ArrivalDateTime != nil {
+		v.add(join(prefix, "category_descriptor.route.departure_date_time"), "is required with arrival_date_time")
+	}
+	if i.CategoryDescriptor != nil && i.CategoryDescriptor.Passenger != nil && i.CategoryDescriptor.Passenger.Identification != nil {
+		i.CategoryDescriptor.Passenger.Identification.validate(v, join(prefix, "category_descriptor.passenger.identification"))
+	}
+}
+
+func (i *ItemRequest) route() *RouteRequest {
+	if i.CategoryDescriptor == nil {
+		return nil
+	}
+	return i.CategoryDescriptor.Route
+}
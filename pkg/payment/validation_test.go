@@ -0,0 +1,184 @@
+package payment
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/money"
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+	"github.com/gdeandradero/sdk-go/pkg/paymentmethod"
+)
+
+func TestRequestValidate(t *testing.T) {
+	fixed := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return fixed }
+	defer func() { now = time.Now }()
+
+	in := func(d time.Duration) *time.Time {
+		t := fixed.Add(d)
+		return &t
+	}
+
+	tests := []struct {
+		name    string
+		request Request
+		method  *paymentmethod.Response
+		want    []string
+	}{
+		{
+			name: "valid_card",
+			request: Request{
+				PaymentMethodID:   "visa",
+				TransactionAmount: money.FromInt(100),
+				Token:             "token",
+				IssuerID:          "25",
+				Installments:      1,
+				Payer:             &PayerRequest{Email: "payer@example.com"},
+			},
+		},
+		{
+			name: "card_missing_fields",
+			request: Request{
+				PaymentMethodID:   "master",
+				TransactionAmount: money.FromInt(100),
+			},
+			want: []string{"token", "issuer_id", "installments"},
+		},
+		{
+			name: "pix_missing_email",
+			request: Request{
+				PaymentMethodID:   "pix",
+				TransactionAmount: money.FromInt(100),
+				DateOfExpiration:  in(10 * time.Minute),
+			},
+			want: []string{"payer.email", "date_of_expiration"},
+		},
+		{
+			name: "boleto_missing_identification_and_address",
+			request: Request{
+				PaymentMethodID:   "bolbradesco",
+				TransactionAmount: money.FromInt(100),
+				Payer:             &PayerRequest{Email: "payer@example.com", FirstName: "John", LastName: "Doe"},
+			},
+			want: []string{"payer.identification", "payer.address"},
+		},
+		{
+			name: "boleto_incomplete_address",
+			request: Request{
+				PaymentMethodID:   "bolbradesco",
+				TransactionAmount: money.FromInt(100),
+				Payer: &PayerRequest{
+					FirstName:      "John",
+					LastName:       "Doe",
+					Identification: &IdentificationRequest{Type: "CPF"},
+					Address:        &PayerAddressRequest{ZipCode: "06233200", StreetName: "Av. das Nações Unidas", StreetNumber: "3003", City: "Osasco"},
+				},
+			},
+			want: []string{"payer.address.neighborhood", "payer.address.federal_unit", "payer.identification.number"},
+		},
+		{
+			name: "amounts_and_dates",
+			request: Request{
+				PaymentMethodID:   "account_money",
+				TransactionAmount: money.FromInt(10),
+				ApplicationFee:    money.FromInt(10),
+				CouponAmount:      money.FromInt(-1),
+				DateOfExpiration:  in(-time.Hour),
+				Payer:             &PayerRequest{Email: "not an email"},
+				AdditionalInfo: &AdditionalInfoRequest{
+					Payer: &AdditionalInfoPayerRequest{RegistrationDate: in(time.Hour)},
+					Items: []ItemRequest{{Quantity: -1, UnitPrice: money.FromInt(-1)}},
+				},
+			},
+			want: []string{
+				"application_fee",
+				"coupon_amount",
+				"date_of_expiration",
+				"payer.email",
+				"additional_info.payer.registration_date",
+				"additional_info.items[0].quantity",
+				"additional_info.items[0].unit_price",
+			},
+		},
+		{
+			name: "amount_out_of_method_range",
+			request: Request{
+				PaymentMethodID:   "bolbradesco",
+				TransactionAmount: money.MustParse("3.5"),
+				Payer: &PayerRequest{
+					FirstName:      "John",
+					LastName:       "Doe",
+					Identification: &IdentificationRequest{Type: "CPF", Number: "19119119100"},
+					Address: &PayerAddressRequest{
+						ZipCode:      "06233200",
+						StreetName:   "Av. das Nações Unidas",
+						StreetNumber: "3003",
+						Neighborhood: "Bonfim",
+						City:         "Osasco",
+						FederalUnit:  "SP",
+					},
+				},
+			},
+			method: &paymentmethod.Response{
+				ID:               "bolbradesco",
+				PaymentTypeID:    "ticket",
+				MinAllowedAmount: money.FromInt(4),
+				MaxAllowedAmount: money.FromInt(100000),
+			},
+			want: []string{"transaction_amount"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.ValidateWith(tt.method)
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Errorf("Request.ValidateWith() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var verr *ValidationError
+			if !errors.As(err, &verr) || !errors.Is(err, ErrInvalidRequest) {
+				t.Fatalf("Request.ValidateWith() error = %v, want *ValidationError", err)
+			}
+			var got []string
+			for _, fe := range verr.Errors {
+				got = append(got, fe.Field)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Request.ValidateWith() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateWithValidation(t *testing.T) {
+	sent := false
+	c := &client{
+		rc: &rest.Mock{
+			SendMock: func(req *http.Request, opts ...rest.Option) ([]byte, error) {
+				sent = true
+				return []byte(`{"id":1}`), nil
+			},
+		},
+	}
+
+	dto := Request{PaymentMethodID: "pix", TransactionAmount: money.FromInt(10)}
+	if _, err := c.Create(dto, WithValidation()); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("client.Create() error = %v, want %v", err, ErrInvalidRequest)
+	}
+	if sent {
+		t.Error("client.Create() sent an invalid request")
+	}
+
+	if _, err := c.Create(dto); err != nil {
+		t.Errorf("client.Create() error = %v, want nil without WithValidation", err)
+	}
+	if !sent {
+		t.Error("client.Create() did not send the request without WithValidation")
+	}
+}
@@ -0,0 +1,240 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+)
+
+const (
+	defaultWatchMinInterval = 2 * time.Second
+	defaultWatchMaxInterval = time.Minute
+	watchBackoffFactor      = 2
+)
+
+// WatchEvent is sent by Watch with the payment when its status changes, or with the error that ended the watch.
+type WatchEvent struct {
+	Payment *Response
+	Err     error
+}
+
+type watchIntervalKey struct{}
+
+type watchInterval struct {
+	min, max time.Duration
+}
+
+// WithWatchInterval sets how often Watch polls the payment: every min at first and after each change,
+// backing off up to max while the payment does not change. The default is from 2 seconds to 1 minute.
+func WithWatchInterval(min, max time.Duration) rest.Option {
+	return rest.WithValue(watchIntervalKey{}, watchInterval{min: min, max: max})
+}
+
+type watchUntilKey struct{}
+
+// WithWatchUntil makes Watch stop once done returns true for the payment, before a final status,
+// e.g. when a pix payment gets its QR code.
+func WithWatchUntil(done func(p *Response) bool) rest.Option {
+	return rest.WithValue(watchUntilKey{}, done)
+}
+
+// pollKey identifies a poll: a payment is polled once per access token, so that a watcher never receives
+// a payment got with the token of another. An empty token is the one of the rest client.
+type pollKey struct {
+	id    int64
+	token string
+}
+
+// poll is the shared polling of a payment.
+type poll struct {
+	cancel   context.CancelFunc
+	watchers map[*watcher]struct{}
+	last     *Response
+}
+
+// watcher is a call of Watch. updates holds the latest event not received yet.
+type watcher struct {
+	updates chan WatchEvent
+}
+
+// offer replaces the pending event with ev, so that the poll never blocks on a slow watcher.
+func (w *watcher) offer(ev WatchEvent) {
+	for {
+		select {
+		case w.updates <- ev:
+			return
+		default:
+			select {
+			case <-w.updates:
+			default:
+			}
+		}
+	}
+}
+
+func (c *client) Watch(ctx context.Context, id int64, opts ...rest.Option) <-chan WatchEvent {
+	until, _ := rest.Value(opts, watchUntilKey{}).(func(p *Response) bool)
+	w := &watcher{updates: make(chan WatchEvent, 1)}
+	key := pollKey{id: id, token: rest.AccessToken(opts)}
+	c.join(key, w, opts)
+
+	events := make(chan WatchEvent)
+	go func() {
+		defer close(events)
+		defer c.leave(key, w)
+
+		var last *Response
+		for {
+			var ev WatchEvent
+			var ok bool
+			select {
+			case <-ctx.Done():
+				return
+			case ev, ok = <-w.updates:
+				if !ok {
+					return
+				}
+			}
+			if ev.Err == nil && last != nil && !statusChanged(last, ev.Payment) {
+				continue
+			}
+
+			select {
+			case events <- ev:
+			case <-ctx.Done():
+				return
+			}
+			if ev.Err != nil || ev.Payment.Status.IsFinal() || until != nil && until(ev.Payment) {
+				return
+			}
+			last = ev.Payment
+		}
+	}()
+	return events
+}
+
+// join adds the watcher to the poll of the key, starting it if needed.
+// The poll is started with the options of its first watcher, except those bound to a single call.
+func (c *client) join(key pollKey, w *watcher, opts []rest.Option) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if p, ok := c.polls[key]; ok {
+		p.watchers[w] = struct{}{}
+		if p.last != nil {
+			w.offer(WatchEvent{Payment: p.last})
+		}
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &poll{cancel: cancel, watchers: map[*watcher]struct{}{w: {}}}
+	if c.polls == nil {
+		c.polls = map[pollKey]*poll{}
+	}
+	c.polls[key] = p
+	go c.poll(ctx, key, p, rest.SharedOptions(opts))
+}
+
+// leave removes the watcher from the poll of the key, stopping it when it has no watchers left.
+func (c *client) leave(key pollKey, w *watcher) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	p, ok := c.polls[key]
+	if !ok {
+		return
+	}
+	delete(p.watchers, w)
+	if len(p.watchers) == 0 {
+		p.cancel()
+		delete(c.polls, key)
+	}
+}
+
+// poll gets the payment until it reaches a final status, fails, or ctx is cancelled,
+// and sends it to the watchers.
+func (c *client) poll(ctx context.Context, key pollKey, p *poll, opts []rest.Option) {
+	interval := watchInterval{min: defaultWatchMinInterval, max: defaultWatchMaxInterval}
+	if v, ok := rest.Value(opts, watchIntervalKey{}).(watchInterval); ok {
+		interval = v
+	}
+	opts = append(opts[:len(opts):len(opts)], rest.WithContext(ctx))
+
+	wait := interval.min
+	for {
+		res, err := c.Get(key.id, opts...)
+		if ctx.Err() != nil {
+			return
+		}
+
+		var ev *WatchEvent
+		switch {
+		case err != nil && !retryable(err):
+			ev = &WatchEvent{Err: err}
+		case err != nil:
+			wait = min(wait*watchBackoffFactor, interval.max)
+		default:
+			ev = &WatchEvent{Payment: res}
+			if p.last == nil || statusChanged(p.last, res) {
+				wait = interval.min
+			} else {
+				wait = min(wait*watchBackoffFactor, interval.max)
+			}
+		}
+
+		if ev != nil && c.broadcast(key, p, *ev) {
+			return
+		}
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// broadcast sends the event to the watchers. It returns true, and ends the poll, on a final event.
+func (c *client) broadcast(key pollKey, p *poll, ev WatchEvent) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ev.Payment != nil {
+		p.last = ev.Payment
+	}
+	for w := range p.watchers {
+		w.offer(ev)
+	}
+	if ev.Err == nil && !ev.Payment.Status.IsFinal() {
+		return false
+	}
+
+	for w := range p.watchers {
+		close(w.updates)
+	}
+	p.watchers = nil
+	p.cancel()
+	if c.polls[key] == p {
+		delete(c.polls, key)
+	}
+	return true
+}
+
+// retryable reports whether polling should go on after err: it stops on client errors, such as an unknown payment.
+func retryable(err error) bool {
+	var er *rest.ErrorResponse
+	if !errors.As(err, &er) {
+		return true
+	}
+	return er.StatusCode == http.StatusTooManyRequests || er.StatusCode < 400 || er.StatusCode >= 500
+}
+
+func statusChanged(a, b *Response) bool {
+	return a.Status != b.Status || a.StatusDetail != b.StatusDetail
+}
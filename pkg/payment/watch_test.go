@@ -0,0 +1,163 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+)
+
+// sequence returns a rest client answering the n-th get with the n-th status, then with the last one.
+func sequence(calls *atomic.Int32, statuses ...string) rest.Client {
+	return &rest.Mock{
+		SendMock: func(req *http.Request, opts ...rest.Option) ([]byte, error) {
+			n := int(calls.Add(1)) - 1
+			s := statuses[min(n, len(statuses)-1)]
+			if s == "" {
+				return nil, &rest.ErrorResponse{StatusCode: http.StatusNotFound, Message: "payment not found"}
+			}
+			if s == "error" {
+				return nil, fmt.Errorf("connection reset")
+			}
+			return []byte(`{"id":1,"status":"` + s + `"}`), nil
+		},
+	}
+}
+
+func collect(events <-chan WatchEvent) (statuses []string) {
+	for ev := range events {
+		if ev.Err != nil {
+			statuses = append(statuses, "error: "+ev.Err.Error())
+			continue
+		}
+		statuses = append(statuses, string(ev.Payment.Status))
+	}
+	return statuses
+}
+
+func TestClientWatch(t *testing.T) {
+	interval := WithWatchInterval(5*time.Millisecond, 20*time.Millisecond)
+
+	t.Run("should_share_polling_until_final_status", func(t *testing.T) {
+		var calls atomic.Int32
+		c := NewClient(sequence(&calls, "pending", "pending", "error", "in_process", "approved"))
+
+		var wg sync.WaitGroup
+		got := make([][]string, 3)
+		for i := range got {
+			events := c.Watch(context.Background(), 1, interval)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				got[i] = collect(events)
+			}()
+		}
+		wg.Wait()
+
+		want := []string{"pending", "in_process", "approved"}
+		for i := range got {
+			if !reflect.DeepEqual(got[i], want) {
+				t.Errorf("client.Watch() watcher %d = %v, want %v", i, got[i], want)
+			}
+		}
+		if n := calls.Load(); n != 5 {
+			t.Errorf("client.Watch() made %d calls, want 5", n)
+		}
+	})
+
+	t.Run("should_poll_once_per_access_token", func(t *testing.T) {
+		var calls sync.Map
+		c := NewClient(&rest.Mock{
+			SendMock: func(req *http.Request, opts ...rest.Option) ([]byte, error) {
+				token := rest.AccessToken(opts)
+				n, _ := calls.LoadOrStore(token, new(atomic.Int32))
+				status := "pending"
+				if n.(*atomic.Int32).Add(1) > 1 {
+					status = "approved"
+				}
+				// the poll adds its own WithContext, the per-call options of the watchers are dropped.
+				if len(opts)-len(rest.SharedOptions(opts)) != 1 {
+					t.Errorf("client.Watch() polled with the per-call options of a watcher")
+				}
+				return []byte(`{"id":1,"status":"` + status + `","external_reference":"` + token + `"}`), nil
+			},
+		})
+
+		var wg sync.WaitGroup
+		got := make([][]string, 4)
+		for i := range got {
+			token := []string{"seller-1", "seller-2"}[i%2]
+			events := c.Watch(context.Background(), 1, interval, rest.WithAccessToken(token), rest.WithResponseCapture(&rest.ResponseInfo{}))
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for ev := range events {
+					got[i] = append(got[i], ev.Payment.ExternalReference+" "+string(ev.Payment.Status))
+				}
+			}()
+		}
+		wg.Wait()
+
+		for i := range got {
+			token := []string{"seller-1", "seller-2"}[i%2]
+			if want := []string{token + " pending", token + " approved"}; !reflect.DeepEqual(got[i], want) {
+				t.Errorf("client.Watch() watcher %d = %v, want %v", i, got[i], want)
+			}
+		}
+		for _, token := range []string{"seller-1", "seller-2"} {
+			if n, _ := calls.Load(token); n == nil || n.(*atomic.Int32).Load() != 2 {
+				t.Errorf("client.Watch() calls with %s = %v, want 2", token, n)
+			}
+		}
+	})
+
+	t.Run("should_stop_when_condition_is_met", func(t *testing.T) {
+		var calls atomic.Int32
+		c := NewClient(sequence(&calls, "pending", "in_process", "approved"))
+
+		until := WithWatchUntil(func(p *Response) bool { return p.Status == StatusInProcess })
+		got := collect(c.Watch(context.Background(), 1, interval, until))
+		if want := []string{"pending", "in_process"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("client.Watch() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("should_stop_on_client_error", func(t *testing.T) {
+		var calls atomic.Int32
+		c := NewClient(sequence(&calls, ""))
+
+		got := collect(c.Watch(context.Background(), 1, interval))
+		if want := []string{"error: payment not found"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("client.Watch() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("should_stop_when_context_is_done", func(t *testing.T) {
+		var calls atomic.Int32
+		c := NewClient(sequence(&calls, "pending"))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		events := c.Watch(ctx, 1, interval)
+		if ev := <-events; ev.Payment == nil || ev.Payment.Status != StatusPending {
+			t.Fatalf("client.Watch() = %+v, want a pending payment", ev)
+		}
+		cancel()
+		if got := collect(events); len(got) != 0 {
+			t.Errorf("client.Watch() after cancel = %v, want no events", got)
+		}
+
+		// the poll stops once it has no watchers left.
+		time.Sleep(50 * time.Millisecond)
+		n := calls.Load()
+		time.Sleep(50 * time.Millisecond)
+		if calls.Load() != n {
+			t.Error("client.Watch() kept polling without watchers")
+		}
+	})
+}
@@ -1,7 +1,7 @@
 package paymentmethod
 
 import (
-	"encoding/json"
+	"context"
 	"net/http"
 
 	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
@@ -30,23 +30,11 @@ func NewClient(restClient rest.Client) Client {
 }
 
 func (c *client) List(opts ...rest.Option) ([]Response, error) {
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+	res, err := rest.Do[rest.NoBody, []Response](context.Background(), c.rc, http.MethodGet, url, nil, nil, nil,
+		append([]rest.Option{rest.WithOperation("paymentmethod.List")}, opts...)...)
 	if err != nil {
-		return nil, &rest.ErrorResponse{
-			StatusCode: http.StatusInternalServerError,
-			Message:    "error creating request: " + err.Error(),
-		}
-	}
-
-	res, err := c.rc.Send(req, opts...)
-	if err != nil {
-		return nil, err
-	}
-
-	var formatted []Response
-	if err := json.Unmarshal(res, &formatted); err != nil {
 		return nil, err
 	}
 
-	return formatted, nil
+	return *res, nil
 }
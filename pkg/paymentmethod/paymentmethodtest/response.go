@@ -0,0 +1,30 @@
+package paymentmethodtest
+
+import (
+	"github.com/gdeandradero/sdk-go/pkg/money"
+	"github.com/gdeandradero/sdk-go/pkg/paymentmethod"
+)
+
+// NewResponse returns an active payment method, e.g. NewResponse("pix", "bank_transfer").
+func NewResponse(id, paymentTypeID string) paymentmethod.Response {
+	return paymentmethod.Response{
+		ID:               id,
+		Name:             id,
+		PaymentTypeID:    paymentTypeID,
+		Status:           "active",
+		DeferredCapture:  "supported",
+		ProcessingModes:  []string{"aggregator"},
+		MinAllowedAmount: money.MustParse("0.50"),
+		MaxAllowedAmount: money.MustParse("60000.00"),
+	}
+}
+
+// Defaults returns the payment methods visa, master, pix and bolbradesco.
+func Defaults() []paymentmethod.Response {
+	return []paymentmethod.Response{
+		NewResponse("visa", "credit_card"),
+		NewResponse("master", "credit_card"),
+		NewResponse("pix", "bank_transfer"),
+		NewResponse("bolbradesco", "ticket"),
+	}
+}
@@ -1,21 +1,30 @@
 package paymentmethod
 
+import (
+	"encoding/json"
+
+	"github.com/gdeandradero/sdk-go/pkg/money"
+)
+
 type Response struct {
-	ID                   string   `json:"id,omitempty"`
-	Name                 string   `json:"name,omitempty"`
-	PaymentTypeID        string   `json:"payment_type_id,omitempty"`
-	Status               string   `json:"status,omitempty"`
-	SecureThumbnail      string   `json:"secure_thumbnail,omitempty"`
-	Thumbnail            string   `json:"thumbnail,omitempty"`
-	DeferredCapture      string   `json:"deferred_capture,omitempty"`
-	AdditionalInfoNeeded []string `json:"additional_info_needed,omitempty"`
-	ProcessingModes      []string `json:"processing_modes,omitempty"`
-	AccreditationTime    int64    `json:"accreditation_time,omitempty"`
-	MinAllowedAmount     float64  `json:"min_allowed_amount,omitempty"`
-	MaxAllowedAmount     float64  `json:"max_allowed_amount,omitempty"`
+	ID                   string       `json:"id,omitempty"`
+	Name                 string       `json:"name,omitempty"`
+	PaymentTypeID        string       `json:"payment_type_id,omitempty"`
+	Status               string       `json:"status,omitempty"`
+	SecureThumbnail      string       `json:"secure_thumbnail,omitempty"`
+	Thumbnail            string       `json:"thumbnail,omitempty"`
+	DeferredCapture      string       `json:"deferred_capture,omitempty"`
+	AdditionalInfoNeeded []string     `json:"additional_info_needed,omitempty"`
+	ProcessingModes      []string     `json:"processing_modes,omitempty"`
+	AccreditationTime    int64        `json:"accreditation_time,omitempty"`
+	MinAllowedAmount     money.Amount `json:"min_allowed_amount,omitzero"`
+	MaxAllowedAmount     money.Amount `json:"max_allowed_amount,omitzero"`
 
 	Settings              []SettingsResponse             `json:"settings,omitempty"`
 	FinancialInstitutions []FinancialInstitutionResponse `json:"financial_institutions,omitempty"`
+
+	// Extra holds the fields of the response the SDK does not model.
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // SettingsResponse represents payment method settings.
@@ -23,6 +32,8 @@ type SettingsResponse struct {
 	Bin          *SettingsBinResponse          `json:"bin,omitempty"`
 	CardNumber   *SettingsCardNumberResponse   `json:"card_number,omitempty"`
 	SecurityCode *SettingsSecurityCodeResponse `json:"security_code,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // SettingsBinResponse represents BIN (Bank Identification Number) settings.
@@ -30,12 +41,16 @@ type SettingsBinResponse struct {
 	Pattern             string `json:"pattern,omitempty"`
 	ExclusionPattern    string `json:"exclusion_pattern,omitempty"`
 	InstallmentsPattern string `json:"installments_pattern,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // SettingsCardNumberResponse represents card number settings.
 type SettingsCardNumberResponse struct {
 	Length     int    `json:"length,omitempty"`
 	Validation string `json:"validation,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // SettingsSecurityCodeResponse represents security code settings.
@@ -43,10 +58,14 @@ type SettingsSecurityCodeResponse struct {
 	Mode         string `json:"mode,omitempty"`
 	Length       int    `json:"length,omitempty"`
 	CardLocation string `json:"card_location,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // FinancialInstitutionResponse represents financial institution settings.
 type FinancialInstitutionResponse struct {
 	ID          string `json:"id,omitempty"`
 	Description string `json:"description,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
 }
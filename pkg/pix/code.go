@@ -0,0 +1,86 @@
+package pix
+
+import (
+	"errors"
+	"image"
+	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/money"
+	"github.com/gdeandradero/sdk-go/pkg/payment"
+)
+
+// ErrNoQRCode is returned by FromPayment when the payment has no pix QR code.
+var ErrNoQRCode = errors.New("payment has no pix QR code")
+
+// Code is the pix QR code of a payment, ready to be served to a payer.
+type Code struct {
+	// Code is the copy-paste code.
+	Code string `json:"code"`
+
+	// ImageBase64 is the base64 encoded PNG of the QR code.
+	ImageBase64 string `json:"image_base64,omitempty"`
+
+	// TicketURL is the page of the payment on Mercado Pago.
+	TicketURL string `json:"ticket_url,omitempty"`
+
+	Amount       money.Amount `json:"amount,omitzero"`
+	MerchantName string       `json:"merchant_name,omitempty"`
+	MerchantCity string       `json:"merchant_city,omitempty"`
+	TxID         string       `json:"txid,omitempty"`
+
+	// ExpiresAt is the expiration of the payment, nil if it has none.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// FromPayment returns the pix code of the payment, validating its payload.
+func FromPayment(p *payment.Response) (*Code, error) {
+	if p.PointOfInteraction == nil || p.PointOfInteraction.TransactionData == nil ||
+		p.PointOfInteraction.TransactionData.QRCode == "" {
+		return nil, ErrNoQRCode
+	}
+	data := p.PointOfInteraction.TransactionData
+
+	payload, err := Parse(data.QRCode)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := payload.Amount
+	if amount.IsZero() {
+		amount = p.TransactionAmount
+	}
+	return &Code{
+		Code:         payload.Raw,
+		ImageBase64:  data.QRCodeBase64,
+		TicketURL:    data.TicketURL,
+		Amount:       amount,
+		MerchantName: payload.MerchantName,
+		MerchantCity: payload.MerchantCity,
+		TxID:         payload.TxID,
+		ExpiresAt:    p.DateOfExpiration,
+	}, nil
+}
+
+// String returns the copy-paste code.
+func (c *Code) String() string {
+	return c.Code
+}
+
+// Image decodes the QR code image.
+func (c *Code) Image() (image.Image, error) {
+	return DecodeImage(c.ImageBase64)
+}
+
+// Expired reports whether the code has expired at t.
+func (c *Code) Expired(t time.Time) bool {
+	return c.ExpiresAt != nil && !t.Before(*c.ExpiresAt)
+}
+
+// ExpiresIn returns how long the code is valid after t, 0 if it has expired.
+// ok is false if the code has no expiration.
+func (c *Code) ExpiresIn(t time.Time) (d time.Duration, ok bool) {
+	if c.ExpiresAt == nil {
+		return 0, false
+	}
+	return max(c.ExpiresAt.Sub(t), 0), true
+}
@@ -0,0 +1,231 @@
+// Package pix provides helpers for the QR codes of pix payments:
+// decoding the QR code image, parsing and validating the EMV BR Code payload,
+// and rendering the copy-paste code with its expiration.
+package pix
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"strconv"
+	"strings"
+
+	"github.com/gdeandradero/sdk-go/pkg/money"
+)
+
+// EMV field IDs of the BR Code.
+const (
+	idPayloadFormat     = "00"
+	idPointOfInitiation = "01"
+	idMerchantAccount   = "26"
+	idMerchantCategory  = "52"
+	idCurrency          = "53"
+	idAmount            = "54"
+	idCountryCode       = "58"
+	idMerchantName      = "59"
+	idMerchantCity      = "60"
+	idPostalCode        = "61"
+	idAdditionalData    = "62"
+	idCRC               = "63"
+)
+
+// EMV field IDs within the merchant account and additional data fields.
+const (
+	idAccountGUI         = "00"
+	idAccountKey         = "01"
+	idAccountDescription = "02"
+	idAccountURL         = "25"
+	idTxID               = "05"
+)
+
+const (
+	crcLength = 4
+
+	// pointOfInitiationOnce marks codes that can be paid only once.
+	pointOfInitiationOnce = "12"
+)
+
+var (
+	// ErrInvalidPayload is returned when a code is not a well formed BR Code.
+	ErrInvalidPayload = errors.New("invalid pix payload")
+
+	// ErrInvalidCRC is returned when the CRC of a code does not match its content.
+	ErrInvalidCRC = errors.New("invalid pix payload CRC")
+)
+
+// Payload is a parsed EMV BR Code, the content of a pix QR code.
+type Payload struct {
+	// Raw is the code that was parsed.
+	Raw string
+
+	PayloadFormat     string
+	PointOfInitiation string
+	MerchantAccount   MerchantAccount
+	MerchantCategory  string
+	Currency          string
+	CountryCode       string
+	MerchantName      string
+	MerchantCity      string
+	PostalCode        string
+
+	// Amount is zero when the payer chooses the amount.
+	Amount money.Amount
+
+	// TxID identifies the transaction, "***" when the code has none.
+	TxID string
+
+	// CRC is the CRC16 of the code, as 4 hexadecimal digits.
+	CRC string
+}
+
+// MerchantAccount is the merchant account information of a BR Code.
+type MerchantAccount struct {
+	// GUI is the domain of the arrangement, "br.gov.bcb.pix".
+	GUI string
+
+	// Key is the pix key of static codes.
+	Key string
+
+	// Description is an optional message of static codes.
+	Description string
+
+	// URL is the location of the payload of dynamic codes.
+	URL string
+}
+
+// Dynamic reports whether the code points to a payload hosted by the payment service provider.
+func (p *Payload) Dynamic() bool {
+	return p.MerchantAccount.URL != ""
+}
+
+// SingleUse reports whether the code can be paid only once.
+func (p *Payload) SingleUse() bool {
+	return p.PointOfInitiation == pointOfInitiationOnce
+}
+
+// Parse parses and validates a BR Code, such as the copy-paste code of a pix payment.
+func Parse(code string) (*Payload, error) {
+	code = strings.TrimSpace(code)
+	if err := ValidateCRC(code); err != nil {
+		return nil, err
+	}
+
+	fields, err := parseFields(code)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Payload{
+		Raw:               code,
+		PayloadFormat:     fields[idPayloadFormat],
+		PointOfInitiation: fields[idPointOfInitiation],
+		MerchantCategory:  fields[idMerchantCategory],
+		Currency:          fields[idCurrency],
+		CountryCode:       fields[idCountryCode],
+		MerchantName:      fields[idMerchantName],
+		MerchantCity:      fields[idMerchantCity],
+		PostalCode:        fields[idPostalCode],
+		CRC:               fields[idCRC],
+	}
+	if p.PayloadFormat != "01" {
+		return nil, fmt.Errorf("%w: unsupported payload format %q", ErrInvalidPayload, p.PayloadFormat)
+	}
+
+	if account, ok := fields[idMerchantAccount]; ok {
+		sub, err := parseFields(account)
+		if err != nil {
+			return nil, fmt.Errorf("%w: merchant account: %v", ErrInvalidPayload, err)
+		}
+		p.MerchantAccount = MerchantAccount{
+			GUI:         sub[idAccountGUI],
+			Key:         sub[idAccountKey],
+			Description: sub[idAccountDescription],
+			URL:         sub[idAccountURL],
+		}
+	}
+	if !strings.EqualFold(p.MerchantAccount.GUI, "br.gov.bcb.pix") {
+		return nil, fmt.Errorf("%w: not a pix merchant account", ErrInvalidPayload)
+	}
+
+	if amount, ok := fields[idAmount]; ok {
+		if p.Amount, err = money.Parse(amount); err != nil {
+			return nil, fmt.Errorf("%w: amount: %v", ErrInvalidPayload, err)
+		}
+	}
+	if data, ok := fields[idAdditionalData]; ok {
+		sub, err := parseFields(data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: additional data: %v", ErrInvalidPayload, err)
+		}
+		p.TxID = sub[idTxID]
+	}
+
+	return p, nil
+}
+
+// parseFields parses a sequence of EMV fields, each an ID of 2 digits, a length of 2 digits and a value.
+func parseFields(s string) (map[string]string, error) {
+	fields := map[string]string{}
+	for len(s) > 0 {
+		if len(s) < 4 {
+			return nil, fmt.Errorf("%w: truncated field %q", ErrInvalidPayload, s)
+		}
+		id := s[:2]
+		n, err := strconv.Atoi(s[2:4])
+		if err != nil || n < 0 || len(s) < 4+n {
+			return nil, fmt.Errorf("%w: bad length of field %s", ErrInvalidPayload, id)
+		}
+		fields[id] = s[4 : 4+n]
+		s = s[4+n:]
+	}
+	return fields, nil
+}
+
+// ValidateCRC checks that the code ends with the CRC field and that it matches the rest of the code.
+func ValidateCRC(code string) error {
+	prefixLen := len(code) - crcLength
+	if prefixLen < 4 || code[prefixLen-4:prefixLen] != idCRC+"04" {
+		return fmt.Errorf("%w: missing CRC field", ErrInvalidPayload)
+	}
+	want := CRC16(code[:prefixLen])
+	if got := strings.ToUpper(code[prefixLen:]); got != want {
+		return fmt.Errorf("%w: got %s, want %s", ErrInvalidCRC, got, want)
+	}
+	return nil
+}
+
+// CRC16 returns the CRC16-CCITT (polynomial 0x1021, initial value 0xFFFF) of s as 4 uppercase hexadecimal digits,
+// as used by the BR Code. s must include the ID and length of the CRC field, "6304".
+func CRC16(s string) string {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(s); i++ {
+		crc ^= uint16(s[i]) << 8
+		for range 8 {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return fmt.Sprintf("%04X", crc)
+}
+
+// DecodeImage decodes the base64 encoded PNG of a QR code, such as TransactionDataResponse.QRCodeBase64.
+func DecodeImage(encoded string) (image.Image, error) {
+	encoded = strings.TrimSpace(encoded)
+	encoded = strings.TrimPrefix(encoded, "data:image/png;base64,")
+
+	b, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding QR code base64: %w", err)
+	}
+	img, err := png.Decode(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding QR code image: %w", err)
+	}
+	return img, nil
+}
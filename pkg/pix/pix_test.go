@@ -0,0 +1,174 @@
+package pix
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/money"
+	"github.com/gdeandradero/sdk-go/pkg/payment"
+)
+
+// withCRC appends the CRC field to a payload.
+func withCRC(payload string) string {
+	payload += "6304"
+	return payload + CRC16(payload)
+}
+
+var (
+	staticCode = withCRC("000201" +
+		"26360014br.gov.bcb.pix0114+5511999999999" +
+		"52040000" + "5303986" + "54041.50" + "5802BR" +
+		"5913Fulano de Tal" + "6009SAO PAULO" +
+		"62070503***")
+
+	dynamicCode = withCRC("000201" + "010212" +
+		"26560014br.gov.bcb.pix2534pix.example.com/qr/v2/9d36b84fc70b" +
+		"52040000" + "5303986" + "5802BR" +
+		"5904Loja" + "6008BRASILIA" +
+		"62120508abc12345")
+)
+
+func TestCRC16(t *testing.T) {
+	if got := CRC16("123456789"); got != "29B1" {
+		t.Errorf("CRC16() = %v, want %v", got, "29B1")
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		want    *Payload
+		wantErr error
+	}{
+		{
+			name: "should_parse_static_code",
+			code: staticCode,
+			want: &Payload{
+				Raw:              staticCode,
+				PayloadFormat:    "01",
+				MerchantAccount:  MerchantAccount{GUI: "br.gov.bcb.pix", Key: "+5511999999999"},
+				MerchantCategory: "0000",
+				Currency:         "986",
+				CountryCode:      "BR",
+				MerchantName:     "Fulano de Tal",
+				MerchantCity:     "SAO PAULO",
+				Amount:           money.MustParse("1.5"),
+				TxID:             "***",
+				CRC:              staticCode[len(staticCode)-4:],
+			},
+		},
+		{
+			name: "should_parse_dynamic_code",
+			code: dynamicCode,
+			want: &Payload{
+				Raw:               dynamicCode,
+				PayloadFormat:     "01",
+				PointOfInitiation: "12",
+				MerchantAccount:   MerchantAccount{GUI: "br.gov.bcb.pix", URL: "pix.example.com/qr/v2/9d36b84fc70b"},
+				MerchantCategory:  "0000",
+				Currency:          "986",
+				CountryCode:       "BR",
+				MerchantName:      "Loja",
+				MerchantCity:      "BRASILIA",
+				TxID:              "abc12345",
+				CRC:               dynamicCode[len(dynamicCode)-4:],
+			},
+		},
+		{
+			name:    "should_fail_on_wrong_crc",
+			code:    staticCode[:len(staticCode)-4] + "0000",
+			wantErr: ErrInvalidCRC,
+		},
+		{
+			name:    "should_fail_without_crc",
+			code:    "000201",
+			wantErr: ErrInvalidPayload,
+		},
+		{
+			name:    "should_fail_on_truncated_field",
+			code:    withCRC("000201" + "5913Fulano"),
+			wantErr: ErrInvalidPayload,
+		},
+		{
+			name:    "should_fail_on_other_arrangement",
+			code:    withCRC("000201" + "26180014br.gov.bcb.xyz"),
+			wantErr: ErrInvalidPayload,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.code)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if *got != *tt.want {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+			if got.Dynamic() != (tt.want.MerchantAccount.URL != "") {
+				t.Errorf("Payload.Dynamic() = %v", got.Dynamic())
+			}
+		})
+	}
+}
+
+func TestFromPayment(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 2, 2))
+	img.Set(1, 1, color.White)
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	expiration := time.Date(2024, 1, 10, 12, 30, 0, 0, time.UTC)
+
+	code, err := FromPayment(&payment.Response{
+		TransactionAmount: money.MustParse("1.5"),
+		DateOfExpiration:  &expiration,
+		PointOfInteraction: &payment.PointOfInteractionResponse{
+			TransactionData: &payment.TransactionDataResponse{
+				QRCode:       staticCode,
+				QRCodeBase64: base64.StdEncoding.EncodeToString(buf.Bytes()),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("FromPayment() error = %v", err)
+	}
+	if code.String() != staticCode || code.MerchantName != "Fulano de Tal" || !code.Amount.Equal(money.MustParse("1.5")) {
+		t.Errorf("FromPayment() = %+v", code)
+	}
+
+	now := expiration.Add(-10 * time.Minute)
+	if d, ok := code.ExpiresIn(now); code.Expired(now) || d != 10*time.Minute || !ok {
+		t.Errorf("Code.ExpiresIn() = %v, %t, want %v, true", d, ok, 10*time.Minute)
+	}
+	if !code.Expired(expiration) {
+		t.Error("Code.Expired() = false at the expiration")
+	}
+
+	decoded, err := code.Image()
+	if err != nil {
+		t.Fatalf("Code.Image() error = %v", err)
+	}
+	if decoded.Bounds() != img.Bounds() {
+		t.Errorf("Code.Image() bounds = %v, want %v", decoded.Bounds(), img.Bounds())
+	}
+
+	code.ExpiresAt = nil
+	if d, ok := code.ExpiresIn(now); code.Expired(now) || d != 0 || ok {
+		t.Errorf("Code.ExpiresIn() without expiration = %v, %t, want 0, false", d, ok)
+	}
+
+	if _, err := FromPayment(&payment.Response{}); !errors.Is(err, ErrNoQRCode) {
+		t.Errorf("FromPayment() error = %v, wantErr %v", err, ErrNoQRCode)
+	}
+}
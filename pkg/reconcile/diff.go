@@ -0,0 +1,106 @@
+package reconcile
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/money"
+)
+
+// Kind is the kind of a discrepancy.
+type Kind string
+
+const (
+	// MissingPayment is a report row whose payment is not in the search results.
+	MissingPayment Kind = "missing_payment"
+
+	// MissingRow is a collected payment without any row in the report.
+	MissingRow Kind = "missing_row"
+
+	// AmountMismatch is a payment whose amounts do not add up, or do not match the report.
+	AmountMismatch Kind = "amount_mismatch"
+
+	// UnexpectedRefund is a refund found on one side only, or with different amounts.
+	UnexpectedRefund Kind = "unexpected_refund"
+
+	// FeeDeviation is a payment whose fees in the report differ from its fee details.
+	FeeDeviation Kind = "fee_deviation"
+)
+
+// Discrepancy is a difference found between the payments and the report.
+type Discrepancy struct {
+	Kind              Kind         `json:"kind"`
+	PaymentID         int64        `json:"payment_id,omitempty"`
+	ExternalReference string       `json:"external_reference,omitempty"`
+	Line              int          `json:"line,omitempty"`
+	Field             string       `json:"field,omitempty"`
+	Expected          money.Amount `json:"expected"`
+	Actual            money.Amount `json:"actual"`
+	Message           string       `json:"message"`
+}
+
+// Result is the result of a reconciliation.
+type Result struct {
+	Begin time.Time `json:"begin_date"`
+	End   time.Time `json:"end_date"`
+
+	// Payments is the number of payments found by the search.
+	Payments int `json:"payments"`
+
+	// Rows is the number of payment and refund rows of the report, other rows are ignored.
+	Rows int `json:"rows"`
+
+	// Matched is the number of payments found in both sides without discrepancies.
+	Matched int `json:"matched"`
+
+	Discrepancies []Discrepancy `json:"discrepancies"`
+}
+
+// OK reports whether no discrepancy was found.
+func (r *Result) OK() bool {
+	return len(r.Discrepancies) == 0
+}
+
+// WriteJSON writes the result as an indented JSON document.
+func (r *Result) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// csvHeader is the header of the file written by WriteCSV.
+var csvHeader = []string{"kind", "payment_id", "external_reference", "line", "field", "expected", "actual", "message"}
+
+// WriteCSV writes the discrepancies as a CSV file, one per row, with a header.
+func (r *Result) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, d := range r.Discrepancies {
+		record := []string{
+			string(d.Kind),
+			"",
+			d.ExternalReference,
+			"",
+			d.Field,
+			d.Expected.String(),
+			d.Actual.String(),
+			d.Message,
+		}
+		if d.PaymentID != 0 {
+			record[1] = strconv.FormatInt(d.PaymentID, 10)
+		}
+		if d.Line != 0 {
+			record[3] = strconv.Itoa(d.Line)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,316 @@
+// Package reconcile reconciles the payments of an account with the rows of its release
+// or settlement reports, and reports the discrepancies in a format a ledger can consume.
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/marketplace"
+	"github.com/gdeandradero/sdk-go/pkg/money"
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+	"github.com/gdeandradero/sdk-go/pkg/payment"
+	"github.com/gdeandradero/sdk-go/pkg/report"
+)
+
+const (
+	defaultPageSize = 100
+	defaultRange    = "date_created"
+
+	searchDateLayout = "2006-01-02T15:04:05.000Z07:00"
+)
+
+// Config configures a reconciliation.
+type Config struct {
+	// Begin and End are the period of the payments to search.
+	Begin time.Time
+	End   time.Time
+
+	// Range is the date of the payments Begin and End refer to, "date_created" by default.
+	// Use "money_release_date" to reconcile release reports.
+	Range string
+
+	// PageSize is the number of payments fetched by each search, 100 by default.
+	PageSize int64
+
+	// Tolerance is the largest difference between two amounts that is not a discrepancy.
+	Tolerance money.Amount
+}
+
+// Walk calls fn with each payment found by the search, fetching the pages one by one.
+// It stops at the first error, returned by the search, by fn or by ctx.
+func Walk(ctx context.Context, pc payment.Client, f payment.Filters, fn func(p *payment.Response) error, opts ...rest.Option) error {
+	if f.Limit <= 0 {
+		f.Limit = defaultPageSize
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		res, err := pc.Search(f, opts...)
+		if err != nil {
+			return err
+		}
+		for i := range res.Results {
+			if err := fn(&res.Results[i]); err != nil {
+				return err
+			}
+		}
+
+		f.Offset += int64(len(res.Results))
+		if len(res.Results) == 0 || f.Offset >= res.Paging.Total {
+			return nil
+		}
+	}
+}
+
+// entry joins a payment and its rows.
+type entry struct {
+	payment *payment.Response
+
+	payments int // number of payment rows
+	gross    money.Amount
+	fees     money.Amount
+	hasFees  bool
+
+	refunds  int // number of refund rows
+	refunded money.Amount
+}
+
+// Run searches the payments of the period and reconciles them with the rows of a report.
+// Rows are joined to payments by ID, PAYMENT_ID or SOURCE_ID, and then by EXTERNAL_REFERENCE.
+// Rows other than payments and refunds, like withdrawals or balances, are ignored.
+//
+// It flags:
+//   - rows whose payment was not found, and collected payments without rows;
+//   - payments whose total paid amount is not the transaction amount plus the fees paid by the payer,
+//     or whose gross amount in the report is not the transaction amount;
+//   - refunds in the report that do not add up to the amount refunded of the payment;
+//   - fees in the report that differ from the fees paid by the collector in the fee details.
+//
+// Discrepancies are sorted by payment ID and report line.
+func Run(ctx context.Context, pc payment.Client, rows []*report.Row, config Config, opts ...rest.Option) (*Result, error) {
+	if config.Range == "" {
+		config.Range = defaultRange
+	}
+	result := &Result{Begin: config.Begin, End: config.End}
+
+	var (
+		entries []*entry
+		byID    = map[int64]*entry{}
+		byRef   = map[string][]*entry{}
+	)
+	f := payment.Filters{
+		Sort:      "id",
+		Criteria:  "asc",
+		Range:     config.Range,
+		BeginDate: config.Begin.Format(searchDateLayout),
+		EndDate:   config.End.Format(searchDateLayout),
+		Limit:     config.PageSize,
+	}
+	err := Walk(ctx, pc, f, func(p *payment.Response) error {
+		if _, ok := byID[p.ID]; ok {
+			return nil
+		}
+		e := &entry{payment: p}
+		entries = append(entries, e)
+		byID[p.ID] = e
+		if p.ExternalReference != "" {
+			byRef[p.ExternalReference] = append(byRef[p.ExternalReference], e)
+		}
+		return nil
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	result.Payments = len(entries)
+
+	for _, row := range rows {
+		refund := isRefund(row)
+		if !refund && !isPayment(row) {
+			continue
+		}
+		result.Rows++
+
+		e := find(row, byID, byRef)
+		if e == nil {
+			result.Discrepancies = append(result.Discrepancies, Discrepancy{
+				Kind:              MissingPayment,
+				PaymentID:         rowPaymentID(row),
+				ExternalReference: row.ExternalReference,
+				Line:              row.Line,
+				Actual:            rowAmount(row),
+				Message:           "payment not found in the search results",
+			})
+			continue
+		}
+
+		if refund {
+			e.refunds++
+			e.refunded = e.refunded.Add(refundAmount(row))
+			continue
+		}
+		e.payments++
+		e.gross = e.gross.Add(rowAmount(row))
+		if fees := rowFees(row); !fees.IsZero() {
+			e.fees = e.fees.Add(fees)
+			e.hasFees = true
+		}
+	}
+
+	for _, e := range entries {
+		ds := check(e, config.Tolerance)
+		if len(ds) == 0 && e.payments+e.refunds > 0 {
+			result.Matched++
+		}
+		result.Discrepancies = append(result.Discrepancies, ds...)
+	}
+
+	sort.SliceStable(result.Discrepancies, func(i, j int) bool {
+		a, b := result.Discrepancies[i], result.Discrepancies[j]
+		if a.PaymentID != b.PaymentID {
+			return a.PaymentID < b.PaymentID
+		}
+		return a.Line < b.Line
+	})
+	return result, nil
+}
+
+// check returns the discrepancies of a payment and its rows.
+func check(e *entry, tolerance money.Amount) []Discrepancy {
+	p := e.payment
+	var ds []Discrepancy
+	add := func(kind Kind, field string, expected, actual money.Amount, message string) {
+		ds = append(ds, Discrepancy{
+			Kind:              kind,
+			PaymentID:         p.ID,
+			ExternalReference: p.ExternalReference,
+			Field:             field,
+			Expected:          expected,
+			Actual:            actual,
+			Message:           message,
+		})
+	}
+
+	if e.payments == 0 && e.refunds == 0 {
+		if collected(p) {
+			add(MissingRow, "", p.TransactionAmount, money.Amount{}, fmt.Sprintf("%s payment not found in the report", p.Status))
+		}
+		return ds
+	}
+
+	if td := p.TransactionDetails; td != nil && !td.TotalPaidAmount.IsZero() {
+		expected := p.TransactionAmount.Add(payerFees(p))
+		if differ(expected, td.TotalPaidAmount, tolerance) {
+			add(AmountMismatch, "total_paid_amount", expected, td.TotalPaidAmount,
+				"total paid amount is not the transaction amount plus the fees paid by the payer")
+		}
+	}
+
+	if e.payments > 0 {
+		if differ(p.TransactionAmount, e.gross, tolerance) {
+			add(AmountMismatch, "gross_amount", p.TransactionAmount, e.gross, "gross amount in the report is not the transaction amount")
+		}
+		if e.hasFees {
+			expected := marketplace.Breakdown(p).PaidByCollector
+			if differ(expected, e.fees, tolerance) {
+				add(FeeDeviation, "fee_amount", expected, e.fees, "fees in the report differ from the fee details of the payment")
+			}
+		}
+	}
+
+	if differ(p.TransactionAmountRefunded, e.refunded, tolerance) {
+		message := "refunds in the report do not add up to the amount refunded"
+		if e.refunds == 0 {
+			message = "refunded payment without refunds in the report"
+		} else if p.TransactionAmountRefunded.IsZero() {
+			message = "refunds in the report for a payment that was not refunded"
+		}
+		add(UnexpectedRefund, "refunded_amount", p.TransactionAmountRefunded, e.refunded, message)
+	}
+
+	return ds
+}
+
+// find returns the entry of the payment of the row, or nil.
+// The external reference is only used when it identifies a single payment.
+func find(row *report.Row, byID map[int64]*entry, byRef map[string][]*entry) *entry {
+	if id := rowPaymentID(row); id != 0 {
+		if e, ok := byID[id]; ok {
+			return e
+		}
+	}
+	if es := byRef[row.ExternalReference]; row.ExternalReference != "" && len(es) == 1 {
+		return es[0]
+	}
+	return nil
+}
+
+// rowPaymentID returns the ID of the payment of the row, or zero.
+func rowPaymentID(row *report.Row) int64 {
+	id := row.PaymentID
+	if id == "" {
+		id = row.SourceID
+	}
+	n, _ := strconv.ParseInt(id, 10, 64)
+	return n
+}
+
+func isPayment(row *report.Row) bool {
+	return strings.EqualFold(row.Description, "payment") || strings.EqualFold(row.TransactionType, "settlement")
+}
+
+func isRefund(row *report.Row) bool {
+	return strings.EqualFold(row.Description, "refund") || strings.EqualFold(row.TransactionType, "refund")
+}
+
+// rowAmount returns the gross amount of the row, falling back to its transaction amount.
+func rowAmount(row *report.Row) money.Amount {
+	if !row.GrossAmount.IsZero() {
+		return row.GrossAmount
+	}
+	return row.TransactionAmount
+}
+
+// refundAmount returns the amount refunded by a refund row, as a positive amount.
+func refundAmount(row *report.Row) money.Amount {
+	if !row.NetDebitAmount.IsZero() {
+		return row.NetDebitAmount.Abs()
+	}
+	return rowAmount(row).Abs()
+}
+
+// rowFees returns the fees charged in the row, as a positive amount.
+// Reports show the fees as negative amounts.
+func rowFees(row *report.Row) money.Amount {
+	return row.MPFeeAmount.Add(row.FeeAmount).Add(row.FinancingFeeAmount).Add(row.ShippingFeeAmount).Abs()
+}
+
+// payerFees returns the fees paid by the payer, which are part of the total paid amount.
+func payerFees(p *payment.Response) money.Amount {
+	var fees money.Amount
+	for _, fd := range p.FeeDetails {
+		if fd.FeePayer == marketplace.FeePayerPayer {
+			fees = fees.Add(fd.Amount)
+		}
+	}
+	return fees
+}
+
+// collected reports whether the money of the payment was collected, so it must be in the report.
+func collected(p *payment.Response) bool {
+	switch p.Status {
+	case payment.StatusApproved, payment.StatusRefunded, payment.StatusChargedBack, payment.StatusInMediation:
+		return true
+	}
+	return false
+}
+
+func differ(a, b, tolerance money.Amount) bool {
+	return a.Sub(b).Abs().Cmp(tolerance) > 0
+}
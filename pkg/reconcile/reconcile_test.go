@@ -0,0 +1,155 @@
+package reconcile
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/money"
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+	"github.com/gdeandradero/sdk-go/pkg/payment"
+	"github.com/gdeandradero/sdk-go/pkg/report"
+)
+
+// pages returns a payment client serving the payments in pages of the requested size.
+func pages(payments ...string) payment.Client {
+	return payment.NewClient(&rest.Mock{
+		SendMock: func(req *http.Request, opts ...rest.Option) ([]byte, error) {
+			q := req.URL.Query()
+			if q.Get("range") != "money_release_date" || q.Get("begin_date") != "2024-01-01T00:00:00.000Z" {
+				return nil, fmt.Errorf("unexpected query %s", req.URL.RawQuery)
+			}
+			var limit, offset int
+			fmt.Sscan(q.Get("limit"), &limit)
+			fmt.Sscan(q.Get("offset"), &offset)
+			end := min(offset+limit, len(payments))
+			return []byte(fmt.Sprintf(`{"results":[%s],"paging":{"total":%d,"limit":%d,"offset":%d}}`,
+				strings.Join(payments[offset:end], ","), len(payments), limit, offset)), nil
+		},
+	})
+}
+
+func TestWalk(t *testing.T) {
+	pc := pages(`{"id":1}`, `{"id":2}`, `{"id":3}`)
+
+	var ids []int64
+	f := payment.Filters{Range: "money_release_date", BeginDate: "2024-01-01T00:00:00.000Z", Limit: 2}
+	err := Walk(context.Background(), pc, f, func(p *payment.Response) error {
+		ids = append(ids, p.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+	if !reflect.DeepEqual(ids, []int64{1, 2, 3}) {
+		t.Errorf("Walk() visited %v, want [1 2 3]", ids)
+	}
+}
+
+func TestRun(t *testing.T) {
+	pc := pages(
+		// matches its row
+		`{"id":1,"status":"approved","external_reference":"order-1","transaction_amount":100,
+			"transaction_details":{"total_paid_amount":100},"fee_details":[{"type":"mercadopago_fee","fee_payer":"collector","amount":4.99}]}`,
+		// financing fee paid by the payer missing from the total paid amount, and a different fee in the report
+		`{"id":2,"status":"approved","transaction_amount":200,"transaction_details":{"total_paid_amount":200},
+			"fee_details":[{"type":"mercadopago_fee","fee_payer":"collector","amount":9.98},{"type":"financing_fee","fee_payer":"payer","amount":12}]}`,
+		// refunded by the API, not in the report
+		`{"id":3,"status":"refunded","transaction_amount":50,"transaction_amount_refunded":50}`,
+		// approved but not released
+		`{"id":4,"status":"approved","transaction_amount":10}`,
+		// joined by external reference, with a different gross amount
+		`{"id":5,"status":"approved","external_reference":"order-5","transaction_amount":30}`,
+		// rejected, not expected in the report
+		`{"id":6,"status":"rejected","transaction_amount":30}`,
+	)
+	rows := []*report.Row{
+		{Line: 2, SourceID: "1", Description: "payment", GrossAmount: money.FromInt(100), MPFeeAmount: money.MustParse("-4.99")},
+		{Line: 3, SourceID: "1", Description: "refund", NetDebitAmount: money.FromInt(20)},
+		{Line: 4, SourceID: "2", Description: "payment", GrossAmount: money.FromInt(200), MPFeeAmount: money.MustParse("-10")},
+		{Line: 5, SourceID: "3", Description: "payment", GrossAmount: money.FromInt(50)},
+		{Line: 6, SourceID: "999", ExternalReference: "order-5", Description: "payment", GrossAmount: money.FromInt(31)},
+		{Line: 7, SourceID: "42", Description: "payment", GrossAmount: money.FromInt(7)},
+		{Line: 8, RecordType: "initial_available_balance", BalanceAmount: money.FromInt(1000)},
+	}
+
+	got, err := Run(context.Background(), pc, rows, Config{
+		Begin:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:      time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+		Range:    "money_release_date",
+		PageSize: 4,
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	var kinds []string
+	for _, d := range got.Discrepancies {
+		kinds = append(kinds, fmt.Sprintf("%d:%s:%s:%s:%s", d.PaymentID, d.Kind, d.Field, d.Expected, d.Actual))
+	}
+	want := []string{
+		"1:unexpected_refund:refunded_amount:0:20",
+		"2:amount_mismatch:total_paid_amount:212:200",
+		"2:fee_deviation:fee_amount:9.98:10",
+		"3:unexpected_refund:refunded_amount:50:0",
+		"4:missing_row::10:0",
+		"5:amount_mismatch:gross_amount:30:31",
+		"42:missing_payment::0:7",
+	}
+	if !reflect.DeepEqual(kinds, want) {
+		t.Errorf("Run() discrepancies = %v, want %v", kinds, want)
+	}
+	if got.Payments != 6 || got.Rows != 6 || got.Matched != 0 {
+		t.Errorf("Run() = %d payments, %d rows, %d matched, want 6, 6, 0", got.Payments, got.Rows, got.Matched)
+	}
+
+	got, err = Run(context.Background(), pc, rows, Config{
+		Begin:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Range:     "money_release_date",
+		Tolerance: money.FromInt(1),
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if got.Matched != 1 {
+		t.Errorf("Run() with tolerance matched %d, want 1", got.Matched)
+	}
+}
+
+func TestResultWrite(t *testing.T) {
+	r := &Result{
+		Discrepancies: []Discrepancy{
+			{Kind: MissingPayment, PaymentID: 42, Line: 7, Actual: money.FromInt(7), Message: "payment not found, \"42\""},
+			{Kind: FeeDeviation, PaymentID: 2, Field: "fee_amount", Expected: money.MustParse("9.98"), Actual: money.FromInt(10)},
+		},
+	}
+
+	var b bytes.Buffer
+	if err := r.WriteCSV(&b); err != nil {
+		t.Fatalf("Result.WriteCSV() error = %v", err)
+	}
+	want := "kind,payment_id,external_reference,line,field,expected,actual,message\n" +
+		"missing_payment,42,,7,,0,7,\"payment not found, \"\"42\"\"\"\n" +
+		"fee_deviation,2,,,fee_amount,9.98,10,\n"
+	if b.String() != want {
+		t.Errorf("Result.WriteCSV() = %q, want %q", b.String(), want)
+	}
+
+	b.Reset()
+	if err := r.WriteJSON(&b); err != nil {
+		t.Fatalf("Result.WriteJSON() error = %v", err)
+	}
+	var decoded Result
+	if err := json.Unmarshal(b.Bytes(), &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(decoded.Discrepancies, r.Discrepancies) {
+		t.Errorf("Result.WriteJSON() = %s", b.String())
+	}
+}
@@ -0,0 +1,44 @@
+package report
+
+// Config configures the generation of the reports of an account.
+// The flags are pointers so that UpdateConfig can turn them off: a nil flag is left unchanged, see Bool.
+type Config struct {
+	FileNamePrefix         string   `json:"file_name_prefix,omitempty"`
+	DisplayTimezone        string   `json:"display_timezone,omitempty"`
+	ReportTranslation      string   `json:"report_translation,omitempty"`
+	Separator              string   `json:"separator,omitempty"`
+	NotificationEmailList  []string `json:"notification_email_list,omitempty"`
+	IncludeWithdrawalAtEnd *bool    `json:"include_withdrawal_at_end,omitempty"`
+	ExecuteAfterWithdrawal *bool    `json:"execute_after_withdrawal,omitempty"`
+	Scheduled              *bool    `json:"scheduled,omitempty"`
+	ShowFeePrevision       *bool    `json:"show_fee_prevision,omitempty"`
+	ShowChargebackCancel   *bool    `json:"show_chargeback_cancel,omitempty"`
+	CouponDetailed         *bool    `json:"coupon_detailed,omitempty"`
+	RefundDetailed         *bool    `json:"refund_detailed,omitempty"`
+	ShippingDetail         *bool    `json:"shipping_detail,omitempty"`
+
+	Frequency *Frequency `json:"frequency,omitempty"`
+	Columns   []Column   `json:"columns,omitempty"`
+}
+
+// Frequency sets when scheduled reports are generated within Config.
+type Frequency struct {
+	// Type is "daily", "weekly" or "monthly".
+	Type string `json:"type,omitempty"`
+
+	// Value is the day of the week ("monday") or of the month (1 to 31), unused by daily reports.
+	Value any `json:"value,omitempty"`
+
+	// Hour is the hour of the day, from 0 to 23.
+	Hour int `json:"hour"`
+}
+
+// Column is a column of the reports within Config, e.g. {Key: "SOURCE_ID"}.
+type Column struct {
+	Key string `json:"key"`
+}
+
+// Bool returns a pointer to v, for the flags of Config, e.g. Config{Scheduled: report.Bool(false)}.
+func Bool(v bool) *bool {
+	return &v
+}
@@ -0,0 +1,211 @@
+// Package report contains the clients of the release and settlement reports,
+// and a reader that streams the rows of a downloaded report into typed structs.
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+)
+
+const (
+	releaseURL    = "https://api.mercadopago.com/v1/account/release_report"
+	settlementURL = "https://api.mercadopago.com/v1/account/settlement_report"
+
+	defaultPollInterval = 30 * time.Second
+)
+
+// Statuses of a report.
+const (
+	StatusPending    = "pending"
+	StatusProcessing = "processing"
+	StatusProcessed  = "processed"
+)
+
+// ErrFailed is matched, with errors.Is, by the error Wait returns when the report failed.
+var ErrFailed = errors.New("report failed")
+
+// Response is a report generated for an account.
+type Response struct {
+	ID          int64  `json:"id,omitempty"`
+	UserID      int64  `json:"user_id,omitempty"`
+	FileName    string `json:"file_name,omitempty"`
+	CreatedFrom string `json:"created_from,omitempty"`
+	Status      string `json:"status,omitempty"`
+	Format      string `json:"format,omitempty"`
+
+	BeginDate      *time.Time `json:"begin_date,omitempty"`
+	EndDate        *time.Time `json:"end_date,omitempty"`
+	DateCreated    *time.Time `json:"date_created,omitempty"`
+	GenerationDate *time.Time `json:"generation_date,omitempty"`
+
+	// Extra holds the fields of the response the SDK does not model.
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// Ready reports whether the report was generated and its file can be downloaded.
+func (r *Response) Ready() bool {
+	return r.FileName != "" && r.Status == StatusProcessed
+}
+
+// Failed reports whether the generation of the report ended without a file, e.g. with status "error".
+func (r *Response) Failed() bool {
+	switch r.Status {
+	case "", StatusPending, StatusProcessing, StatusProcessed:
+		return false
+	}
+	return true
+}
+
+// Client contains the methods to interact with the release or settlement reports API.
+type Client interface {
+	// GetConfig gets the report configuration of the account.
+	// It is a get request to the endpoint: {report}/config
+	GetConfig(opts ...rest.Option) (*Config, error)
+
+	// CreateConfig creates the report configuration of the account.
+	// It is a post request to the endpoint: {report}/config
+	CreateConfig(dto Config, opts ...rest.Option) (*Config, error)
+
+	// UpdateConfig updates the report configuration of the account.
+	// It is a put request to the endpoint: {report}/config
+	UpdateConfig(dto Config, opts ...rest.Option) (*Config, error)
+
+	// Create asks for a report of the period from begin to end. The report is generated asynchronously,
+	// see List and Wait.
+	// It is a post request to the endpoint: {report}
+	Create(begin, end time.Time, opts ...rest.Option) error
+
+	// List lists the reports of the account.
+	// It is a get request to the endpoint: {report}/list
+	List(opts ...rest.Option) ([]Response, error)
+
+	// Download downloads the file of a report, parse it with NewReader.
+	// It is a get request to the endpoint: {report}/{file_name}
+	Download(fileName string, opts ...rest.Option) ([]byte, error)
+}
+
+// client is the implementation of Client.
+type client struct {
+	rc   rest.Client
+	url  string
+	name string
+}
+
+// NewReleaseClient returns a new client of the release reports, https://api.mercadopago.com/v1/account/release_report,
+// which list the money released to the account.
+func NewReleaseClient(restClient rest.Client) Client {
+	return &client{
+		rc:   restClient,
+		url:  releaseURL,
+		name: "release_report",
+	}
+}
+
+// NewSettlementClient returns a new client of the settlement reports, https://api.mercadopago.com/v1/account/settlement_report,
+// which list the transactions settled in the account.
+func NewSettlementClient(restClient rest.Client) Client {
+	return &client{
+		rc:   restClient,
+		url:  settlementURL,
+		name: "settlement_report",
+	}
+}
+
+func (c *client) GetConfig(opts ...rest.Option) (*Config, error) {
+	return c.config(http.MethodGet, nil, "GetConfig", opts)
+}
+
+func (c *client) CreateConfig(dto Config, opts ...rest.Option) (*Config, error) {
+	return c.config(http.MethodPost, &dto, "CreateConfig", opts)
+}
+
+func (c *client) UpdateConfig(dto Config, opts ...rest.Option) (*Config, error) {
+	return c.config(http.MethodPut, &dto, "UpdateConfig", opts)
+}
+
+func (c *client) config(method string, dto *Config, operation string, opts []rest.Option) (*Config, error) {
+	return rest.Do[Config, Config](context.Background(), c.rc, method, c.url+"/config", nil, nil, dto,
+		append([]rest.Option{rest.WithOperation(c.name + "." + operation)}, opts...)...)
+}
+
+func (c *client) Create(begin, end time.Time, opts ...rest.Option) error {
+	dto := map[string]string{
+		"begin_date": begin.UTC().Format(time.RFC3339),
+		"end_date":   end.UTC().Format(time.RFC3339),
+	}
+	_, err := rest.Do[map[string]string, rest.NoBody](context.Background(), c.rc, http.MethodPost, c.url, nil, nil, &dto,
+		append([]rest.Option{rest.WithOperation(c.name + ".Create")}, opts...)...)
+	return err
+}
+
+func (c *client) List(opts ...rest.Option) ([]Response, error) {
+	res, err := rest.Do[rest.NoBody, []Response](context.Background(), c.rc, http.MethodGet, c.url+"/list", nil, nil, nil,
+		append([]rest.Option{rest.WithOperation(c.name + ".List")}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return *res, nil
+}
+
+// Download returns the file as is, a CSV or XLSX report rather than JSON, so it builds its request
+// instead of using rest.Do.
+func (c *client) Download(fileName string, opts ...rest.Option) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, c.url+"/"+url.PathEscape(fileName), nil)
+	if err != nil {
+		return nil, &rest.ErrorResponse{
+			StatusCode: http.StatusInternalServerError,
+			Message:    "error creating request: " + err.Error(),
+		}
+	}
+
+	return c.rc.Send(req, append([]rest.Option{rest.WithOperation(c.name + ".Download")}, opts...)...)
+}
+
+// Wait polls List every interval until the report of the period from begin to end is ready, and returns it.
+// A zero interval polls every 30 seconds. It stops when ctx is done, and with an error matching ErrFailed
+// when the report failed.
+func Wait(ctx context.Context, c Client, begin, end time.Time, interval time.Duration, opts ...rest.Option) (*Response, error) {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		reports, err := c.List(opts...)
+		if err != nil {
+			return nil, err
+		}
+		// the period may have been asked for before, so only its latest report counts.
+		var latest *Response
+		for i := range reports {
+			r := &reports[i]
+			if r.BeginDate != nil && r.EndDate != nil &&
+				r.BeginDate.Equal(begin.Truncate(time.Second)) && r.EndDate.Equal(end.Truncate(time.Second)) &&
+				(latest == nil || r.ID > latest.ID) {
+				latest = r
+			}
+		}
+		switch {
+		case latest == nil:
+		case latest.Ready():
+			return latest, nil
+		case latest.Failed():
+			return nil, fmt.Errorf("%w: report %d has status %s", ErrFailed, latest.ID, latest.Status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
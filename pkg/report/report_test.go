@@ -0,0 +1,175 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/money"
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+)
+
+const releaseReport = "\ufeffDATE;SOURCE_ID;EXTERNAL_REFERENCE;RECORD_TYPE;DESCRIPTION;NET_CREDIT_AMOUNT;NET_DEBIT_AMOUNT;GROSS_AMOUNT;MP_FEE_AMOUNT;INSTALLMENTS;PAYMENT_METHOD;STORE_ID\n" +
+	"2024-01-10T12:00:00.000-03:00;123456;order-1;release;payment;95.01;0.00;100.00;-4.99;1;pix;\n" +
+	"2024-01-11T09:30:00.000-03:00;123457;\"order;2\";release;refund;0.00;50.00;-50.00;0.00;1;visa;77\n"
+
+func TestReader(t *testing.T) {
+	r, err := NewReader(strings.NewReader(releaseReport))
+	if err != nil {
+		t.Fatalf("NewReader() error = %v", err)
+	}
+	if got := r.Header()[0]; got != "DATE" {
+		t.Errorf("Reader.Header()[0] = %q, want %q", got, "DATE")
+	}
+
+	rows, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("Reader.ReadAll() error = %v", err)
+	}
+	want := []*Row{
+		{
+			Line:              2,
+			Date:              time.Date(2024, 1, 10, 15, 0, 0, 0, time.UTC),
+			SourceID:          "123456",
+			ExternalReference: "order-1",
+			RecordType:        "release",
+			Description:       "payment",
+			NetCreditAmount:   money.MustParse("95.01"),
+			GrossAmount:       money.FromInt(100),
+			MPFeeAmount:       money.MustParse("-4.99"),
+			Installments:      1,
+			PaymentMethod:     "pix",
+			Extra:             map[string]string{"STORE_ID": ""},
+		},
+		{
+			Line:              3,
+			Date:              time.Date(2024, 1, 11, 12, 30, 0, 0, time.UTC),
+			SourceID:          "123457",
+			ExternalReference: "order;2",
+			RecordType:        "release",
+			Description:       "refund",
+			NetDebitAmount:    money.FromInt(50),
+			GrossAmount:       money.FromInt(-50),
+			Installments:      1,
+			PaymentMethod:     "visa",
+			Extra:             map[string]string{"STORE_ID": "77"},
+		},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("Reader.ReadAll() = %d rows, want %d", len(rows), len(want))
+	}
+	for i := range rows {
+		if !rows[i].Date.Equal(want[i].Date) {
+			t.Errorf("row %d Date = %v, want %v", i, rows[i].Date, want[i].Date)
+		}
+		rows[i].Date = want[i].Date
+		if !reflect.DeepEqual(rows[i], want[i]) {
+			t.Errorf("row %d = %+v, want %+v", i, rows[i], want[i])
+		}
+	}
+}
+
+func TestReaderInvalidAmount(t *testing.T) {
+	r, err := NewReader(strings.NewReader("SOURCE_ID,GROSS_AMOUNT\n1,abc\n"))
+	if err != nil {
+		t.Fatalf("NewReader() error = %v", err)
+	}
+	if _, err := r.Read(); err == nil || !strings.Contains(err.Error(), "report line 2, column GROSS_AMOUNT") {
+		t.Errorf("Reader.Read() error = %v, want an error on line 2", err)
+	}
+	if _, err := r.Read(); err != io.EOF {
+		t.Errorf("Reader.Read() error = %v, want io.EOF", err)
+	}
+}
+
+func TestWait(t *testing.T) {
+	begin := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC)
+
+	calls := 0
+	c := NewReleaseClient(&rest.Mock{
+		SendMock: func(req *http.Request, opts ...rest.Option) ([]byte, error) {
+			if req.URL.Path != "/v1/account/release_report/list" {
+				return nil, fmt.Errorf("unexpected path %s", req.URL.Path)
+			}
+			calls++
+			if calls == 1 {
+				return []byte(`[{"id":1,"begin_date":"2024-01-01T00:00:00Z","end_date":"2024-01-31T23:59:59Z","status":"pending"}]`), nil
+			}
+			// a failed report of the period asked for before does not stop the wait.
+			return []byte(`[{"id":1,"file_name":"release-1.csv","begin_date":"2024-01-01T00:00:00Z","end_date":"2024-01-31T23:59:59Z","status":"processed"},` +
+				`{"id":0,"begin_date":"2024-01-01T00:00:00Z","end_date":"2024-01-31T23:59:59Z","status":"error"}]`), nil
+		},
+	})
+
+	got, err := Wait(context.Background(), c, begin, end, time.Millisecond)
+	if err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if got.FileName != "release-1.csv" || calls != 2 {
+		t.Errorf("Wait() = %+v after %d calls, want release-1.csv after 2", got, calls)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := Wait(ctx, c, begin, begin, time.Millisecond); err != context.DeadlineExceeded {
+		t.Errorf("Wait() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	failed := NewReleaseClient(&rest.Mock{
+		SendMock: func(req *http.Request, opts ...rest.Option) ([]byte, error) {
+			return []byte(`[{"id":2,"file_name":"release-2.csv","begin_date":"2024-01-01T00:00:00Z","end_date":"2024-01-31T23:59:59Z","status":"error"}]`), nil
+		},
+	})
+	if _, err := Wait(context.Background(), failed, begin, end, time.Millisecond); !errors.Is(err, ErrFailed) {
+		t.Errorf("Wait() error = %v, want ErrFailed", err)
+	}
+}
+
+func TestClientUpdateConfig(t *testing.T) {
+	var body string
+	c := NewReleaseClient(&rest.Mock{
+		SendMock: func(req *http.Request, opts ...rest.Option) ([]byte, error) {
+			b, _ := io.ReadAll(req.Body)
+			body = string(b)
+			return b, nil
+		},
+	})
+
+	got, err := c.UpdateConfig(Config{Scheduled: Bool(false), ShowFeePrevision: Bool(true)})
+	if err != nil {
+		t.Fatalf("UpdateConfig() error = %v", err)
+	}
+	if want := `{"scheduled":false,"show_fee_prevision":true}`; body != want {
+		t.Errorf("UpdateConfig() body = %s, want %s", body, want)
+	}
+	if got.Scheduled == nil || *got.Scheduled || got.IncludeWithdrawalAtEnd != nil {
+		t.Errorf("UpdateConfig() = %+v, want scheduled false and include_withdrawal_at_end unset", got)
+	}
+}
+
+func TestClientCreate(t *testing.T) {
+	var body string
+	c := NewSettlementClient(&rest.Mock{
+		SendMock: func(req *http.Request, opts ...rest.Option) ([]byte, error) {
+			b, _ := io.ReadAll(req.Body)
+			body = req.Method + " " + req.URL.Path + " " + string(b)
+			return nil, nil
+		},
+	})
+
+	loc := time.FixedZone("BRT", -3*60*60)
+	if err := c.Create(time.Date(2024, 1, 1, 0, 0, 0, 0, loc), time.Date(2024, 1, 2, 0, 0, 0, 0, loc)); err != nil {
+		t.Fatalf("client.Create() error = %v", err)
+	}
+	want := `POST /v1/account/settlement_report {"begin_date":"2024-01-01T03:00:00Z","end_date":"2024-01-02T03:00:00Z"}`
+	if body != want {
+		t.Errorf("client.Create() sent %s, want %s", body, want)
+	}
+}
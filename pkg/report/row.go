@@ -0,0 +1,206 @@
+package report
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/money"
+)
+
+// Row is a row of a release or settlement report. Each report has its own columns,
+// the fields of the columns missing from a report are left zero.
+type Row struct {
+	// Line is the line of the row in the file, the header being line 1.
+	Line int
+
+	SourceID          string // SOURCE_ID, the ID of the payment, refund or other operation.
+	PaymentID         string // PAYMENT_ID
+	ExternalReference string // EXTERNAL_REFERENCE
+	RecordType        string // RECORD_TYPE, e.g. "release" or "initial_available_balance".
+	Description       string // DESCRIPTION, e.g. "payment" or "refund".
+	TransactionType   string // TRANSACTION_TYPE, e.g. "SETTLEMENT" or "REFUND".
+	PaymentMethod     string // PAYMENT_METHOD
+	PaymentMethodType string // PAYMENT_METHOD_TYPE
+	Currency          string // TRANSACTION_CURRENCY or SETTLEMENT_CURRENCY
+	Installments      int    // INSTALLMENTS
+
+	Date             time.Time // DATE
+	TransactionDate  time.Time // TRANSACTION_DATE
+	SettlementDate   time.Time // SETTLEMENT_DATE
+	MoneyReleaseDate time.Time // MONEY_RELEASE_DATE
+
+	TransactionAmount   money.Amount // TRANSACTION_AMOUNT
+	GrossAmount         money.Amount // GROSS_AMOUNT
+	FeeAmount           money.Amount // FEE_AMOUNT
+	MPFeeAmount         money.Amount // MP_FEE_AMOUNT
+	FinancingFeeAmount  money.Amount // FINANCING_FEE_AMOUNT
+	ShippingFeeAmount   money.Amount // SHIPPING_FEE_AMOUNT
+	TaxesAmount         money.Amount // TAXES_AMOUNT
+	CouponAmount        money.Amount // COUPON_AMOUNT
+	NetCreditAmount     money.Amount // NET_CREDIT_AMOUNT
+	NetDebitAmount      money.Amount // NET_DEBIT_AMOUNT
+	SettlementNetAmount money.Amount // SETTLEMENT_NET_AMOUNT
+	RealAmount          money.Amount // REAL_AMOUNT
+	BalanceAmount       money.Amount // BALANCE_AMOUNT
+
+	// Extra holds the columns that have no field, by name.
+	Extra map[string]string
+}
+
+// columns maps the column names to the functions setting the fields of Row.
+var columns = map[string]func(r *Row, v string) error{
+	"SOURCE_ID":            func(r *Row, v string) error { r.SourceID = v; return nil },
+	"PAYMENT_ID":           func(r *Row, v string) error { r.PaymentID = v; return nil },
+	"EXTERNAL_REFERENCE":   func(r *Row, v string) error { r.ExternalReference = v; return nil },
+	"RECORD_TYPE":          func(r *Row, v string) error { r.RecordType = v; return nil },
+	"DESCRIPTION":          func(r *Row, v string) error { r.Description = v; return nil },
+	"TRANSACTION_TYPE":     func(r *Row, v string) error { r.TransactionType = v; return nil },
+	"PAYMENT_METHOD":       func(r *Row, v string) error { r.PaymentMethod = v; return nil },
+	"PAYMENT_METHOD_TYPE":  func(r *Row, v string) error { r.PaymentMethodType = v; return nil },
+	"TRANSACTION_CURRENCY": func(r *Row, v string) error { r.Currency = v; return nil },
+	"SETTLEMENT_CURRENCY":  func(r *Row, v string) error { r.Currency = v; return nil },
+	"INSTALLMENTS":         intColumn(func(r *Row) *int { return &r.Installments }),
+
+	"DATE":               timeColumn(func(r *Row) *time.Time { return &r.Date }),
+	"TRANSACTION_DATE":   timeColumn(func(r *Row) *time.Time { return &r.TransactionDate }),
+	"SETTLEMENT_DATE":    timeColumn(func(r *Row) *time.Time { return &r.SettlementDate }),
+	"MONEY_RELEASE_DATE": timeColumn(func(r *Row) *time.Time { return &r.MoneyReleaseDate }),
+
+	"TRANSACTION_AMOUNT":    amountColumn(func(r *Row) *money.Amount { return &r.TransactionAmount }),
+	"GROSS_AMOUNT":          amountColumn(func(r *Row) *money.Amount { return &r.GrossAmount }),
+	"FEE_AMOUNT":            amountColumn(func(r *Row) *money.Amount { return &r.FeeAmount }),
+	"MP_FEE_AMOUNT":         amountColumn(func(r *Row) *money.Amount { return &r.MPFeeAmount }),
+	"FINANCING_FEE_AMOUNT":  amountColumn(func(r *Row) *money.Amount { return &r.FinancingFeeAmount }),
+	"SHIPPING_FEE_AMOUNT":   amountColumn(func(r *Row) *money.Amount { return &r.ShippingFeeAmount }),
+	"TAXES_AMOUNT":          amountColumn(func(r *Row) *money.Amount { return &r.TaxesAmount }),
+	"COUPON_AMOUNT":         amountColumn(func(r *Row) *money.Amount { return &r.CouponAmount }),
+	"NET_CREDIT_AMOUNT":     amountColumn(func(r *Row) *money.Amount { return &r.NetCreditAmount }),
+	"NET_DEBIT_AMOUNT":      amountColumn(func(r *Row) *money.Amount { return &r.NetDebitAmount }),
+	"SETTLEMENT_NET_AMOUNT": amountColumn(func(r *Row) *money.Amount { return &r.SettlementNetAmount }),
+	"REAL_AMOUNT":           amountColumn(func(r *Row) *money.Amount { return &r.RealAmount }),
+	"BALANCE_AMOUNT":        amountColumn(func(r *Row) *money.Amount { return &r.BalanceAmount }),
+}
+
+func intColumn(field func(r *Row) *int) func(r *Row, v string) error {
+	return func(r *Row, v string) error {
+		if v == "" {
+			return nil
+		}
+		n, err := strconv.Atoi(v)
+		*field(r) = n
+		return err
+	}
+}
+
+func amountColumn(field func(r *Row) *money.Amount) func(r *Row, v string) error {
+	return func(r *Row, v string) error {
+		if v == "" {
+			return nil
+		}
+		a, err := money.Parse(v)
+		*field(r) = a
+		return err
+	}
+}
+
+func timeColumn(field func(r *Row) *time.Time) func(r *Row, v string) error {
+	return func(r *Row, v string) error {
+		if v == "" {
+			return nil
+		}
+		t, err := time.Parse(time.RFC3339Nano, v)
+		*field(r) = t
+		return err
+	}
+}
+
+// Reader reads the rows of a report one by one, without loading the whole file.
+type Reader struct {
+	csv    *csv.Reader
+	header []string
+}
+
+// NewReader returns a reader of the report, reading its header.
+// The separator, a comma or a semicolon, is detected from the header.
+func NewReader(r io.Reader) (*Reader, error) {
+	br := bufio.NewReader(r)
+	comma := ','
+	if first, err := br.Peek(br.Size()); len(first) > 0 && (err == nil || err == io.EOF || err == bufio.ErrBufferFull) {
+		header, _, _ := bytes.Cut(first, []byte("\n"))
+		if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+			comma = ';'
+		}
+	}
+
+	cr := csv.NewReader(br)
+	cr.Comma = comma
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error reading report header: %w", err)
+	}
+	header = append([]string(nil), header...)
+	for i, h := range header {
+		header[i] = strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+	}
+
+	return &Reader{csv: cr, header: header}, nil
+}
+
+// Header returns the column names of the report.
+func (r *Reader) Header() []string {
+	return r.header
+}
+
+// Read returns the next row, or io.EOF at the end of the report.
+func (r *Reader) Read() (*Row, error) {
+	record, err := r.csv.Read()
+	if err != nil {
+		return nil, err
+	}
+	line, _ := r.csv.FieldPos(0)
+
+	row := &Row{Line: line}
+	for i, v := range record {
+		if i >= len(r.header) {
+			break
+		}
+		name := r.header[i]
+		v = strings.TrimSpace(v)
+		set, ok := columns[name]
+		if !ok {
+			if row.Extra == nil {
+				row.Extra = map[string]string{}
+			}
+			row.Extra[name] = v
+			continue
+		}
+		if err := set(row, v); err != nil {
+			return nil, fmt.Errorf("report line %d, column %s: %w", line, name, err)
+		}
+	}
+	return row, nil
+}
+
+// ReadAll reads the remaining rows.
+func (r *Reader) ReadAll() ([]*Row, error) {
+	var rows []*Row
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return rows, err
+		}
+		rows = append(rows, row)
+	}
+}
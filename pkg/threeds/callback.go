@@ -0,0 +1,149 @@
+package threeds
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+	"github.com/gdeandradero/sdk-go/pkg/payment"
+)
+
+const defaultCallbackTimeout = 10 * time.Second
+
+// Result is the outcome of a challenge.
+type Result string
+
+const (
+	// Approved means the payer completed the challenge and the payment was approved.
+	Approved Result = "approved"
+
+	// Rejected means the payment was rejected, e.g. because the payer failed or abandoned the challenge.
+	Rejected Result = "rejected"
+
+	// Pending means the payment did not leave the challenge before the timeout.
+	Pending Result = "pending"
+
+	// Failed means the payment could not be fetched.
+	Failed Result = "failed"
+)
+
+// Outcome is the final outcome of a challenge, reported by the callback handler.
+type Outcome struct {
+	PaymentID    int64                `json:"payment_id"`
+	Result       Result               `json:"result"`
+	Status       payment.Status       `json:"status,omitempty"`
+	StatusDetail payment.StatusDetail `json:"status_detail,omitempty"`
+
+	// Payment is the payment fetched after the challenge, nil if it could not be fetched.
+	Payment *payment.Response `json:"-"`
+
+	// Err is the error that prevented fetching the payment.
+	Err error `json:"-"`
+}
+
+// CallbackConfig configures the callback handler.
+type CallbackConfig struct {
+	// Payments fetches the payment.
+	Payments payment.Client
+
+	// PaymentID returns the ID of the payment of the callback request.
+	// By default it is read from the "payment_id" query or form parameter.
+	PaymentID func(r *http.Request) (int64, error)
+
+	// Timeout is how long to wait for the payment to leave the challenge, 10 seconds by default.
+	// The status of the payment may take a few seconds to change after the challenge.
+	Timeout time.Duration
+
+	// Options are passed to the calls of the Payments API, e.g. rest.WithAccessToken.
+	Options []rest.Option
+
+	// OnOutcome writes the response for the outcome. By default the outcome is written as JSON,
+	// with 502 Bad Gateway when the payment could not be fetched.
+	OnOutcome func(w http.ResponseWriter, r *http.Request, o *Outcome)
+}
+
+// CallbackHandler returns the handler of the challenge completion callback. It re-fetches the payment,
+// waiting up to the timeout for it to leave the challenge, and reports the final outcome.
+func CallbackHandler(config CallbackConfig) http.Handler {
+	if config.PaymentID == nil {
+		config.PaymentID = paymentIDParam
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultCallbackTimeout
+	}
+	if config.OnOutcome == nil {
+		config.OnOutcome = writeOutcome
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := config.PaymentID(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), config.Timeout)
+		defer cancel()
+		config.OnOutcome(w, r, Fetch(ctx, config.Payments, id, config.Options...))
+	})
+}
+
+// Fetch fetches the payment after its challenge, waiting until it leaves the challenge or ctx is done,
+// and returns its outcome.
+func Fetch(ctx context.Context, pc payment.Client, id int64, opts ...rest.Option) *Outcome {
+	// the interval may be overridden by opts, not the condition.
+	opts = append(append([]rest.Option{payment.WithWatchInterval(500*time.Millisecond, 2*time.Second)}, opts...),
+		payment.WithWatchUntil(func(p *payment.Response) bool { return !RequiresChallenge(p) }))
+
+	o := &Outcome{PaymentID: id, Result: Failed}
+	for ev := range pc.Watch(ctx, id, opts...) {
+		if ev.Err != nil {
+			o.Err = ev.Err
+			return o
+		}
+		o.Payment = ev.Payment
+	}
+	if o.Payment == nil {
+		o.Err = ctx.Err()
+		if o.Err == nil {
+			o.Err = errors.New("payment not fetched")
+		}
+		return o
+	}
+
+	o.Status = o.Payment.Status
+	o.StatusDetail = o.Payment.StatusDetail
+	switch {
+	case o.Status.IsApproved():
+		o.Result = Approved
+	case o.Status.IsRejected(), o.Status == payment.StatusCancelled:
+		o.Result = Rejected
+	default:
+		o.Result = Pending
+	}
+	return o
+}
+
+func paymentIDParam(r *http.Request) (int64, error) {
+	v := r.FormValue("payment_id")
+	id, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid payment_id %q", v)
+	}
+	return id, nil
+}
+
+func writeOutcome(w http.ResponseWriter, r *http.Request, o *Outcome) {
+	status := http.StatusOK
+	if o.Result == Failed {
+		status = http.StatusBadGateway
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(o)
+}
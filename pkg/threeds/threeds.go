@@ -0,0 +1,92 @@
+// Package threeds supports the 3-D Secure challenge flow of card payments: it detects the payments
+// waiting for a challenge, renders the form sending the payer to the challenge, and handles its completion.
+package threeds
+
+import (
+	"errors"
+	"html/template"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/gdeandradero/sdk-go/pkg/payment"
+)
+
+// Modes of payment.Request.ThreeDSecureMode.
+const (
+	// ModeNotSupported creates the payment without 3-D Secure, the default.
+	ModeNotSupported = "not_supported"
+
+	// ModeOptional lets Mercado Pago ask for a challenge when the issuer requires it.
+	ModeOptional = "optional"
+)
+
+// ErrNoChallenge is returned when a payment does not require a challenge.
+var ErrNoChallenge = errors.New("payment does not require a 3DS challenge")
+
+// RequiresChallenge reports whether the payment waits for the payer to complete a 3-D Secure challenge.
+func RequiresChallenge(p *payment.Response) bool {
+	return p != nil && p.Status == payment.StatusPending && p.StatusDetail == payment.StatusDetailPendingChallenge &&
+		p.ThreeDSInfo != nil && p.ThreeDSInfo.ExternalResourceURL != "" && p.ThreeDSInfo.Creq != ""
+}
+
+// Challenge is the challenge of a payment: its CReq must be posted to the URL by the payer's browser.
+type Challenge struct {
+	PaymentID int64
+	URL       string
+	Creq      string
+}
+
+// NewChallenge returns the challenge of a payment, or ErrNoChallenge.
+func NewChallenge(p *payment.Response) (*Challenge, error) {
+	if !RequiresChallenge(p) {
+		return nil, ErrNoChallenge
+	}
+	return &Challenge{
+		PaymentID: p.ID,
+		URL:       p.ThreeDSInfo.ExternalResourceURL,
+		Creq:      p.ThreeDSInfo.Creq,
+	}, nil
+}
+
+// formTemplate posts the CReq as soon as the page loads, with a button for browsers without JavaScript.
+// It is meant to be served in the page of the payer, or in an iframe of it.
+var formTemplate = template.Must(template.New("form").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>3-D Secure</title></head>
+<body onload="document.forms[0].submit()">
+<form method="post" action="{{.URL}}" enctype="application/x-www-form-urlencoded">
+<input type="hidden" name="creq" value="{{.Creq}}">
+<noscript><button type="submit">Continue</button></noscript>
+</form>
+</body>
+</html>
+`))
+
+// WriteForm writes an HTML page auto-submitting the CReq to the challenge URL.
+func (c *Challenge) WriteForm(w io.Writer) error {
+	return formTemplate.Execute(w, c)
+}
+
+// ServeHTTP serves the page of WriteForm, so a challenge can be mounted as a handler.
+func (c *Challenge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := c.WriteForm(w); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// RedirectURL returns the challenge URL with the CReq as the "creq" query parameter,
+// for the flows that redirect the payer instead of posting a form. Prefer the form where possible:
+// the CReq is then not exposed in the browser history.
+func (c *Challenge) RedirectURL() (string, error) {
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	q.Set("creq", c.Creq)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
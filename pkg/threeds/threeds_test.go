@@ -0,0 +1,131 @@
+package threeds
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gdeandradero/sdk-go/pkg/mp/rest"
+	"github.com/gdeandradero/sdk-go/pkg/payment"
+)
+
+const challenged = `{"id":1,"status":"pending","status_detail":"pending_challenge",` +
+	`"three_ds_info":{"external_resource_url":"https://acs.example.com/challenge?v=2","creq":"eyJ0\"><script>"}}`
+
+func TestNewChallenge(t *testing.T) {
+	var p payment.Response
+	if err := json.Unmarshal([]byte(challenged), &p); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := NewChallenge(&p)
+	if err != nil {
+		t.Fatalf("NewChallenge() error = %v", err)
+	}
+
+	var b strings.Builder
+	if err := c.WriteForm(&b); err != nil {
+		t.Fatalf("Challenge.WriteForm() error = %v", err)
+	}
+	form := b.String()
+	if !strings.Contains(form, `action="https://acs.example.com/challenge?v=2"`) ||
+		!strings.Contains(form, `name="creq" value="eyJ0&#34;&gt;&lt;script&gt;"`) {
+		t.Errorf("Challenge.WriteForm() = %s, want the escaped CReq posted to the challenge URL", form)
+	}
+
+	u, err := c.RedirectURL()
+	if want := "https://acs.example.com/challenge?creq=eyJ0%22%3E%3Cscript%3E&v=2"; err != nil || u != want {
+		t.Errorf("Challenge.RedirectURL() = %q, %v, want %q", u, err, want)
+	}
+
+	p.StatusDetail = payment.StatusDetailPendingWaitingPayment
+	if _, err := NewChallenge(&p); !errors.Is(err, ErrNoChallenge) {
+		t.Errorf("NewChallenge() error = %v, want %v", err, ErrNoChallenge)
+	}
+}
+
+func TestCallbackHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		responses  []string
+		wantCode   int
+		wantResult Result
+	}{
+		{
+			name:       "should_report_approved_after_challenge",
+			target:     "/3ds/callback?payment_id=1",
+			responses:  []string{challenged, challenged, `{"id":1,"status":"approved","status_detail":"accredited"}`},
+			wantCode:   http.StatusOK,
+			wantResult: Approved,
+		},
+		{
+			name:       "should_report_rejected",
+			target:     "/3ds/callback?payment_id=1",
+			responses:  []string{`{"id":1,"status":"rejected","status_detail":"cc_rejected_3ds_challenge"}`},
+			wantCode:   http.StatusOK,
+			wantResult: Rejected,
+		},
+		{
+			name:       "should_report_pending_on_timeout",
+			target:     "/3ds/callback?payment_id=1",
+			responses:  []string{challenged},
+			wantCode:   http.StatusOK,
+			wantResult: Pending,
+		},
+		{
+			name:       "should_report_failed_fetch",
+			target:     "/3ds/callback?payment_id=1",
+			wantCode:   http.StatusBadGateway,
+			wantResult: Failed,
+		},
+		{
+			name:     "should_fail_without_payment_id",
+			target:   "/3ds/callback",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			pc := payment.NewClient(&rest.Mock{
+				SendMock: func(req *http.Request, opts ...rest.Option) ([]byte, error) {
+					n := int(calls.Add(1)) - 1
+					if len(tt.responses) == 0 {
+						return nil, &rest.ErrorResponse{StatusCode: http.StatusNotFound, Message: "payment not found"}
+					}
+					return []byte(tt.responses[min(n, len(tt.responses)-1)]), nil
+				},
+			})
+			h := CallbackHandler(CallbackConfig{
+				Payments: pc,
+				Timeout:  time.Second,
+				Options:  []rest.Option{payment.WithWatchInterval(time.Millisecond, 5*time.Millisecond)},
+			})
+			if tt.wantResult == Pending {
+				h = CallbackHandler(CallbackConfig{Payments: pc, Timeout: 50 * time.Millisecond})
+			}
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, tt.target, nil))
+			if rec.Code != tt.wantCode {
+				t.Fatalf("CallbackHandler() code = %d, want %d: %s", rec.Code, tt.wantCode, rec.Body)
+			}
+			if tt.wantResult == "" {
+				return
+			}
+			var o Outcome
+			if err := json.Unmarshal(rec.Body.Bytes(), &o); err != nil {
+				t.Fatal(err)
+			}
+			if o.Result != tt.wantResult || o.PaymentID != 1 {
+				t.Errorf("CallbackHandler() outcome = %+v, want %s", o, tt.wantResult)
+			}
+		})
+	}
+}